@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/collectors"
+	"github.com/ctcac00/metrics-tui/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd checks which metrics can be collected on this machine
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Report which metrics are available on this system",
+	Long: `doctor runs every collector once and reports whether its metrics are
+available, unavailable (with the reason), or need more permissions.
+
+Use it to find out why a panel stays empty, and include its output in bug
+reports.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		appConfig, err = config.Load()
+		if err != nil {
+			cmd.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		runDoctor(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// readiness is the outcome of a doctor check
+type readiness string
+
+const (
+	readyAvailable   readiness = "available"
+	readyPartial     readiness = "partial"
+	readyUnavailable readiness = "unavailable"
+	readyPermission  readiness = "needs-permission"
+	readyDisabled    readiness = "disabled"
+)
+
+// doctorCheck runs one collector and decides whether its result is usable;
+// empty returns a reason when the collector succeeded but found nothing
+type doctorCheck struct {
+	name      string
+	collector collectors.Collector
+	empty     func(result any) string
+}
+
+// runDoctor prints the platform and a readiness line for every collector
+func runDoctor(cmd *cobra.Command) {
+	ctx := context.Background()
+
+	cmd.Println("=== metrics-tui doctor ===")
+	cmd.Println()
+	cmd.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	if os.Geteuid() == 0 {
+		cmd.Println("User:     root")
+	} else if os.Geteuid() > 0 {
+		cmd.Printf("User:     uid %d\n", os.Geteuid())
+	}
+	cmd.Println()
+
+	checks := []doctorCheck{
+		{"cpu", collectors.NewCPUCollector(1, appConfig.CPU.Logical, appConfig.CPU.Power, !appConfig.HostView), func(result any) string {
+			if m, ok := result.(*collectors.CPUMetrics); ok && len(m.Usage) == 0 {
+				return "no per-core usage reported"
+			}
+			return ""
+		}},
+		{"memory", collectors.NewMemoryCollector(1, appConfig.Memory.UsedBasis, !appConfig.HostView), nil},
+		{"disk", collectors.NewDiskCollector(1, nil, true, false, false, false), func(result any) string {
+			if m, ok := result.(*collectors.DiskMetrics); ok && len(m.Usage) == 0 {
+				return "no readable partitions found"
+			}
+			return ""
+		}},
+		{"network", collectors.NewNetworkCollector(1, nil, true, appConfig.Network.ExcludeLoopback), func(result any) string {
+			if m, ok := result.(*collectors.NetworkMetrics); ok && len(m.IO) == 0 {
+				return "no active interfaces found"
+			}
+			return ""
+		}},
+		{"sensors", collectors.NewSensorsCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.SensorMetrics); ok && len(m.Temperatures) == 0 {
+				return "no temperature sensors found (virtual machine or missing drivers?)"
+			}
+			return ""
+		}},
+		{"host", collectors.NewHostCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.HostMetrics); ok && m.LoadAvg == nil {
+				return "load average not supported on this platform"
+			}
+			return ""
+		}},
+		{"processes", collectors.NewProcessCollector(1, 5), func(result any) string {
+			if m, ok := result.(*collectors.ProcessMetrics); ok && len(m.Processes) == 0 {
+				return "no readable processes found"
+			}
+			return ""
+		}},
+		{"gpu", collectors.NewGPUCollector(1, false), func(result any) string {
+			if m, ok := result.(*collectors.GPUMetrics); ok && len(m.GPUs) == 0 {
+				return "no supported GPU found (nvidia-smi not installed, or no i915 device?)"
+			}
+			return ""
+		}},
+		{"containers", collectors.NewContainerCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.ContainerMetrics); ok && len(m.Runtimes) == 0 {
+				return "no container runtime found (no Docker or Podman socket, no containerd state)"
+			}
+			return ""
+		}},
+		{"bandwidth", collectors.NewBandwidthCollector(1, appConfig.Process.Limit), func(result any) string {
+			if m, ok := result.(*collectors.BandwidthMetrics); ok && len(m.Processes) == 0 {
+				return "no processes with TCP connections found"
+			}
+			return ""
+		}},
+		{"connections", collectors.NewConnectionCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.ConnectionMetrics); ok && len(m.Connections) == 0 {
+				return "no TCP or UDP sockets found"
+			}
+			return ""
+		}},
+		{"services", collectors.NewServiceCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.ServiceMetrics); ok && len(m.Services) == 0 {
+				return "no active systemd services found"
+			}
+			return ""
+		}},
+		{"kubernetes", collectors.NewKubernetesCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.KubernetesMetrics); ok && !m.Configured {
+				return "no kubeconfig or in-cluster service account found"
+			}
+			return ""
+		}},
+		{"vms", collectors.NewVMCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.VMMetrics); ok && m.URI == "" {
+				return "virsh not found (needs libvirt)"
+			}
+			return ""
+		}},
+		{"rpi", collectors.NewPiCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.PiMetrics); ok && m.Model == "" {
+				return "not a Raspberry Pi"
+			}
+			return ""
+		}},
+		{"ping", collectors.NewPingCollector(1, appConfig.Network.PingTargets), func(result any) string {
+			if m, ok := result.(*collectors.PingMetrics); ok && len(m.Targets) == 0 {
+				return "no network.ping_targets configured"
+			}
+			return ""
+		}},
+		{"certs", collectors.NewCertCollector(1, appConfig.Network.TLSEndpoints), func(result any) string {
+			if len(appConfig.Network.TLSEndpoints) == 0 {
+				return "no network.tls_endpoints configured"
+			}
+			return ""
+		}},
+		{"clock", collectors.NewClockCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.ClockMetrics); ok && m.Source == "" {
+				return "no chrony, ntpd or timesyncd found"
+			}
+			return ""
+		}},
+		{"conntrack", collectors.NewConntrackCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.ConntrackMetrics); ok && m.Max == 0 {
+				return "nf_conntrack not loaded"
+			}
+			return ""
+		}},
+		{"ups", collectors.NewUPSCollector(1, appConfig.UPS.Address), func(result any) string {
+			if m, ok := result.(*collectors.UPSMetrics); ok && len(m.UPSes) == 0 {
+				return "no UPS found at NUT server " + m.Server
+			}
+			return ""
+		}},
+		{"updates", collectors.NewUpdatesCollector(1), func(result any) string {
+			if m, ok := result.(*collectors.UpdatesMetrics); ok && m.Manager == "" {
+				return "no apt, dnf or pacman found"
+			}
+			return ""
+		}},
+	}
+	for _, script := range appConfig.Collectors.Scripts {
+		checks = append(checks, doctorCheck{
+			name: script.Name,
+			collector: collectors.NewScriptCollector(collectors.ScriptConfig{
+				Name:     script.Name,
+				Command:  script.Command,
+				Interval: uint(script.Interval.Seconds()),
+				Unit:     script.Unit,
+			}),
+		})
+	}
+
+	disabled := make(map[string]bool)
+	for _, name := range appConfig.Collectors.Disabled {
+		disabled[name] = true
+	}
+
+	for _, check := range checks {
+		if disabled[check.name] {
+			printReadiness(cmd, check.name, readyDisabled, "turned off in collectors.disabled")
+			continue
+		}
+		if check.name == "updates" && !appConfig.Updates.Enabled {
+			printReadiness(cmd, check.name, readyDisabled, "off unless updates.enabled is set")
+			continue
+		}
+
+		result, err := check.collector.Collect(ctx)
+		state, reason := classifyCollection(result, err)
+		if state == readyAvailable && check.empty != nil {
+			if why := check.empty(result); why != "" {
+				state, reason = readyUnavailable, why
+			}
+		}
+		printReadiness(cmd, check.name, state, reason)
+	}
+}
+
+// classifyCollection sorts a collection result into a readiness state
+func classifyCollection(result any, err error) (readiness, string) {
+	if err == nil {
+		return readyAvailable, ""
+	}
+
+	state := readyUnavailable
+	var partial *data.PartialError
+	if errors.As(err, &partial) && result != nil {
+		state = readyPartial
+	}
+
+	if errors.Is(err, fs.ErrPermission) || strings.Contains(strings.ToLower(err.Error()), "permission denied") {
+		return readyPermission, err.Error()
+	}
+	if strings.Contains(err.Error(), "not implemented") {
+		return readyUnavailable, "not supported on " + runtime.GOOS
+	}
+	return state, err.Error()
+}
+
+// printReadiness prints one aligned line of the doctor report
+func printReadiness(cmd *cobra.Command, name string, state readiness, reason string) {
+	if reason == "" {
+		cmd.Printf("  %-12s %s\n", name, state)
+		return
+	}
+	cmd.Printf("  %-12s %-16s %s\n", name, state, reason)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -48,9 +49,25 @@ Built with Bubble Tea for a beautiful, responsive TUI experience.`,
 			return
 		}
 
+		if viper.GetBool("top") {
+			appConfig.UI.Mode = "top"
+		}
+		if viper.GetBool("compact-line") {
+			appConfig.UI.Mode = "compact"
+		}
+		if viper.GetBool("utc") {
+			appConfig.Display.Timezone = "UTC"
+		}
+
 		// Launch the TUI
-		model := ui.NewModel()
-		p := tea.NewProgram(model, tea.WithAltScreen())
+		model := ui.NewModel(appConfig)
+		options := []tea.ProgramOption{tea.WithAltScreen()}
+		if appConfig.UI.Mouse {
+			// Mouse reporting takes over the terminal's own text selection
+			// (Shift+drag still selects in most terminals)
+			options = append(options, tea.WithMouseCellMotion())
+		}
+		p := tea.NewProgram(model, options...)
 		if _, err := p.Run(); err != nil {
 			cmd.Printf("Error running TUI: %v\n", err)
 			os.Exit(1)
@@ -84,16 +101,44 @@ func init() {
 	// Flag: debug
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Enable debug logging")
 
+	// Flag: debug overlay
+	rootCmd.PersistentFlags().Bool("debug-overlay", false, "Enable the collector health overlay (D)")
+
 	// Flag: precision
 	rootCmd.PersistentFlags().IntP("precision", "p", 1, "Decimal places for values (0-3)")
 
+	// Flag: top mode
+	rootCmd.PersistentFlags().Bool("top", false, "Show a top-style summary and process table")
+
+	// Flag: compact line mode
+	rootCmd.PersistentFlags().Bool("compact-line", false, "Show one line per metric, for tmux panes")
+
+	// Flag: low power profile
+	rootCmd.PersistentFlags().Bool("low-power", false, "Refresh less often and skip sparklines to save battery")
+
+	// Flag: anonymized snapshots
+	rootCmd.PersistentFlags().Bool("anonymize", false, "Redact hostname, IPs, and serials in snapshots and exports")
+
+	// Flag: UTC timestamps
+	rootCmd.PersistentFlags().Bool("utc", false, "Show all timestamps in UTC")
+
+	// Flag: host view
+	rootCmd.PersistentFlags().Bool("host-view", false, "Show host CPU and memory totals instead of the container's cgroup limits")
+
 	// Bind flags to viper
 	viper.BindPFlag("refresh", rootCmd.PersistentFlags().Lookup("refresh"))
 	viper.BindPFlag("theme", rootCmd.PersistentFlags().Lookup("theme"))
 	viper.BindPFlag("display.no_graphs", rootCmd.PersistentFlags().Lookup("no-graphs"))
 	viper.BindPFlag("list-disks", rootCmd.PersistentFlags().Lookup("list-disks"))
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	viper.BindPFlag("debug_overlay", rootCmd.PersistentFlags().Lookup("debug-overlay"))
 	viper.BindPFlag("display.precision", rootCmd.PersistentFlags().Lookup("precision"))
+	viper.BindPFlag("top", rootCmd.PersistentFlags().Lookup("top"))
+	viper.BindPFlag("compact-line", rootCmd.PersistentFlags().Lookup("compact-line"))
+	viper.BindPFlag("utc", rootCmd.PersistentFlags().Lookup("utc"))
+	viper.BindPFlag("low_power", rootCmd.PersistentFlags().Lookup("low-power"))
+	viper.BindPFlag("host_view", rootCmd.PersistentFlags().Lookup("host-view"))
+	viper.BindPFlag("snapshot.anonymize", rootCmd.PersistentFlags().Lookup("anonymize"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -102,12 +147,11 @@ func initConfig() {
 		// Use config file from the flag
 		viper.SetConfigFile(cfgFile)
 	} else {
-		// Find home directory
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
-
-		// Search config in home directory
-		viper.AddConfigPath(home+"/.config/metrics-tui")
+		// Search config in the home directory, if there is one; without
+		// HOME only the working directory is searched
+		if home, err := os.UserHomeDir(); err == nil && home != "" {
+			viper.AddConfigPath(home + "/.config/metrics-tui")
+		}
 		viper.AddConfigPath(".")
 		viper.SetConfigType("yaml")
 		viper.SetConfigName("config")
@@ -124,13 +168,14 @@ func initConfig() {
 // listAvailableDisks lists available disk partitions
 func listAvailableDisks(cmd *cobra.Command) {
 	ctx := context.Background()
-	diskCollector := collectors.NewDiskCollector(1, nil, true)
+	diskCollector := collectors.NewDiskCollector(1, nil, true, false, false, false)
 
 	data, err := diskCollector.Collect(ctx)
-	if err != nil {
+	if data == nil {
 		cmd.Printf("Error collecting disk info: %v\n", err)
 		return
 	}
+	printPartialError(cmd, err)
 
 	metrics, ok := data.(*collectors.DiskMetrics)
 	if !ok {
@@ -145,18 +190,39 @@ func listAvailableDisks(cmd *cobra.Command) {
 	}
 }
 
+// printPartialError reports items a collector could not read, if any
+func printPartialError(cmd *cobra.Command, err error) {
+	if err != nil {
+		cmd.Printf("  Partial: %v\n", err)
+	}
+}
+
 // testCollectors tests all collectors and prints their data
 func testCollectors(cmd *cobra.Command) {
 	ctx := context.Background()
-	cmd.Println("\n=== Testing Collectors ===\n")
+	cmd.Println("\n=== Testing Collectors ===")
+	cmd.Println()
 
 	// Test CPU collector
 	cmd.Println("CPU Collector:")
-	cpuCollector := collectors.NewCPUCollector(1)
-	if data, err := cpuCollector.Collect(ctx); err == nil {
+	cpuCollector := collectors.NewCPUCollector(1, appConfig.CPU.Logical, appConfig.CPU.Power, !appConfig.HostView)
+	if data, err := cpuCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
 		if metrics, ok := data.(*collectors.CPUMetrics); ok {
 			cmd.Printf("  Cores: %d\n", metrics.CoreCount)
+			if metrics.LoadCores != metrics.CoreCount {
+				cmd.Printf("  Physical Cores: %d\n", metrics.LoadCores)
+			}
+			if len(metrics.Offline) > 0 {
+				cmd.Printf("  Offline Cores: %v\n", metrics.Offline)
+			}
 			cmd.Printf("  Total Usage: %.1f%%\n", metrics.Total)
+			if metrics.CgroupCores > 0 {
+				cmd.Printf("  Cgroup Quota: %.2f cores (usage is relative to it)\n", metrics.CgroupCores)
+			}
+			for _, power := range metrics.Power {
+				cmd.Printf("  Power (%s): %.1f W\n", power.Domain, power.Watts)
+			}
 		}
 	} else {
 		cmd.Printf("  Error: %v\n", err)
@@ -164,12 +230,20 @@ func testCollectors(cmd *cobra.Command) {
 
 	// Test Memory collector
 	cmd.Println("\nMemory Collector:")
-	memCollector := collectors.NewMemoryCollector(1)
-	if data, err := memCollector.Collect(ctx); err == nil {
+	memCollector := collectors.NewMemoryCollector(1, appConfig.Memory.UsedBasis, !appConfig.HostView)
+	if data, err := memCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
 		if metrics, ok := data.(*collectors.MemoryMetrics); ok {
 			cmd.Printf("  Total: %s\n", formatBytes(metrics.Total))
+			if metrics.Cgroup {
+				cmd.Println("  (cgroup memory limit)")
+			}
 			cmd.Printf("  Used: %s (%.1f%%)\n", formatBytes(metrics.Used), metrics.UsedPercent)
 			cmd.Printf("  Available: %s\n", formatBytes(metrics.Available))
+			for _, node := range metrics.NUMA {
+				cmd.Printf("    node%d: %s / %s, numa_miss %d, numa_foreign %d\n",
+					node.ID, formatBytes(node.Used), formatBytes(node.Total), node.Miss, node.Foreign)
+			}
 		}
 	} else {
 		cmd.Printf("  Error: %v\n", err)
@@ -177,12 +251,30 @@ func testCollectors(cmd *cobra.Command) {
 
 	// Test Disk collector
 	cmd.Println("\nDisk Collector:")
-	diskCollector := collectors.NewDiskCollector(1, nil, true)
-	if data, err := diskCollector.Collect(ctx); err == nil {
+	diskCollector := collectors.NewDiskCollector(1, nil, true, appConfig.Disk.ShowDeviceInfo, appConfig.Disk.ShowTemperature, appConfig.Disk.ShowHealth)
+	if data, err := diskCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
 		if metrics, ok := data.(*collectors.DiskMetrics); ok {
 			cmd.Printf("  Partitions: %d\n", len(metrics.Partitions))
 			for mount, usage := range metrics.Usage {
 				cmd.Printf("    %s: %s used (%.1f%%)\n", mount, formatBytes(usage.Used), usage.UsedPercent)
+				if device := metrics.Devices[mount]; device != "" {
+					cmd.Printf("      Device: %s\n", device)
+				}
+				if temp, ok := metrics.Temps[mount]; ok {
+					cmd.Printf("      Temperature: %.0f°C\n", temp)
+				}
+				if health, ok := metrics.Health[mount]; ok {
+					cmd.Printf("      Health: %d%% used, %d%% spare, %d media errors\n",
+						health.PercentUsed, health.AvailableSpare, health.MediaErrors)
+				}
+			}
+			for _, array := range metrics.RAID {
+				cmd.Printf("  RAID %s: %s %s, %d/%d active [%s]\n",
+					array.Name, array.State, array.Level, array.Active, array.Devices, array.Status)
+				if array.Sync != "" {
+					cmd.Printf("    %s %.1f%% (finish %s)\n", array.Sync, array.SyncPercent, array.SyncFinish)
+				}
 			}
 		}
 	} else {
@@ -191,13 +283,20 @@ func testCollectors(cmd *cobra.Command) {
 
 	// Test Network collector
 	cmd.Println("\nNetwork Collector:")
-	netCollector := collectors.NewNetworkCollector(1, nil, true)
-	if data, err := netCollector.Collect(ctx); err == nil {
+	netCollector := collectors.NewNetworkCollector(1, nil, true, appConfig.Network.ExcludeLoopback)
+	if data, err := netCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
 		if metrics, ok := data.(*collectors.NetworkMetrics); ok {
 			cmd.Printf("  Interfaces: %d\n", len(metrics.Interfaces))
 			for name, io := range metrics.IO {
 				cmd.Printf("    %s: RX %s, TX %s\n", name, formatBytes(io.BytesRecv), formatBytes(io.BytesSent))
 			}
+			for name, wifi := range metrics.Wireless {
+				cmd.Printf("    %s: Wi-Fi %q, %.0f dBm, %.0f Mbit/s, channel %d\n", name, wifi.SSID, wifi.Signal, wifi.Bitrate, wifi.Channel)
+			}
+			for name, link := range metrics.Links {
+				cmd.Printf("    %s: link %s, speed %d Mb/s, duplex %q\n", name, link.OperState, link.Speed, link.Duplex)
+			}
 		}
 	} else {
 		cmd.Printf("  Error: %v\n", err)
@@ -206,12 +305,16 @@ func testCollectors(cmd *cobra.Command) {
 	// Test Sensors collector
 	cmd.Println("\nSensors Collector:")
 	sensorCollector := collectors.NewSensorsCollector(1)
-	if data, err := sensorCollector.Collect(ctx); err == nil {
+	if data, err := sensorCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
 		if metrics, ok := data.(*collectors.SensorMetrics); ok {
 			cmd.Printf("  Temperatures: %d\n", len(metrics.Temperatures))
 			for _, temp := range metrics.Temperatures {
 				cmd.Printf("    %s: %.1f°C\n", temp.SensorKey, temp.Temperature)
 			}
+			for _, psu := range metrics.PowerSupplies {
+				cmd.Printf("  Power supply %s: %s\n", psu.Name, psu.Status)
+			}
 		}
 	} else {
 		cmd.Printf("  Error: %v\n", err)
@@ -220,30 +323,352 @@ func testCollectors(cmd *cobra.Command) {
 	// Test Host collector
 	cmd.Println("\nHost Collector:")
 	hostCollector := collectors.NewHostCollector(1)
-	if data, err := hostCollector.Collect(ctx); err == nil {
+	if data, err := hostCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
 		if metrics, ok := data.(*collectors.HostMetrics); ok {
 			cmd.Printf("  Hostname: %s\n", metrics.Info.Hostname)
 			cmd.Printf("  Uptime: %s\n", formatDuration(time.Duration(metrics.Info.Uptime)*time.Second))
 			if metrics.LoadAvg != nil {
 				cmd.Printf("  Load Average: %.2f %.2f %.2f\n", metrics.LoadAvg.Load1, metrics.LoadAvg.Load5, metrics.LoadAvg.Load15)
 			}
+			if metrics.Entropy != nil {
+				cmd.Printf("  Entropy: %d / %d bits\n", metrics.Entropy.Available, metrics.Entropy.PoolSize)
+			}
+			cmd.Printf("  Sessions: %d\n", len(metrics.Users))
+			for _, user := range metrics.Users {
+				cmd.Printf("    %s on %s from %s\n", user.User, user.Terminal, user.Host)
+			}
 		}
 	} else {
 		cmd.Printf("  Error: %v\n", err)
 	}
 
-	cmd.Println("\n=== Testing Aggregator ===\n")
+	// Test Process collector
+	cmd.Println("\nProcess Collector:")
+	processCollector := collectors.NewProcessCollector(1, 5)
+	if data, err := processCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.ProcessMetrics); ok {
+			cmd.Printf("  Processes: %d\n", metrics.Total)
+			for _, p := range metrics.Processes {
+				cmd.Printf("    %7d %-20s CPU %5.1f%%  Mem %5.1f%%\n", p.PID, p.Name, p.CPU, p.Memory)
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test GPU collector
+	cmd.Println("\nGPU Collector:")
+	gpuCollector := collectors.NewGPUCollector(1, true)
+	if data, err := gpuCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.GPUMetrics); ok {
+			cmd.Printf("  GPUs: %d\n", len(metrics.GPUs))
+			for _, gpu := range metrics.GPUs {
+				cmd.Printf("    %d %s: %.0f%% busy, %s / %s VRAM\n", gpu.Index, gpu.Name, gpu.Utilization, formatBytes(gpu.MemoryUsed), formatBytes(gpu.MemoryTotal))
+			}
+			for _, process := range metrics.Processes {
+				cmd.Printf("    PID %d %s: %.0f%% busy, %s VRAM\n", process.PID, process.Name, process.Utilization, formatBytes(process.MemoryUsed))
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test Container collector
+	cmd.Println("\nContainer Collector:")
+	containerCollector := collectors.NewContainerCollector(1)
+	if data, err := containerCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.ContainerMetrics); ok {
+			if len(metrics.Runtimes) == 0 {
+				cmd.Println("  No container runtime found")
+			} else {
+				cmd.Printf("  Runtimes: %s, %d running\n", strings.Join(metrics.Runtimes, ", "), len(metrics.Containers))
+				for _, container := range metrics.Containers {
+					cmd.Printf("    %s %-10s %-20s Mem %s\n", container.ID, container.Runtime, container.Name, formatBytes(container.MemoryUsed))
+				}
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test Bandwidth collector
+	cmd.Println("\nBandwidth Collector:")
+	bandwidthCollector := collectors.NewBandwidthCollector(1, 5)
+	if data, err := bandwidthCollector.Collect(ctx); data != nil {
+		if metrics, ok := data.(*collectors.BandwidthMetrics); ok {
+			cmd.Printf("  Processes with TCP connections: %d\n", len(metrics.Processes))
+			for _, p := range metrics.Processes {
+				cmd.Printf("    %7d %-20s %d connections\n", p.PID, p.Name, p.Connections)
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test Connection collector
+	cmd.Println("\nConnection Collector:")
+	connectionCollector := collectors.NewConnectionCollector(1)
+	if data, err := connectionCollector.Collect(ctx); data != nil {
+		if metrics, ok := data.(*collectors.ConnectionMetrics); ok {
+			cmd.Printf("  Sockets: %d\n", len(metrics.Connections))
+			for state, count := range metrics.States {
+				cmd.Printf("    %-12s %d\n", state, count)
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test Service collector
+	cmd.Println("\nService Collector:")
+	serviceCollector := collectors.NewServiceCollector(1)
+	if data, err := serviceCollector.Collect(ctx); data != nil {
+		if metrics, ok := data.(*collectors.ServiceMetrics); ok {
+			cmd.Printf("  Services: %d, failed units: %d\n", len(metrics.Services), metrics.Failed)
+			for _, service := range metrics.Services {
+				if service.ActiveState == "failed" {
+					cmd.Printf("    %s: %s\n", service.Name, service.SubState)
+				}
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test Kubernetes collector
+	cmd.Println("\nKubernetes Collector:")
+	kubernetesCollector := collectors.NewKubernetesCollector(1)
+	if data, err := kubernetesCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.KubernetesMetrics); ok {
+			if !metrics.Configured {
+				cmd.Println("  No cluster configured")
+			} else {
+				cmd.Printf("  Node: %s, pods: %d\n", metrics.Node, len(metrics.Pods))
+				for _, pod := range metrics.Pods {
+					cmd.Printf("    %s/%s: %s, CPU %.3f / %.3f cores, memory %s / %s\n",
+						pod.Namespace, pod.Name, pod.Phase, pod.CPUUsage, pod.CPURequest,
+						formatBytes(pod.MemoryUsage), formatBytes(pod.MemoryRequest))
+				}
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test VM collector
+	cmd.Println("\nVM Collector:")
+	vmCollector := collectors.NewVMCollector(1)
+	if data, err := vmCollector.Collect(ctx); data != nil {
+		if metrics, ok := data.(*collectors.VMMetrics); ok {
+			if metrics.URI == "" {
+				cmd.Println("  virsh not found")
+			} else {
+				cmd.Printf("  %s: %d domains\n", metrics.URI, len(metrics.VMs))
+				for _, vm := range metrics.VMs {
+					cmd.Printf("    %s: %s, %d vCPUs, memory %s / %s\n",
+						vm.Name, vm.State, vm.VCPUs, formatBytes(vm.MemoryUsed), formatBytes(vm.MemoryMax))
+				}
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test Raspberry Pi collector
+	cmd.Println("\nRaspberry Pi Collector:")
+	piCollector := collectors.NewPiCollector(1)
+	if data, err := piCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.PiMetrics); ok {
+			if metrics.Model == "" {
+				cmd.Println("  Not a Raspberry Pi")
+			} else {
+				cmd.Printf("  %s\n", metrics.Model)
+				cmd.Printf("  SoC: %.1f°C, core %.2f V, ARM %d MHz\n",
+					metrics.SoCTemp, metrics.CoreVolts, metrics.ARMClock/1_000_000)
+				if metrics.HasThrottled {
+					cmd.Printf("  Throttled: 0x%x\n", metrics.Throttled)
+				}
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test ping collector
+	cmd.Println("\nPing Collector:")
+	pingCollector := collectors.NewPingCollector(1, appConfig.Network.PingTargets)
+	if data, err := pingCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.PingMetrics); ok {
+			if len(metrics.Targets) == 0 {
+				cmd.Println("  No network.ping_targets configured")
+			}
+			for _, target := range metrics.Targets {
+				cmd.Printf("  %s: %.1f ms, %.0f%% loss\n", target.Host, target.RTT, target.Loss)
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test certificate collector
+	cmd.Println("\nCertificate Collector:")
+	certCollector := collectors.NewCertCollector(1, appConfig.Network.TLSEndpoints)
+	if data, err := certCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.CertMetrics); ok {
+			if len(appConfig.Network.TLSEndpoints) == 0 {
+				cmd.Println("  No network.tls_endpoints configured")
+			}
+			for _, cert := range metrics.Certs {
+				cmd.Printf("  %s: %s, issued by %s, expires %s (verified: %t)\n",
+					cert.Endpoint, cert.Subject, cert.Issuer, cert.NotAfter.Format(time.RFC3339), cert.Verified)
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test clock collector
+	cmd.Println("\nClock Collector:")
+	clockCollector := collectors.NewClockCollector(1)
+	if data, err := clockCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.ClockMetrics); ok {
+			if metrics.Source == "" {
+				cmd.Println("  No chrony, ntpd or timesyncd found")
+			} else {
+				cmd.Printf("  Source: %s, synchronized: %v\n", metrics.Source, metrics.Synced)
+				if metrics.HasOffset {
+					cmd.Printf("  Offset: %+.3f ms\n", metrics.Offset)
+				}
+				if metrics.Server != "" {
+					cmd.Printf("  Server: %s (stratum %d)\n", metrics.Server, metrics.Stratum)
+				}
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test conntrack collector
+	cmd.Println("\nConntrack Collector:")
+	conntrackCollector := collectors.NewConntrackCollector(1)
+	if data, err := conntrackCollector.Collect(ctx); err == nil {
+		if metrics, ok := data.(*collectors.ConntrackMetrics); ok {
+			if metrics.Max == 0 {
+				cmd.Println("  nf_conntrack not loaded")
+			} else {
+				cmd.Printf("  Tracked: %d / %d (%.1f%%), dropped: %d\n",
+					metrics.Count, metrics.Max, float64(metrics.Count)/float64(metrics.Max)*100, metrics.Drops)
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test UPS collector
+	cmd.Println("\nUPS Collector:")
+	upsCollector := collectors.NewUPSCollector(1, appConfig.UPS.Address)
+	if data, err := upsCollector.Collect(ctx); data != nil {
+		printPartialError(cmd, err)
+		if metrics, ok := data.(*collectors.UPSMetrics); ok {
+			if len(metrics.UPSes) == 0 {
+				cmd.Printf("  No UPS found at NUT server %s\n", metrics.Server)
+			}
+			for _, ups := range metrics.UPSes {
+				cmd.Printf("  %s (%s): status %s, charge %.0f%%, load %.0f%%, runtime %s\n",
+					ups.Name, ups.Model, ups.Status, ups.Charge, ups.Load, ups.Runtime)
+			}
+		}
+	} else {
+		cmd.Printf("  Error: %v\n", err)
+	}
+
+	// Test package updates collector
+	if appConfig.Updates.Enabled {
+		cmd.Println("\nUpdates Collector:")
+		updatesCollector := collectors.NewUpdatesCollector(1)
+		if data, err := updatesCollector.Collect(ctx); data != nil {
+			if metrics, ok := data.(*collectors.UpdatesMetrics); ok {
+				if metrics.Manager == "" {
+					cmd.Println("  No apt, dnf or pacman found")
+				} else {
+					cmd.Printf("  %s: %d pending, %d security\n", metrics.Manager, metrics.Pending, metrics.Security)
+				}
+			}
+		} else {
+			cmd.Printf("  Error: %v\n", err)
+		}
+	}
+
+	// Test script collectors
+	if len(appConfig.Collectors.Scripts) > 0 {
+		cmd.Println("\nScript Collectors:")
+		for _, script := range appConfig.Collectors.Scripts {
+			scriptCollector := collectors.NewScriptCollector(collectors.ScriptConfig{
+				Name:     script.Name,
+				Command:  script.Command,
+				Interval: uint(script.Interval.Seconds()),
+				Unit:     script.Unit,
+			})
+			if data, err := scriptCollector.Collect(ctx); data != nil {
+				printPartialError(cmd, err)
+				if metrics, ok := data.(*collectors.ScriptMetrics); ok {
+					cmd.Printf("  %s: %g %s\n", metrics.Name, metrics.Value, metrics.Unit)
+				}
+			} else {
+				cmd.Printf("  Error: %v\n", err)
+			}
+		}
+	}
+
+	cmd.Println("\n=== Testing Aggregator ===")
+	cmd.Println()
 
 	// Test aggregator
 	aggConfig := &collectors.AggregatorConfig{
 		CPUInterval:          1,
+		CPULogical:           appConfig.CPU.Logical,
+		CPUPower:             appConfig.CPU.Power,
 		MemoryInterval:       1,
+		MemoryUsedBasis:      appConfig.Memory.UsedBasis,
+		Cgroup:               !appConfig.HostView,
 		DiskInterval:         1,
 		NetworkInterval:      1,
 		SensorsInterval:      1,
 		HostInterval:         1,
+		ProcessInterval:      1,
+		ProcessLimit:         5,
+		GPUInterval:          1,
+		ContainerInterval:    1,
+		BandwidthInterval:    1,
+		ConnectionInterval:   1,
+		ServiceInterval:      1,
+		KubernetesInterval:   1,
+		VMInterval:           1,
+		PiInterval:           1,
+		PingInterval:         1,
+		ClockInterval:        1,
+		ConntrackInterval:    1,
+		UPSInterval:          1,
+		UPSAddress:           appConfig.UPS.Address,
+		UpdatesInterval:      1,
+		CertInterval:         1,
+		CertEndpoints:        appConfig.Network.TLSEndpoints,
+		Updates:              appConfig.Updates.Enabled,
 		DiskIncludeAll:       true,
+		DiskDeviceInfo:       appConfig.Disk.ShowDeviceInfo,
+		DiskTemperature:      appConfig.Disk.ShowTemperature,
+		DiskHealth:           appConfig.Disk.ShowHealth,
 		NetworkExcludeVirtual: true,
+		NetworkExcludeLoopback: appConfig.Network.ExcludeLoopback,
 	}
 	aggregator := collectors.NewAggregator(aggConfig)
 
@@ -0,0 +1,42 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PartialError reports a collection that succeeded for some items (devices,
+// interfaces, sensors) but failed for others. The collected data is still
+// valid; Failed maps each failed item to its error.
+type PartialError struct {
+	Failed map[string]error
+}
+
+// NewPartialError returns a PartialError for the given failures, or nil if
+// nothing failed
+func NewPartialError(failed map[string]error) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return &PartialError{Failed: failed}
+}
+
+// Items returns the names of the failed items in sorted order
+func (e *PartialError) Items() []string {
+	items := make([]string, 0, len(e.Failed))
+	for item := range e.Failed {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return items
+}
+
+// Error implements error
+func (e *PartialError) Error() string {
+	var parts []string
+	for _, item := range e.Items() {
+		parts = append(parts, fmt.Sprintf("%s: %v", item, e.Failed[item]))
+	}
+	return fmt.Sprintf("%d item(s) failed: %s", len(e.Failed), strings.Join(parts, "; "))
+}
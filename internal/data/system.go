@@ -1,6 +1,10 @@
 package data
 
 import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/shirou/gopsutil/v4/cpu"
@@ -13,11 +17,71 @@ import (
 
 // CPUMetrics holds CPU usage data
 type CPUMetrics struct {
-	Usage      []float64
-	Total      float64
-	CoreCount  int
-	Times      []cpu.TimesStat
-	LastUpdate time.Time
+	Usage       []float64
+	Total       float64
+	CoreCount   int
+	LoadCores   int // Divisor for load percent (physical cores with cpu.logical: false)
+	CoreIDs     []int
+	Offline     []int
+	Times       []cpu.TimesStat
+	Breakdown   *CPUBreakdown // nil until two samples have been taken
+	Power       []PowerStat   // RAPL or powermetrics power per domain (cpu.power), nil when unavailable
+	CgroupCores float64       // Cgroup CPU quota in cores that Total is relative to, 0 without one
+	LastUpdate  time.Time
+}
+
+// PowerStat is the average power draw of one RAPL domain since the previous
+// sample, summed over all CPU packages. On macOS it is powermetrics' reading,
+// which adds the "gpu" and "ane" (Neural Engine) domains.
+type PowerStat struct {
+	Domain string // "package", "core", "uncore", "dram", "psys", "gpu" or "ane"
+	Watts  float64
+}
+
+// PackagePower returns the power drawn by the CPU packages, if known
+func (c *CPUMetrics) PackagePower() (float64, bool) {
+	for _, power := range c.Power {
+		if power.Domain == "package" {
+			return power.Watts, true
+		}
+	}
+	return 0, false
+}
+
+// CPUBreakdown splits aggregate CPU time into categories (percent)
+type CPUBreakdown struct {
+	User   float64
+	System float64
+	IOWait float64
+	Other  float64
+	Idle   float64
+}
+
+// DisplayCoreCount returns the number of cores with usage data. This is what
+// the UI shows, since CoreCount comes from a separate call and can disagree
+// with the per-core slice (offline cores, containers, hyperthreading quirks).
+func (c *CPUMetrics) DisplayCoreCount() int {
+	if len(c.Usage) > 0 {
+		return len(c.Usage)
+	}
+	return c.CoreCount
+}
+
+// CapacityCores returns the cores Total is relative to: the cgroup's CPU
+// quota when there is one, otherwise every core shown
+func (c *CPUMetrics) CapacityCores() float64 {
+	if c.CgroupCores > 0 {
+		return c.CgroupCores
+	}
+	return float64(c.DisplayCoreCount())
+}
+
+// LoadCoreCount returns the core count load averages are relative to
+func (c *CPUMetrics) LoadCoreCount() int {
+	if c.LoadCores > 0 {
+		return c.LoadCores
+	}
+	return c.CoreCount
 }
 
 // SwapMemoryStat holds swap memory information
@@ -26,6 +90,8 @@ type SwapMemoryStat struct {
 	Used        uint64
 	Free        uint64
 	UsedPercent float64
+	InPerSec    float64 // Bytes swapped in per second
+	OutPerSec   float64 // Bytes swapped out per second
 }
 
 // MemoryMetrics holds memory usage data
@@ -36,11 +102,66 @@ type MemoryMetrics struct {
 	UsedPercent float64
 	Free        uint64
 	Buffers     uint64
-	Cached      uint64
+	Cached      uint64 // Page cache plus reclaimable slab
+	Shared      uint64 // tmpfs and shared memory, part of Cached
+	Slab        uint64
+	Dirty       uint64
+	HugePages   HugePagesStat
+	Breakdown   *MemoryBreakdown // nil where /proc/meminfo details aren't available
 	Swap        SwapMemoryStat
+	NUMA        []NUMANode // Per-node breakdown on multi-node machines (Linux)
+	Cgroup      bool       // Total and usage are the cgroup's limit and working set
 	LastUpdate  time.Time
 }
 
+// HugePagesStat holds the static huge page pool
+type HugePagesStat struct {
+	Total    uint64 // Pages
+	Free     uint64
+	PageSize uint64 // Bytes
+}
+
+// MemoryBreakdown splits total memory by use (percent); the rest is free
+type MemoryBreakdown struct {
+	Apps      float64
+	Buffers   float64
+	Cache     float64 // Without reclaimable slab
+	Slab      float64
+	HugePages float64 // The whole pool, used or not
+}
+
+// NUMANode holds the memory usage and allocation counters of a NUMA node.
+// The counters are pages since boot.
+type NUMANode struct {
+	ID        int
+	Total     uint64
+	Free      uint64
+	Used      uint64
+	FilePages uint64 // Page cache on this node
+	Hit       uint64 // Pages allocated here as intended
+	Miss      uint64 // Pages allocated here though another node was preferred
+	Foreign   uint64 // Pages meant for this node but allocated elsewhere
+	Local     uint64 // Pages allocated here for a process running here
+	Other     uint64 // Pages allocated here for a process on another node
+}
+
+// UsedPercent returns the share of the node's memory in use
+func (n NUMANode) UsedPercent() float64 {
+	if n.Total == 0 {
+		return 0
+	}
+	return float64(n.Used) / float64(n.Total) * 100
+}
+
+// LocalPercent returns the share of the node's allocations made for
+// processes running on it, and false before any allocation
+func (n NUMANode) LocalPercent() (float64, bool) {
+	if n.Local+n.Other == 0 {
+		return 0, false
+	}
+	return float64(n.Local) / float64(n.Local+n.Other) * 100, true
+}
+
 // IORate represents IO rates between two samples
 type IORate struct {
 	ReadBytesPerSec  float64
@@ -54,9 +175,44 @@ type DiskMetrics struct {
 	Partitions []disk.PartitionStat
 	Usage      map[string]disk.UsageStat
 	IO         map[string]disk.IOCountersStat
+	Devices    map[string]string     // Mountpoint -> device model/serial (disk.show_device_info)
+	Temps      map[string]float64    // Mountpoint -> device temperature in °C (disk.show_temperature)
+	Health     map[string]DiskHealth // Mountpoint -> NVMe drive health (disk.show_health)
+	RAID       []RAIDArray           // Linux software RAID arrays from /proc/mdstat
+	Rates      map[string]IORate     // Whole disk -> I/O rate since the previous collection
+	MountRates map[string]IORate     // Mountpoint -> I/O rate of its device since the previous collection
 	LastUpdate time.Time
 }
 
+// RAIDArray holds the state of a Linux software RAID array
+type RAIDArray struct {
+	Name        string // e.g. "md0"
+	Level       string // e.g. "raid1"; empty for inactive arrays
+	State       string // "active", "inactive", "active (auto-read-only)", ...
+	Devices     int    // Member devices the array is built for
+	Active      int    // Member devices currently in sync
+	Failed      int    // Members marked faulty
+	Spares      int
+	Status      string  // Member map such as "UU_", "_" marks a missing device
+	Sync        string  // "resync", "recovery", "check", "reshape" or "repair"; empty when idle
+	SyncPercent float64 // Progress of Sync
+	SyncFinish  string  // Estimated time left as reported, e.g. "12.3min"
+}
+
+// Degraded reports whether the array is missing members or has failed ones
+func (r RAIDArray) Degraded() bool {
+	return r.Active < r.Devices || r.Failed > 0
+}
+
+// DiskHealth is the SMART / health information log of an NVMe drive
+type DiskHealth struct {
+	CriticalWarning uint8  // Bit field; 0 when the drive reports no problem
+	PercentUsed     int    // Estimated share of rated endurance used; may exceed 100
+	AvailableSpare  int    // Percent of spare blocks left
+	SpareThreshold  int    // AvailableSpare at or below this triggers a warning
+	MediaErrors     uint64 // Unrecovered data integrity errors
+}
+
 // NetIORate represents network IO rate between two samples
 type NetIORate struct {
 	BytesSentPerSec   float64
@@ -71,48 +227,606 @@ type NetIORate struct {
 type NetworkMetrics struct {
 	Interfaces []net.InterfaceStat
 	IO         map[string]net.IOCountersStat
+	Wireless   map[string]WirelessStat // Wi-Fi link per wireless interface (Linux)
+	Links      map[string]LinkStat     // Speed, duplex and state per interface (Linux)
+	Rates      map[string]NetIORate    // Per interface since the previous collection
 	LastUpdate time.Time
 }
 
+// LinkStat holds the negotiated link state of a network interface.
+// Readings that aren't known are zero.
+type LinkStat struct {
+	Speed     int    // Mb/s; 0 when unknown (Wi-Fi, virtual, or no carrier)
+	Duplex    string // "full" or "half"
+	OperState string // "up", "down", "dormant", ...
+}
+
+// WirelessStat holds the link state of a Wi-Fi interface. Readings that
+// aren't known are zero (Quality is -1).
+type WirelessStat struct {
+	SSID      string  // Empty when disconnected or iw isn't installed
+	Signal    float64 // dBm
+	Quality   float64 // Link quality percent
+	Bitrate   float64 // Transmit bitrate in Mbit/s
+	Frequency float64 // MHz
+	Channel   int
+}
+
 // FanStat holds fan speed data
 type FanStat struct {
 	Name string
 	RPM  uint64
 }
 
-// SensorMetrics holds sensor data (temperatures and fans)
+// PSUStat holds the state of a power supply reported by the BMC
+type PSUStat struct {
+	Name   string
+	Status string
+	Failed bool // The supply reported a failure or lost its input
+}
+
+// SensorMetrics holds sensor data (temperatures, fans and, on servers
+// with IPMI, power supplies)
 type SensorMetrics struct {
-	Temperatures []sensors.TemperatureStat
-	Fans         []FanStat
-	LastUpdate   time.Time
+	Temperatures  []sensors.TemperatureStat
+	Fans          []FanStat
+	PowerSupplies []PSUStat
+	CoreTemps     map[int]float64 // Logical CPU -> temperature of its core (°C), where there are per-core sensors
+	LastUpdate    time.Time
 }
 
 // HostMetrics holds host information
 type HostMetrics struct {
 	Info       host.InfoStat
 	LoadAvg    *load.AvgStat
+	Activity   *KernelActivity // Context switch, interrupt and fork rates (Linux); nil until two samples
+	Users      []host.UserStat // Logged-in sessions from utmp, oldest first; nil when not available
+	Entropy    *EntropyStat    // Kernel entropy pool (Linux)
 	LastUpdate time.Time
 }
 
+// EntropyStat holds the fill level of the kernel's random entropy pool
+type EntropyStat struct {
+	Available int // Bits of entropy available
+	PoolSize  int // Bits the pool holds when full
+}
+
+// KernelActivity holds system-wide kernel activity rates
+type KernelActivity struct {
+	ContextSwitchesPerSec float64
+	InterruptsPerSec      float64
+	ForksPerSec           float64 // Processes and threads created
+}
+
+// CustomMetric holds a single user-defined metric, e.g. from a script
+type CustomMetric struct {
+	Name       string
+	Value      float64
+	Unit       string
+	Warning    float64 // 0 = no threshold
+	Critical   float64 // 0 = no threshold
+	LastUpdate time.Time
+}
+
+// ProcessStat holds usage data for a single process
+type ProcessStat struct {
+	PID     int32
+	Name    string
+	User    string
+	Command string
+	CPU     float64 // Percent of one core
+	Memory  float64 // Resident memory as percent of total
+	RSS     uint64
+
+	// Bytes per second read from and written to storage
+	ReadPerSec  float64
+	WritePerSec float64
+}
+
+// ProcessMetrics holds the busiest processes
+type ProcessMetrics struct {
+	Processes  []ProcessStat
+	Total      int // Number of processes running
+	LastUpdate time.Time
+}
+
+// GPUStat holds usage data for a single GPU. Readings the driver doesn't
+// report are -1 (MemoryTotal is 0 when VRAM usage is unknown).
+type GPUStat struct {
+	Index         int
+	Name          string
+	Vendor        string
+	Utilization   float64 // Percent busy
+	MemoryUsed    uint64  // VRAM in bytes
+	MemoryTotal   uint64
+	Temperature   float64 // °C
+	PowerDraw     float64 // Watts
+	PowerLimit    float64
+	GraphicsClock float64 // MHz
+	MemoryClock   float64
+}
+
+// GPUProcessStat holds the GPU usage of a single process, summed over
+// every GPU it runs on
+type GPUProcessStat struct {
+	PID         int32
+	Name        string
+	Utilization float64 // Percent of a GPU busy; -1 when unknown
+	MemoryUsed  uint64  // VRAM in bytes
+}
+
+// GPUMetrics holds data for every GPU found
+type GPUMetrics struct {
+	GPUs       []GPUStat
+	Processes  []GPUProcessStat // Processes using an NVIDIA GPU, in top mode
+	LastUpdate time.Time
+}
+
+// ContainerStat holds usage data for a single running container
+type ContainerStat struct {
+	ID          string
+	Name        string
+	Image       string
+	Runtime     string
+	CPU         float64 // Percent of one core
+	MemoryUsed  uint64
+	MemoryLimit uint64 // 0 when unlimited
+	NetRxPerSec float64
+	NetTxPerSec float64
+}
+
+// ContainerMetrics holds data for every running container
+type ContainerMetrics struct {
+	Runtimes   []string // Empty when no container runtime was found
+	Containers []ContainerStat
+	LastUpdate time.Time
+}
+
+// ProcessNetStat holds the TCP traffic of a single process
+type ProcessNetStat struct {
+	PID         int32 // 0 for traffic on sockets no visible process owns
+	Name        string
+	Connections int     // Open TCP sockets
+	RxPerSec    float64 // Bytes per second received
+	TxPerSec    float64 // Bytes per second sent
+}
+
+// BandwidthMetrics holds the processes using the network, busiest first
+type BandwidthMetrics struct {
+	Processes  []ProcessNetStat
+	LastUpdate time.Time
+}
+
+// ConnectionStat describes a single TCP or UDP socket
+type ConnectionStat struct {
+	Protocol   string // "tcp", "tcp6", "udp" or "udp6"
+	LocalAddr  string
+	RemoteAddr string // Empty for listening and unconnected sockets
+	Status     string // TCP state such as ESTABLISHED; empty for UDP
+	PID        int32  // 0 when the owning process isn't visible
+	Process    string
+}
+
+// ConnectionMetrics holds every open TCP and UDP socket
+type ConnectionMetrics struct {
+	Connections []ConnectionStat
+	States      map[string]int // Socket count per TCP state, and UDP sockets under "UDP"
+	LastUpdate  time.Time
+}
+
+// ListeningPort is a socket waiting for connections or datagrams
+type ListeningPort struct {
+	Protocol string
+	Address  string // Bound address, "*" when bound to all addresses
+	Port     uint16
+	PID      int32 // 0 when the owning process isn't visible
+	Process  string
+}
+
+// ListeningPorts returns the TCP sockets in LISTEN state and the unconnected
+// UDP sockets, ordered by port and then protocol
+func (c *ConnectionMetrics) ListeningPorts() []ListeningPort {
+	var ports []ListeningPort
+	for _, conn := range c.Connections {
+		isUDP := strings.HasPrefix(conn.Protocol, "udp")
+		if conn.Status != "LISTEN" && !(isUDP && conn.RemoteAddr == "") {
+			continue
+		}
+		// Addresses are host:port, with IPv6 hosts in brackets
+		i := strings.LastIndexByte(conn.LocalAddr, ':')
+		if i < 0 {
+			continue
+		}
+		port, err := strconv.ParseUint(conn.LocalAddr[i+1:], 10, 16)
+		if err != nil || port == 0 {
+			continue
+		}
+		address := strings.Trim(conn.LocalAddr[:i], "[]")
+		if address == "0.0.0.0" || address == "::" {
+			address = "*"
+		}
+		ports = append(ports, ListeningPort{
+			Protocol: conn.Protocol,
+			Address:  address,
+			Port:     uint16(port),
+			PID:      conn.PID,
+			Process:  conn.Process,
+		})
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		if ports[i].Protocol != ports[j].Protocol {
+			return ports[i].Protocol < ports[j].Protocol
+		}
+		return ports[i].Address < ports[j].Address
+	})
+	return ports
+}
+
+// ServiceStat holds the state and resource usage of a systemd unit
+type ServiceStat struct {
+	Name        string // Unit name, e.g. "nginx.service"
+	Description string
+	ActiveState string  // "active", "failed", "activating", ...
+	SubState    string  // "running", "exited", "dead", ...
+	CPU         float64 // Percent of one core since the previous sample
+	Memory      uint64  // Bytes charged to the unit's cgroup
+}
+
+// ServiceMetrics holds the failed systemd units and the running services
+type ServiceMetrics struct {
+	Services   []ServiceStat // Failed units first, then services by CPU
+	Failed     int           // Units of any type in the failed state
+	LastUpdate time.Time
+}
+
+// PodStat holds the requests and usage of a pod scheduled on this node.
+// CPU is in cores and memory in bytes, each summed over the pod's containers.
+type PodStat struct {
+	Namespace     string
+	Name          string
+	Phase         string // "Running", "Pending", "Succeeded", "Failed" or "Unknown"
+	Ready         int    // Containers passing their readiness checks
+	Containers    int
+	Restarts      int
+	CPURequest    float64
+	CPUUsage      float64 // From metrics-server; 0 when it isn't installed
+	MemoryRequest uint64
+	MemoryUsage   uint64
+}
+
+// KubernetesMetrics holds the pods running on the local node
+type KubernetesMetrics struct {
+	Configured bool   // A kubeconfig or in-cluster service account was found
+	Node       string // Node the pods were listed for
+	Pods       []PodStat
+	HasUsage   bool // metrics-server answered, so CPUUsage and MemoryUsage are set
+	LastUpdate time.Time
+}
+
+// VMStat holds the state and usage of a single libvirt domain
+type VMStat struct {
+	Name            string
+	State           string  // "running", "paused", "shut off", ...
+	VCPUs           int     // Current virtual CPUs
+	CPU             float64 // Percent of one host core since the previous sample
+	MemoryUsed      uint64  // Guest memory as reported by the balloon driver
+	MemoryMax       uint64
+	DiskReadPerSec  float64 // Bytes per second over all block devices
+	DiskWritePerSec float64
+	NetRxPerSec     float64 // Bytes per second over all interfaces
+	NetTxPerSec     float64
+}
+
+// VMMetrics holds every libvirt domain, running ones first
+type VMMetrics struct {
+	URI        string // libvirt connection used; empty when virsh isn't installed
+	VMs        []VMStat
+	LastUpdate time.Time
+}
+
+// PiMetrics holds Raspberry Pi SoC telemetry. Readings that aren't known
+// are zero.
+type PiMetrics struct {
+	Model        string  // Board model; empty when this isn't a Raspberry Pi
+	SoCTemp      float64 // °C
+	CoreVolts    float64 // Needs vcgencmd
+	ARMClock     uint64  // Hz
+	Throttled    uint32  // Firmware get_throttled bitmask
+	HasThrottled bool    // Throttled could be read
+	LastUpdate   time.Time
+}
+
+// Flags of the firmware's get_throttled bitmask. The low bits are set while
+// the condition holds; the same bits shifted by 16 stay set once it has
+// happened since boot.
+const (
+	PiUnderVoltage   uint32 = 1 << 0
+	PiFreqCapped     uint32 = 1 << 1
+	PiThrottled      uint32 = 1 << 2
+	PiSoftTempLimit  uint32 = 1 << 3
+	piOccurredOffset        = 16
+)
+
+// piThrottleReasons names the get_throttled flags in display order
+var piThrottleReasons = []struct {
+	flag uint32
+	name string
+}{
+	{PiUnderVoltage, "under-voltage"},
+	{PiFreqCapped, "frequency capped"},
+	{PiThrottled, "throttled"},
+	{PiSoftTempLimit, "soft temperature limit"},
+}
+
+// Active reports whether the flag is set right now
+func (p PiMetrics) Active(flag uint32) bool {
+	return p.Throttled&flag != 0
+}
+
+// Occurred reports whether the flag has been set at any time since boot
+func (p PiMetrics) Occurred(flag uint32) bool {
+	return p.Throttled&(flag|flag<<piOccurredOffset) != 0
+}
+
+// ActiveReasons names the flags set right now
+func (p PiMetrics) ActiveReasons() []string {
+	var reasons []string
+	for _, r := range piThrottleReasons {
+		if p.Active(r.flag) {
+			reasons = append(reasons, r.name)
+		}
+	}
+	return reasons
+}
+
+// PastReasons names the flags that have been set since boot but aren't now
+func (p PiMetrics) PastReasons() []string {
+	var reasons []string
+	for _, r := range piThrottleReasons {
+		if !p.Active(r.flag) && p.Occurred(r.flag) {
+			reasons = append(reasons, r.name)
+		}
+	}
+	return reasons
+}
+
+// PingStat holds the latest latency measurement of a ping target
+type PingStat struct {
+	Host string
+	RTT  float64 // Average round-trip time in milliseconds; 0 without replies
+	Loss float64 // Percent of echo requests that got no reply
+}
+
+// PingMetrics holds the latency of every configured target, in config order
+type PingMetrics struct {
+	Targets    []PingStat
+	LastUpdate time.Time
+}
+
+// ClockMetrics holds the NTP synchronization state of the system clock
+type ClockMetrics struct {
+	Source     string  // "chrony", "ntpd" or "timesyncd"; empty when no time service answered
+	Synced     bool    // The time service considers the clock synchronized
+	Offset     float64 // Milliseconds the clock is ahead of the reference; negative when behind
+	HasOffset  bool    // Offset could be read
+	Server     string  // Reference the clock follows, when known
+	Stratum    int
+	LastUpdate time.Time
+}
+
+// ConntrackMetrics holds netfilter connection tracking table usage. Max is
+// 0 when nf_conntrack isn't loaded.
+type ConntrackMetrics struct {
+	Count      uint64 // Connections tracked now
+	Max        uint64 // Table size (nf_conntrack_max)
+	Drops      uint64 // New connections dropped, mostly for lack of room, since boot
+	LastUpdate time.Time
+}
+
+// UsedPercent returns how full the table is, or 0 without conntrack
+func (c *ConntrackMetrics) UsedPercent() float64 {
+	if c.Max == 0 {
+		return 0
+	}
+	return float64(c.Count) / float64(c.Max) * 100
+}
+
+// UPSStat holds the state of a single UPS. Readings the driver doesn't
+// report are -1 (Runtime is 0 when unknown).
+type UPSStat struct {
+	Name        string
+	Description string
+	Model       string
+	Status      string  // Raw ups.status flags, e.g. "OL CHRG" or "OB DISCHRG LB"
+	OnBattery   bool    // Running from the battery
+	LowBattery  bool    // Battery is low; NUT starts shutting systems down
+	Charge      float64 // Battery charge in percent
+	Load        float64 // Output load in percent of capacity
+	Runtime     time.Duration
+}
+
+// UPSMetrics holds every UPS the NUT server knows about
+type UPSMetrics struct {
+	Server     string
+	UPSes      []UPSStat
+	LastUpdate time.Time
+}
+
+// UpdatesMetrics holds the number of packages waiting to be upgraded
+type UpdatesMetrics struct {
+	Manager    string // "apt", "dnf" or "pacman"; empty when none was found
+	Pending    int
+	Security   int // -1 when the package manager doesn't say
+	LastUpdate time.Time
+}
+
+// CertStat holds the certificate served by a TLS endpoint
+type CertStat struct {
+	Endpoint string
+	Subject  string
+	Issuer   string
+	NotAfter time.Time // Earliest expiry in the chain
+	Verified bool      // The chain verifies against the system roots
+}
+
+// DaysLeft returns the days until the certificate expires at now,
+// negative once it has expired
+func (c CertStat) DaysLeft(now time.Time) float64 {
+	return c.NotAfter.Sub(now).Hours() / 24
+}
+
+// CertMetrics holds the certificates of the configured TLS endpoints
+type CertMetrics struct {
+	Certs      []CertStat
+	LastUpdate time.Time
+}
+
+// CollectorState describes whether a collector's panel has data to show
+type CollectorState int
+
+const (
+	StateLoading  CollectorState = iota // Never collected yet
+	StateReady                          // Data available
+	StateDisabled                       // Turned off in config
+	StateError                          // Last collection failed
+)
+
 // SystemData aggregates all system metrics
 type SystemData struct {
-	CPU       *CPUMetrics
-	Memory    *MemoryMetrics
-	Disk      *DiskMetrics
-	Network   *NetworkMetrics
-	Sensors   *SensorMetrics
-	Host      *HostMetrics
-	Timestamp time.Time
-	Error     error
+	CPU         *CPUMetrics
+	Memory      *MemoryMetrics
+	Disk        *DiskMetrics
+	Network     *NetworkMetrics
+	Sensors     *SensorMetrics
+	Host        *HostMetrics
+	Processes   *ProcessMetrics
+	GPU         *GPUMetrics
+	Containers  *ContainerMetrics
+	Bandwidth   *BandwidthMetrics
+	Connections *ConnectionMetrics
+	Services    *ServiceMetrics
+	Kubernetes  *KubernetesMetrics
+	VMs         *VMMetrics
+	Pi          *PiMetrics
+	Ping        *PingMetrics
+	Clock       *ClockMetrics
+	Conntrack   *ConntrackMetrics
+	UPS         *UPSMetrics
+	Updates     *UpdatesMetrics
+	Certs       *CertMetrics
+	Custom      []CustomMetric
+	Timestamp   time.Time
+	Error       error
+
+	// Per-collector status, keyed by collector name
+	CollectorErrors    map[string]error
+	DisabledCollectors map[string]bool
+}
+
+// CollectorState returns the state of the named collector's data
+func (s *SystemData) CollectorState(name string) CollectorState {
+	if s == nil {
+		return StateLoading
+	}
+	if s.DisabledCollectors[name] {
+		return StateDisabled
+	}
+	if err := s.CollectorErrors[name]; err != nil {
+		// Partial failures still leave data worth showing
+		var partial *PartialError
+		if !errors.As(err, &partial) || !s.hasData(name) {
+			return StateError
+		}
+	}
+	if s.hasData(name) {
+		return StateReady
+	}
+	return StateLoading
+}
+
+// PartialFailure returns the named collector's partial failure, if its last
+// collection only succeeded for some items
+func (s *SystemData) PartialFailure(name string) *PartialError {
+	if s == nil {
+		return nil
+	}
+	var partial *PartialError
+	if errors.As(s.CollectorErrors[name], &partial) {
+		return partial
+	}
+	return nil
+}
+
+// hasData reports whether the named collector has produced data
+func (s *SystemData) hasData(name string) bool {
+	switch name {
+	case "cpu":
+		return s.CPU != nil
+	case "memory":
+		return s.Memory != nil
+	case "disk":
+		return s.Disk != nil
+	case "network":
+		return s.Network != nil
+	case "sensors":
+		return s.Sensors != nil
+	case "host":
+		return s.Host != nil
+	case "processes":
+		return s.Processes != nil
+	case "gpu":
+		return s.GPU != nil
+	case "containers":
+		return s.Containers != nil
+	case "bandwidth":
+		return s.Bandwidth != nil
+	case "connections":
+		return s.Connections != nil
+	case "services":
+		return s.Services != nil
+	case "kubernetes":
+		return s.Kubernetes != nil
+	case "vms":
+		return s.VMs != nil
+	case "rpi":
+		return s.Pi != nil
+	case "ping":
+		return s.Ping != nil
+	case "clock":
+		return s.Clock != nil
+	case "conntrack":
+		return s.Conntrack != nil
+	case "ups":
+		return s.UPS != nil
+	case "updates":
+		return s.Updates != nil
+	case "certs":
+		return s.Certs != nil
+	}
+	return false
 }
 
 // HistoryData holds historical data for sparklines
 type HistoryData struct {
-	CPU     []float64
-	Memory  []float64
-	Network RxTxHistory
-	Disk    RWHistory
-	maxSize int
+	CPU        []float64
+	Memory     []float64
+	Network    RxTxHistory
+	Interfaces map[string]RxTxHistory // Receive/transmit rates per network interface
+	Disk       RWHistory
+	Disks      map[string]RWHistory // Read/write rates per whole disk
+	Custom     map[string][]float64 // Keyed by custom metric name
+	Busy       []float64            // Composite system pressure, see SystemBusy
+	Power      []float64            // CPU package power in watts
+	Activity   ActivityHistory
+	Latency    map[string][]float64 // Ping round-trip time in ms, keyed by target
+	Loss       map[string][]float64 // Ping loss percent, keyed by target
+	Temps      map[string][]float64 // Temperature in °C, keyed by sensor key
+	Times      []time.Time          // When each tick's values were added, oldest first
+	maxSize    int
 }
 
 // RxTxHistory tracks network receive/transmit history
@@ -121,6 +835,13 @@ type RxTxHistory struct {
 	Tx []float64
 }
 
+// ActivityHistory tracks context switch, interrupt and fork rates
+type ActivityHistory struct {
+	ContextSwitches []float64
+	Interrupts      []float64
+	Forks           []float64
+}
+
 // RWHistory tracks disk read/write history
 type RWHistory struct {
 	Read  []float64
@@ -130,14 +851,37 @@ type RWHistory struct {
 // NewHistoryData creates a new history tracker
 func NewHistoryData(maxSize int) *HistoryData {
 	return &HistoryData{
-		CPU:     make([]float64, 0, maxSize),
-		Memory:  make([]float64, 0, maxSize),
-		Network: RxTxHistory{Rx: make([]float64, 0, maxSize), Tx: make([]float64, 0, maxSize)},
-		Disk:    RWHistory{Read: make([]float64, 0, maxSize), Write: make([]float64, 0, maxSize)},
+		CPU:        make([]float64, 0, maxSize),
+		Memory:     make([]float64, 0, maxSize),
+		Network:    RxTxHistory{Rx: make([]float64, 0, maxSize), Tx: make([]float64, 0, maxSize)},
+		Disk:       RWHistory{Read: make([]float64, 0, maxSize), Write: make([]float64, 0, maxSize)},
+		Disks:      make(map[string]RWHistory),
+		Custom:     make(map[string][]float64),
+		Interfaces: make(map[string]RxTxHistory),
+		Busy:       make([]float64, 0, maxSize),
+		Power:      make([]float64, 0, maxSize),
+		Activity: ActivityHistory{
+			ContextSwitches: make([]float64, 0, maxSize),
+			Interrupts:      make([]float64, 0, maxSize),
+			Forks:           make([]float64, 0, maxSize),
+		},
+		Latency: make(map[string][]float64),
+		Loss:    make(map[string][]float64),
+		Temps:   make(map[string][]float64),
+		Times:   make([]time.Time, 0, maxSize),
 		maxSize: maxSize,
 	}
 }
 
+// AddTime records when the values of a tick were added, so a series of the
+// same length can be matched to the times it covers
+func (h *HistoryData) AddTime(t time.Time) {
+	h.Times = append(h.Times, t)
+	if len(h.Times) > h.maxSize {
+		h.Times = h.Times[1:]
+	}
+}
+
 // AddCPU adds a CPU usage value to history
 func (h *HistoryData) AddCPU(value float64) {
 	h.CPU = h.appendAndTrim(h.CPU, value)
@@ -158,6 +902,14 @@ func (h *HistoryData) AddNetworkTx(value float64) {
 	h.Network.Tx = h.appendAndTrim(h.Network.Tx, value)
 }
 
+// AddInterface adds an interface's receive and transmit rates to history
+func (h *HistoryData) AddInterface(name string, rx, tx float64) {
+	iface := h.Interfaces[name]
+	iface.Rx = h.appendAndTrim(iface.Rx, rx)
+	iface.Tx = h.appendAndTrim(iface.Tx, tx)
+	h.Interfaces[name] = iface
+}
+
 // AddDiskRead adds a disk read value to history
 func (h *HistoryData) AddDiskRead(value float64) {
 	h.Disk.Read = h.appendAndTrim(h.Disk.Read, value)
@@ -168,6 +920,57 @@ func (h *HistoryData) AddDiskWrite(value float64) {
 	h.Disk.Write = h.appendAndTrim(h.Disk.Write, value)
 }
 
+// AddDisk adds a disk's read and write rates to history
+func (h *HistoryData) AddDisk(device string, read, write float64) {
+	disk := h.Disks[device]
+	disk.Read = h.appendAndTrim(disk.Read, read)
+	disk.Write = h.appendAndTrim(disk.Write, write)
+	h.Disks[device] = disk
+}
+
+// AddCustom adds a custom metric value to history
+func (h *HistoryData) AddCustom(name string, value float64) {
+	h.Custom[name] = h.appendAndTrim(h.Custom[name], value)
+}
+
+// AddBusy adds a composite system busy value to history
+func (h *HistoryData) AddBusy(value float64) {
+	h.Busy = h.appendAndTrim(h.Busy, value)
+}
+
+// AddPower adds a CPU package power value to history
+func (h *HistoryData) AddPower(value float64) {
+	h.Power = h.appendAndTrim(h.Power, value)
+}
+
+// AddActivity adds kernel activity rates to history
+func (h *HistoryData) AddActivity(activity KernelActivity) {
+	h.Activity.ContextSwitches = h.appendAndTrim(h.Activity.ContextSwitches, activity.ContextSwitchesPerSec)
+	h.Activity.Interrupts = h.appendAndTrim(h.Activity.Interrupts, activity.InterruptsPerSec)
+	h.Activity.Forks = h.appendAndTrim(h.Activity.Forks, activity.ForksPerSec)
+}
+
+// AddPing adds a ping target's loss to history, and its round-trip time
+// when any reply came back
+func (h *HistoryData) AddPing(stat PingStat) {
+	if stat.Loss < 100 {
+		h.Latency[stat.Host] = h.appendAndTrim(h.Latency[stat.Host], stat.RTT)
+	}
+	h.Loss[stat.Host] = h.appendAndTrim(h.Loss[stat.Host], stat.Loss)
+}
+
+// AddTemperature adds a sensor's temperature to history
+func (h *HistoryData) AddTemperature(key string, value float64) {
+	h.Temps[key] = h.appendAndTrim(h.Temps[key], value)
+}
+
+// Reset clears every series. Fresh slices are allocated rather than
+// truncating in place, so sparklines still holding the old slices keep
+// rendering them unchanged until they are handed the new ones.
+func (h *HistoryData) Reset() {
+	*h = *NewHistoryData(h.maxSize)
+}
+
 // appendAndTrim adds a value to a slice and keeps it at maxSize
 func (h *HistoryData) appendAndTrim(slice []float64, value float64) []float64 {
 	slice = append(slice, value)
@@ -208,3 +1011,89 @@ func (h *HistoryData) GetLatestNetworkTx() float64 {
 	}
 	return h.Network.Tx[len(h.Network.Tx)-1]
 }
+
+// BusyWeights are the relative weights of the inputs to SystemBusy
+type BusyWeights struct {
+	CPU    float64
+	IOWait float64
+	Load   float64
+}
+
+// SystemBusy blends CPU usage, IO wait and load into a single 0-100 pressure
+// value:
+//
+//	busy = (CPU * cpu% + IOWait * iowait% + Load * min(load1/cores, 1) * 100)
+//	       / (CPU + IOWait + Load)
+//
+// Inputs that aren't available yet (IO wait needs two CPU samples) are left
+// out and the remaining weights renormalized. ok is false when no weighted
+// input is available.
+func SystemBusy(s *SystemData, w BusyWeights) (busy float64, ok bool) {
+	if s == nil {
+		return 0, false
+	}
+
+	var sum, weights float64
+	if s.CPU != nil && w.CPU > 0 {
+		sum += w.CPU * s.CPU.Total
+		weights += w.CPU
+	}
+	if s.CPU != nil && s.CPU.Breakdown != nil && w.IOWait > 0 {
+		sum += w.IOWait * s.CPU.Breakdown.IOWait
+		weights += w.IOWait
+	}
+	if s.Host != nil && s.Host.LoadAvg != nil && s.CPU != nil && s.CPU.LoadCoreCount() > 0 && w.Load > 0 {
+		load := s.Host.LoadAvg.Load1 / float64(s.CPU.LoadCoreCount()) * 100
+		if load > 100 {
+			load = 100
+		}
+		sum += w.Load * load
+		weights += w.Load
+	}
+
+	if weights == 0 {
+		return 0, false
+	}
+	return sum / weights, true
+}
+
+// PressureWeights weights the inputs of MemoryPressure. SwapFullRate is the
+// combined swap-in/out rate, in bytes per second, that counts as saturated.
+type PressureWeights struct {
+	Available    float64
+	Swap         float64
+	SwapFullRate float64
+}
+
+// MemoryPressure combines how little memory is available with how hard the
+// system is swapping into a single 0-100 score:
+//
+//	pressure = (Available * (1 - available/total) * 100
+//	            + Swap * min((swap in + swap out) / SwapFullRate, 1) * 100)
+//	           / (Available + Swap)
+//
+// Unlike used%, reclaimable cache doesn't raise it, while active swapping
+// does even with memory left. ok is false when memory hasn't been collected
+// or no weight is set.
+func MemoryPressure(m *MemoryMetrics, w PressureWeights) (pressure float64, ok bool) {
+	if m == nil || m.Total == 0 {
+		return 0, false
+	}
+
+	var sum, weights float64
+	if w.Available > 0 {
+		available := min(float64(m.Available)/float64(m.Total), 1)
+		sum += w.Available * (1 - available) * 100
+		weights += w.Available
+	}
+	if w.Swap > 0 && w.SwapFullRate > 0 {
+		rate := min((m.Swap.InPerSec+m.Swap.OutPerSec)/w.SwapFullRate, 1)
+		sum += w.Swap * rate * 100
+		weights += w.Swap
+	}
+
+	if weights == 0 {
+		return 0, false
+	}
+	return sum / weights, true
+}
@@ -2,9 +2,13 @@ package collectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ctcac00/metrics-tui/internal/data"
@@ -14,39 +18,122 @@ import (
 type Aggregator struct {
 	collectors      map[string]Collector
 	data            map[string]any
+	errors          map[string]error
+	status          map[string]*CollectorStatus
+	disabled        map[string]bool
+	collectLocks    map[string]*sync.Mutex // Serializes collections from each collector
+	customNames     []string // Custom metric collector keys in registration order
 	mu              sync.RWMutex
 	ctx             context.Context
 	cancel          context.CancelFunc
 	wg              sync.WaitGroup
+	initial         sync.WaitGroup // Collectors yet to finish their first collection
+	started         chan struct{}  // Closed once every first collection is done
 	updateInterval  time.Duration
+	jitter          float64
 	onDataUpdate    func(*data.SystemData)
+	paused          atomic.Bool // Collectors skip their collections while set
+}
+
+// CollectorStatus describes the health of a single collector
+type CollectorStatus struct {
+	Interval      time.Duration
+	LastAttempt   time.Time // Zero until the first collection finishes
+	LastSuccess   time.Time // Zero until a collection succeeds
+	LastError     error     // Error from the last attempt, nil on full success
+	LastDuration  time.Duration
+	TotalDuration time.Duration // Summed over all attempts
+	SuccessCount  uint64        // Collections that produced data, including partial ones
+	FailureCount  uint64
+}
+
+// AverageDuration returns the mean collection time over all attempts
+func (s CollectorStatus) AverageDuration() time.Duration {
+	attempts := s.SuccessCount + s.FailureCount
+	if attempts == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(attempts)
 }
 
 // AggregatorConfig holds configuration for the aggregator
 type AggregatorConfig struct {
 	CPUInterval          uint
+	CPULogical           bool // Load percent relative to logical (true) or physical cores
+	CPUPower             bool // Read RAPL energy counters
 	MemoryInterval       uint
+	MemoryUsedBasis      string // "gopsutil" or "available"
+	Cgroup               bool   // CPU and memory relative to the cgroup's limits when set
 	DiskInterval         uint
 	NetworkInterval      uint
 	SensorsInterval      uint
 	HostInterval         uint
+	ProcessInterval      uint
+	ProcessLimit         int // Top processes kept by CPU and by memory
+	GPUInterval          uint
+	GPUProcesses         bool // Sample per-process GPU usage (top mode)
+	ContainerInterval    uint
+	BandwidthInterval    uint
+	ConnectionInterval   uint
+	ServiceInterval      uint
+	KubernetesInterval   uint
+	VMInterval           uint
+	PiInterval           uint
+	PingInterval         uint
+	PingTargets          []string
+	ClockInterval        uint
+	ConntrackInterval    uint
+	UPSInterval          uint
+	UPSAddress           string // NUT server host:port; empty for localhost
+	UpdatesInterval      uint
+	Updates              bool // Check the package manager for pending updates
+	CertInterval         uint
+	CertEndpoints        []string // TLS host:port endpoints whose certificates are checked
 	DiskPartitions       []string
 	DiskIncludeAll       bool
+	DiskDeviceInfo       bool // Read device model/serial from sysfs
+	DiskTemperature      bool // Read device temperature from hwmon
+	DiskHealth           bool // Read NVMe SMART logs through nvme-cli
 	NetworkInterfaces    []string
 	NetworkExcludeVirtual bool
+	NetworkExcludeLoopback bool
+	DisabledCollectors   []string
+	Jitter               float64 // Random spread applied to collector timing (0.1 = ±10%)
+	Scripts              []ScriptConfig
 }
 
 // DefaultAggregatorConfig returns default configuration
 func DefaultAggregatorConfig() *AggregatorConfig {
 	return &AggregatorConfig{
 		CPUInterval:          1,
+		CPULogical:           true,
+		CPUPower:             true,
 		MemoryInterval:       2,
+		MemoryUsedBasis:      "gopsutil",
+		Cgroup:               true,
 		DiskInterval:         5,
 		NetworkInterval:      2,
 		SensorsInterval:      5,
 		HostInterval:         5,
+		ProcessInterval:      3,
+		ProcessLimit:         20,
+		GPUInterval:          2,
+		ContainerInterval:    5,
+		BandwidthInterval:    3,
+		ConnectionInterval:   5,
+		ServiceInterval:      5,
+		KubernetesInterval:   10,
+		VMInterval:           5,
+		PiInterval:           5,
+		PingInterval:         10,
+		ClockInterval:        30,
+		ConntrackInterval:    5,
+		UPSInterval:          10,
+		UpdatesInterval:      3600,
+		CertInterval:         3600,
 		DiskIncludeAll:       true,
 		NetworkExcludeVirtual: true,
+		NetworkExcludeLoopback: true,
 	}
 }
 
@@ -61,22 +148,72 @@ func NewAggregator(config *AggregatorConfig) *Aggregator {
 	agg := &Aggregator{
 		collectors:     make(map[string]Collector),
 		data:           make(map[string]any),
+		errors:         make(map[string]error),
+		status:         make(map[string]*CollectorStatus),
+		disabled:       make(map[string]bool),
+		collectLocks:   make(map[string]*sync.Mutex),
 		ctx:            ctx,
 		cancel:         cancel,
 		updateInterval: 500 * time.Millisecond, // Check for updates twice per second
+		jitter:         config.Jitter,
+		started:        make(chan struct{}),
 	}
 
 	// Initialize collectors
-	agg.collectors["cpu"] = NewCPUCollector(config.CPUInterval)
-	agg.collectors["memory"] = NewMemoryCollector(config.MemoryInterval)
-	agg.collectors["disk"] = NewDiskCollector(config.DiskInterval, config.DiskPartitions, config.DiskIncludeAll)
-	agg.collectors["network"] = NewNetworkCollector(config.NetworkInterval, config.NetworkInterfaces, config.NetworkExcludeVirtual)
+	agg.collectors["cpu"] = NewCPUCollector(config.CPUInterval, config.CPULogical, config.CPUPower, config.Cgroup)
+	agg.collectors["memory"] = NewMemoryCollector(config.MemoryInterval, config.MemoryUsedBasis, config.Cgroup)
+	agg.collectors["disk"] = NewDiskCollector(config.DiskInterval, config.DiskPartitions, config.DiskIncludeAll, config.DiskDeviceInfo, config.DiskTemperature, config.DiskHealth)
+	agg.collectors["network"] = NewNetworkCollector(config.NetworkInterval, config.NetworkInterfaces, config.NetworkExcludeVirtual, config.NetworkExcludeLoopback)
 	agg.collectors["sensors"] = NewSensorsCollector(config.SensorsInterval)
 	agg.collectors["host"] = NewHostCollector(config.HostInterval)
+	agg.collectors["processes"] = NewProcessCollector(config.ProcessInterval, config.ProcessLimit)
+	agg.collectors["gpu"] = NewGPUCollector(config.GPUInterval, config.GPUProcesses)
+	agg.collectors["containers"] = NewContainerCollector(config.ContainerInterval)
+	agg.collectors["bandwidth"] = NewBandwidthCollector(config.BandwidthInterval, config.ProcessLimit)
+	agg.collectors["connections"] = NewConnectionCollector(config.ConnectionInterval)
+	agg.collectors["services"] = NewServiceCollector(config.ServiceInterval)
+	agg.collectors["kubernetes"] = NewKubernetesCollector(config.KubernetesInterval)
+	agg.collectors["vms"] = NewVMCollector(config.VMInterval)
+	agg.collectors["rpi"] = NewPiCollector(config.PiInterval)
+	agg.collectors["ping"] = NewPingCollector(config.PingInterval, config.PingTargets)
+	agg.collectors["clock"] = NewClockCollector(config.ClockInterval)
+	agg.collectors["conntrack"] = NewConntrackCollector(config.ConntrackInterval)
+	agg.collectors["ups"] = NewUPSCollector(config.UPSInterval, config.UPSAddress)
+	agg.collectors["certs"] = NewCertCollector(config.CertInterval, config.CertEndpoints)
+	if config.Updates {
+		agg.collectors["updates"] = NewUpdatesCollector(config.UpdatesInterval)
+	}
+
+	// User-defined script collectors
+	for _, script := range config.Scripts {
+		agg.RegisterCustom(NewScriptCollector(script))
+	}
+
+	// Drop collectors turned off in config
+	for _, name := range config.DisabledCollectors {
+		if _, ok := agg.collectors[name]; ok {
+			delete(agg.collectors, name)
+			agg.disabled[name] = true
+		}
+	}
 
 	return agg
 }
 
+// RegisterCustom adds a collector whose output feeds SystemData.Custom.
+// The collector must return *ScriptMetrics or []*ScriptMetrics. It must be
+// registered before Start; duplicate names are ignored.
+func (a *Aggregator) RegisterCustom(collector Collector) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, exists := a.collectors[collector.Name()]; exists {
+		return
+	}
+	a.collectors[collector.Name()] = collector
+	a.customNames = append(a.customNames, collector.Name())
+}
+
 // SetOnDataUpdate sets a callback function to be called when data is updated
 func (a *Aggregator) SetOnDataUpdate(fn func(*data.SystemData)) {
 	a.mu.Lock()
@@ -84,55 +221,218 @@ func (a *Aggregator) SetOnDataUpdate(fn func(*data.SystemData)) {
 	a.onDataUpdate = fn
 }
 
-// Start begins concurrent collection from all collectors
+// Start begins concurrent collection from all collectors. It returns
+// immediately; use Started to wait for the first round of data.
 func (a *Aggregator) Start() {
+	a.initial.Add(len(a.collectors))
 	for _, collector := range a.collectors {
 		a.wg.Add(1)
 		go a.startCollector(collector)
 	}
+	go func() {
+		a.initial.Wait()
+		close(a.started)
+	}()
 
 	// Start update checker goroutine
 	a.wg.Add(1)
 	go a.updateChecker()
 }
 
+// Started returns a channel that is closed once every collector has finished
+// its first collection, successful or not, or has been stopped before it
+func (a *Aggregator) Started() <-chan struct{} {
+	return a.started
+}
+
 // Stop gracefully stops all collectors
 func (a *Aggregator) Stop() {
 	a.cancel()
 	a.wg.Wait()
 }
 
+// SetPaused stops or resumes collection. Collectors keep their schedule
+// while paused and collect again at their next interval after resuming; the
+// last data stays available meanwhile.
+func (a *Aggregator) SetPaused(paused bool) {
+	a.paused.Store(paused)
+}
+
 // startCollector runs a single collector in a loop
 func (a *Aggregator) startCollector(collector Collector) {
 	defer a.wg.Done()
+	initialDone := sync.OnceFunc(a.initial.Done)
+	defer initialDone()
 
-	ticker := time.NewTicker(time.Duration(collector.Interval()) * time.Second)
-	defer ticker.Stop()
+	interval := time.Duration(collector.Interval()) * time.Second
+
+	// Stagger the initial collection so collectors don't all fire at once
+	if a.jitter > 0 {
+		delay := time.Duration(rand.Float64() * a.jitter * float64(interval))
+		select {
+		case <-time.After(delay):
+		case <-a.ctx.Done():
+			return
+		}
+	}
 
 	// Do initial collection
 	a.collectFrom(collector)
+	initialDone()
+
+	timer := time.NewTimer(a.jitteredInterval(interval))
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
-			a.collectFrom(collector)
+		case <-timer.C:
+			if !a.paused.Load() {
+				a.collectFrom(collector)
+			}
+			timer.Reset(a.jitteredInterval(interval))
 		case <-a.ctx.Done():
 			return
 		}
 	}
 }
 
+// jitteredInterval spreads an interval randomly by the configured jitter fraction
+func (a *Aggregator) jitteredInterval(interval time.Duration) time.Duration {
+	if a.jitter <= 0 {
+		return interval
+	}
+	factor := 1 + a.jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(interval) * factor)
+}
+
+// collectLock returns the mutex serializing collections from the named
+// collector. Collectors keep delta state (CPU times, network counters), so
+// a CollectNow must never overlap the collector's own loop.
+func (a *Aggregator) collectLock(name string) *sync.Mutex {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	lock, ok := a.collectLocks[name]
+	if !ok {
+		lock = &sync.Mutex{}
+		a.collectLocks[name] = lock
+	}
+	return lock
+}
+
 // collectFrom performs a single collection from a collector
 func (a *Aggregator) collectFrom(collector Collector) {
-	result, err := collector.Collect(a.ctx)
-	if err != nil {
+	lock := a.collectLock(collector.Name())
+	lock.Lock()
+	defer lock.Unlock()
+
+	start := time.Now()
+	result, err := a.safeCollect(collector)
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	status := a.status[collector.Name()]
+	if status == nil {
+		status = &CollectorStatus{}
+		a.status[collector.Name()] = status
+	}
+	status.LastAttempt = now
+	status.LastError = err
+	status.LastDuration = now.Sub(start)
+	status.TotalDuration += status.LastDuration
+
+	// A partial failure still returns data; keep it alongside the error
+	var partial *data.PartialError
+	if err != nil && !(errors.As(err, &partial) && result != nil) {
 		log.Printf("[%s] Collection error: %v", collector.Name(), err)
+		a.errors[collector.Name()] = err
+		status.FailureCount++
 		return
 	}
 
-	a.mu.Lock()
+	status.LastSuccess = now
+	status.SuccessCount++
 	a.data[collector.Name()] = result
-	a.mu.Unlock()
+	if partial != nil {
+		log.Printf("[%s] Partial collection: %v", collector.Name(), err)
+		a.errors[collector.Name()] = err
+	} else {
+		delete(a.errors, collector.Name())
+	}
+}
+
+// CollectNow runs one collection from the named collector right away,
+// outside its regular interval, and blocks until it finishes. It does
+// nothing and returns false if the collector is unknown or was collected
+// within minGap, so callers can't hammer slow collectors.
+func (a *Aggregator) CollectNow(name string, minGap time.Duration) bool {
+	a.mu.RLock()
+	collector, ok := a.collectors[name]
+	status := a.status[name]
+	recent := status != nil && time.Since(status.LastAttempt) < minGap
+	a.mu.RUnlock()
+	if !ok || recent {
+		return false
+	}
+
+	a.collectFrom(collector)
+	return true
+}
+
+// CollectAll runs one collection from every collector concurrently, outside
+// their regular intervals, and blocks until the slowest finishes. As with
+// CollectNow, collectors collected within minGap are skipped, and a
+// collector already mid-collection is waited for rather than run twice at
+// once. It returns the number of collectors that ran.
+func (a *Aggregator) CollectAll(minGap time.Duration) int {
+	var wg sync.WaitGroup
+	var collected atomic.Int32
+	for _, name := range a.ListCollectors() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if a.CollectNow(name, minGap) {
+				collected.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	return int(collected.Load())
+}
+
+// safeCollect runs a single Collect call, turning a panic into an error so
+// one faulty collector can't take down the program. The collector's loop
+// keeps running and simply tries again on its next interval.
+func (a *Aggregator) safeCollect(collector Collector) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[%s] Collector panic: %v\n%s", collector.Name(), r, debug.Stack())
+			result = nil
+			err = fmt.Errorf("collector panicked: %v", r)
+		}
+	}()
+	return collector.Collect(a.ctx)
+}
+
+// CollectorStatus returns a snapshot of every collector's health, keyed by
+// collector name. Collectors that have not finished a collection yet are
+// included with zero values.
+func (a *Aggregator) CollectorStatus() map[string]CollectorStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	statuses := make(map[string]CollectorStatus, len(a.collectors))
+	for name, collector := range a.collectors {
+		var status CollectorStatus
+		if s := a.status[name]; s != nil {
+			status = *s
+		}
+		status.Interval = time.Duration(collector.Interval()) * time.Second
+		statuses[name] = status
+	}
+	return statuses
 }
 
 // updateChecker periodically checks for data updates and triggers callbacks
@@ -170,11 +470,43 @@ func convertCPUMetrics(m *CPUMetrics) *data.CPUMetrics {
 		return nil
 	}
 	return &data.CPUMetrics{
-		Usage:      m.Usage,
-		Total:      m.Total,
-		CoreCount:  m.CoreCount,
-		Times:      m.Times,
-		LastUpdate: m.LastUpdate,
+		Usage:       m.Usage,
+		Total:       m.Total,
+		CoreCount:   m.CoreCount,
+		LoadCores:   m.LoadCores,
+		CoreIDs:     m.CoreIDs,
+		Offline:     m.Offline,
+		Times:       m.Times,
+		Breakdown:   convertCPUBreakdown(m.Breakdown),
+		Power:       convertPowerStats(m.Power),
+		CgroupCores: m.CgroupCores,
+		LastUpdate:  m.LastUpdate,
+	}
+}
+
+// convertPowerStats converts from collectors.PowerStat to data.PowerStat
+func convertPowerStats(stats []PowerStat) []data.PowerStat {
+	if stats == nil {
+		return nil
+	}
+	power := make([]data.PowerStat, len(stats))
+	for i, stat := range stats {
+		power[i] = data.PowerStat(stat)
+	}
+	return power
+}
+
+// convertCPUBreakdown converts from collectors.CPUBreakdown to data.CPUBreakdown
+func convertCPUBreakdown(b *CPUBreakdown) *data.CPUBreakdown {
+	if b == nil {
+		return nil
+	}
+	return &data.CPUBreakdown{
+		User:   b.User,
+		System: b.System,
+		IOWait: b.IOWait,
+		Other:  b.Other,
+		Idle:   b.Idle,
 	}
 }
 
@@ -191,32 +523,121 @@ func convertMemoryMetrics(m *MemoryMetrics) *data.MemoryMetrics {
 		Free:        m.Free,
 		Buffers:     m.Buffers,
 		Cached:      m.Cached,
+		Shared:      m.Shared,
+		Slab:        m.Slab,
+		Dirty:       m.Dirty,
+		HugePages:   data.HugePagesStat(m.HugePages),
+		Breakdown:   convertMemoryBreakdown(m.Breakdown),
 		Swap:        data.SwapMemoryStat(m.Swap),
+		NUMA:        convertNUMANodes(m.NUMA),
+		Cgroup:      m.Cgroup,
 		LastUpdate:  m.LastUpdate,
 	}
 }
 
+// convertMemoryBreakdown converts from collectors.MemoryBreakdown to data.MemoryBreakdown
+func convertMemoryBreakdown(b *MemoryBreakdown) *data.MemoryBreakdown {
+	if b == nil {
+		return nil
+	}
+	breakdown := data.MemoryBreakdown(*b)
+	return &breakdown
+}
+
+// convertNUMANodes converts from collectors.NUMANode to data.NUMANode
+func convertNUMANodes(nodes []NUMANode) []data.NUMANode {
+	if nodes == nil {
+		return nil
+	}
+	numa := make([]data.NUMANode, len(nodes))
+	for i, node := range nodes {
+		numa[i] = data.NUMANode(node)
+	}
+	return numa
+}
+
 // convertDiskMetrics converts from collectors.DiskMetrics to data.DiskMetrics
 func convertDiskMetrics(m *DiskMetrics) *data.DiskMetrics {
 	if m == nil {
 		return nil
 	}
+	var health map[string]data.DiskHealth
+	if m.Health != nil {
+		health = make(map[string]data.DiskHealth, len(m.Health))
+		for mount, h := range m.Health {
+			health[mount] = data.DiskHealth(h)
+		}
+	}
 	return &data.DiskMetrics{
 		Partitions: m.Partitions,
 		Usage:      m.Usage,
 		IO:         m.IO,
+		Devices:    m.Devices,
+		Temps:      m.Temps,
+		Health:     health,
+		RAID:       convertRAIDArrays(m.RAID),
+		Rates:      convertIORates(m.Rates),
+		MountRates: convertIORates(m.MountRates),
 		LastUpdate: m.LastUpdate,
 	}
 }
 
+// convertIORates converts from collectors.IORate to data.IORate
+func convertIORates(rates map[string]IORate) map[string]data.IORate {
+	if rates == nil {
+		return nil
+	}
+	converted := make(map[string]data.IORate, len(rates))
+	for key, rate := range rates {
+		converted[key] = data.IORate(rate)
+	}
+	return converted
+}
+
+// convertRAIDArrays converts from collectors.RAIDArray to data.RAIDArray
+func convertRAIDArrays(arrays []RAIDArray) []data.RAIDArray {
+	if arrays == nil {
+		return nil
+	}
+	raid := make([]data.RAIDArray, len(arrays))
+	for i, array := range arrays {
+		raid[i] = data.RAIDArray(array)
+	}
+	return raid
+}
+
 // convertNetworkMetrics converts from collectors.NetworkMetrics to data.NetworkMetrics
 func convertNetworkMetrics(m *NetworkMetrics) *data.NetworkMetrics {
 	if m == nil {
 		return nil
 	}
+	var wireless map[string]data.WirelessStat
+	if m.Wireless != nil {
+		wireless = make(map[string]data.WirelessStat, len(m.Wireless))
+		for name, stat := range m.Wireless {
+			wireless[name] = data.WirelessStat(stat)
+		}
+	}
+	var links map[string]data.LinkStat
+	if m.Links != nil {
+		links = make(map[string]data.LinkStat, len(m.Links))
+		for name, stat := range m.Links {
+			links[name] = data.LinkStat(stat)
+		}
+	}
+	var rates map[string]data.NetIORate
+	if m.Rates != nil {
+		rates = make(map[string]data.NetIORate, len(m.Rates))
+		for name, rate := range m.Rates {
+			rates[name] = data.NetIORate(rate)
+		}
+	}
 	return &data.NetworkMetrics{
 		Interfaces: m.Interfaces,
 		IO:         m.IO,
+		Wireless:   wireless,
+		Links:      links,
+		Rates:      rates,
 		LastUpdate: m.LastUpdate,
 	}
 }
@@ -234,10 +655,16 @@ func convertSensorMetrics(m *SensorMetrics) *data.SensorMetrics {
 			RPM:  fan.RPM,
 		}
 	}
+	psus := make([]data.PSUStat, len(m.PowerSupplies))
+	for i, psu := range m.PowerSupplies {
+		psus[i] = data.PSUStat(psu)
+	}
 	return &data.SensorMetrics{
-		Temperatures: m.Temperatures,
-		Fans:         fans,
-		LastUpdate:   m.LastUpdate,
+		Temperatures:  m.Temperatures,
+		Fans:          fans,
+		PowerSupplies: psus,
+		CoreTemps:     m.CoreTemps,
+		LastUpdate:    m.LastUpdate,
 	}
 }
 
@@ -249,6 +676,236 @@ func convertHostMetrics(m *HostMetrics) *data.HostMetrics {
 	return &data.HostMetrics{
 		Info:       m.Info,
 		LoadAvg:    m.LoadAvg,
+		Activity:   (*data.KernelActivity)(m.Activity),
+		Users:      m.Users,
+		Entropy:    (*data.EntropyStat)(m.Entropy),
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertProcessMetrics converts from collectors.ProcessMetrics to data.ProcessMetrics
+func convertProcessMetrics(m *ProcessMetrics) *data.ProcessMetrics {
+	if m == nil {
+		return nil
+	}
+	processes := make([]data.ProcessStat, len(m.Processes))
+	for i, p := range m.Processes {
+		processes[i] = data.ProcessStat(p)
+	}
+	return &data.ProcessMetrics{
+		Processes:  processes,
+		Total:      m.Total,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertGPUMetrics converts from collectors.GPUMetrics to data.GPUMetrics
+func convertGPUMetrics(m *GPUMetrics) *data.GPUMetrics {
+	if m == nil {
+		return nil
+	}
+	gpus := make([]data.GPUStat, len(m.GPUs))
+	for i, gpu := range m.GPUs {
+		gpus[i] = data.GPUStat(gpu)
+	}
+	processes := make([]data.GPUProcessStat, len(m.Processes))
+	for i, p := range m.Processes {
+		processes[i] = data.GPUProcessStat(p)
+	}
+	return &data.GPUMetrics{
+		GPUs:       gpus,
+		Processes:  processes,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertContainerMetrics converts from collectors.ContainerMetrics to data.ContainerMetrics
+func convertContainerMetrics(m *ContainerMetrics) *data.ContainerMetrics {
+	if m == nil {
+		return nil
+	}
+	containers := make([]data.ContainerStat, len(m.Containers))
+	for i, container := range m.Containers {
+		containers[i] = data.ContainerStat(container)
+	}
+	return &data.ContainerMetrics{
+		Runtimes:   m.Runtimes,
+		Containers: containers,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertBandwidthMetrics converts from collectors.BandwidthMetrics to data.BandwidthMetrics
+func convertBandwidthMetrics(m *BandwidthMetrics) *data.BandwidthMetrics {
+	if m == nil {
+		return nil
+	}
+	processes := make([]data.ProcessNetStat, len(m.Processes))
+	for i, process := range m.Processes {
+		processes[i] = data.ProcessNetStat(process)
+	}
+	return &data.BandwidthMetrics{
+		Processes:  processes,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertConnectionMetrics converts from collectors.ConnectionMetrics to data.ConnectionMetrics
+func convertConnectionMetrics(m *ConnectionMetrics) *data.ConnectionMetrics {
+	if m == nil {
+		return nil
+	}
+	connections := make([]data.ConnectionStat, len(m.Connections))
+	for i, conn := range m.Connections {
+		connections[i] = data.ConnectionStat(conn)
+	}
+	return &data.ConnectionMetrics{
+		Connections: connections,
+		States:      m.States,
+		LastUpdate:  m.LastUpdate,
+	}
+}
+
+// convertServiceMetrics converts from collectors.ServiceMetrics to data.ServiceMetrics
+func convertServiceMetrics(m *ServiceMetrics) *data.ServiceMetrics {
+	if m == nil {
+		return nil
+	}
+	services := make([]data.ServiceStat, len(m.Services))
+	for i, service := range m.Services {
+		services[i] = data.ServiceStat(service)
+	}
+	return &data.ServiceMetrics{
+		Services:   services,
+		Failed:     m.Failed,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertKubernetesMetrics converts from collectors.KubernetesMetrics to data.KubernetesMetrics
+func convertKubernetesMetrics(m *KubernetesMetrics) *data.KubernetesMetrics {
+	if m == nil {
+		return nil
+	}
+	pods := make([]data.PodStat, len(m.Pods))
+	for i, pod := range m.Pods {
+		pods[i] = data.PodStat(pod)
+	}
+	return &data.KubernetesMetrics{
+		Configured: m.Configured,
+		Node:       m.Node,
+		Pods:       pods,
+		HasUsage:   m.HasUsage,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertVMMetrics converts from collectors.VMMetrics to data.VMMetrics
+func convertVMMetrics(m *VMMetrics) *data.VMMetrics {
+	if m == nil {
+		return nil
+	}
+	vms := make([]data.VMStat, len(m.VMs))
+	for i, vm := range m.VMs {
+		vms[i] = data.VMStat(vm)
+	}
+	return &data.VMMetrics{
+		URI:        m.URI,
+		VMs:        vms,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertPiMetrics converts from collectors.PiMetrics to data.PiMetrics
+func convertPiMetrics(m *PiMetrics) *data.PiMetrics {
+	if m == nil {
+		return nil
+	}
+	pi := data.PiMetrics(*m)
+	return &pi
+}
+
+// convertPingMetrics converts from collectors.PingMetrics to data.PingMetrics
+func convertPingMetrics(m *PingMetrics) *data.PingMetrics {
+	if m == nil {
+		return nil
+	}
+	targets := make([]data.PingStat, len(m.Targets))
+	for i, target := range m.Targets {
+		targets[i] = data.PingStat(target)
+	}
+	return &data.PingMetrics{
+		Targets:    targets,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertClockMetrics converts from collectors.ClockMetrics to data.ClockMetrics
+func convertClockMetrics(m *ClockMetrics) *data.ClockMetrics {
+	if m == nil {
+		return nil
+	}
+	clock := data.ClockMetrics(*m)
+	return &clock
+}
+
+// convertConntrackMetrics converts from collectors.ConntrackMetrics to data.ConntrackMetrics
+func convertConntrackMetrics(m *ConntrackMetrics) *data.ConntrackMetrics {
+	if m == nil {
+		return nil
+	}
+	conntrack := data.ConntrackMetrics(*m)
+	return &conntrack
+}
+
+// convertUPSMetrics converts from collectors.UPSMetrics to data.UPSMetrics
+func convertUPSMetrics(m *UPSMetrics) *data.UPSMetrics {
+	if m == nil {
+		return nil
+	}
+	upses := make([]data.UPSStat, len(m.UPSes))
+	for i, ups := range m.UPSes {
+		upses[i] = data.UPSStat(ups)
+	}
+	return &data.UPSMetrics{
+		Server:     m.Server,
+		UPSes:      upses,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertUpdatesMetrics converts from collectors.UpdatesMetrics to data.UpdatesMetrics
+func convertUpdatesMetrics(m *UpdatesMetrics) *data.UpdatesMetrics {
+	if m == nil {
+		return nil
+	}
+	updates := data.UpdatesMetrics(*m)
+	return &updates
+}
+
+// convertCertMetrics converts from collectors.CertMetrics to data.CertMetrics
+func convertCertMetrics(m *CertMetrics) *data.CertMetrics {
+	if m == nil {
+		return nil
+	}
+	certs := make([]data.CertStat, len(m.Certs))
+	for i, cert := range m.Certs {
+		certs[i] = data.CertStat(cert)
+	}
+	return &data.CertMetrics{
+		Certs:      certs,
+		LastUpdate: m.LastUpdate,
+	}
+}
+
+// convertScriptMetrics converts from collectors.ScriptMetrics to data.CustomMetric
+func convertScriptMetrics(m *ScriptMetrics) data.CustomMetric {
+	return data.CustomMetric{
+		Name:       m.Name,
+		Value:      m.Value,
+		Unit:       m.Unit,
+		Warning:    m.Warning,
+		Critical:   m.Critical,
 		LastUpdate: m.LastUpdate,
 	}
 }
@@ -259,7 +916,16 @@ func (a *Aggregator) GetSystemData() *data.SystemData {
 	defer a.mu.RUnlock()
 
 	systemData := &data.SystemData{
-		Timestamp: time.Now(),
+		Timestamp:          time.Now(),
+		CollectorErrors:    make(map[string]error, len(a.errors)),
+		DisabledCollectors: make(map[string]bool, len(a.disabled)),
+	}
+
+	for name, err := range a.errors {
+		systemData.CollectorErrors[name] = err
+	}
+	for name := range a.disabled {
+		systemData.DisabledCollectors[name] = true
 	}
 
 	if cpuData, ok := a.data["cpu"].(*CPUMetrics); ok {
@@ -280,6 +946,61 @@ func (a *Aggregator) GetSystemData() *data.SystemData {
 	if hostData, ok := a.data["host"].(*HostMetrics); ok {
 		systemData.Host = convertHostMetrics(hostData)
 	}
+	if processData, ok := a.data["processes"].(*ProcessMetrics); ok {
+		systemData.Processes = convertProcessMetrics(processData)
+	}
+	if gpuData, ok := a.data["gpu"].(*GPUMetrics); ok {
+		systemData.GPU = convertGPUMetrics(gpuData)
+	}
+	if containerData, ok := a.data["containers"].(*ContainerMetrics); ok {
+		systemData.Containers = convertContainerMetrics(containerData)
+	}
+	if bandwidthData, ok := a.data["bandwidth"].(*BandwidthMetrics); ok {
+		systemData.Bandwidth = convertBandwidthMetrics(bandwidthData)
+	}
+	if connectionData, ok := a.data["connections"].(*ConnectionMetrics); ok {
+		systemData.Connections = convertConnectionMetrics(connectionData)
+	}
+	if serviceData, ok := a.data["services"].(*ServiceMetrics); ok {
+		systemData.Services = convertServiceMetrics(serviceData)
+	}
+	if kubernetesData, ok := a.data["kubernetes"].(*KubernetesMetrics); ok {
+		systemData.Kubernetes = convertKubernetesMetrics(kubernetesData)
+	}
+	if vmData, ok := a.data["vms"].(*VMMetrics); ok {
+		systemData.VMs = convertVMMetrics(vmData)
+	}
+	if piData, ok := a.data["rpi"].(*PiMetrics); ok {
+		systemData.Pi = convertPiMetrics(piData)
+	}
+	if pingData, ok := a.data["ping"].(*PingMetrics); ok {
+		systemData.Ping = convertPingMetrics(pingData)
+	}
+	if clockData, ok := a.data["clock"].(*ClockMetrics); ok {
+		systemData.Clock = convertClockMetrics(clockData)
+	}
+	if conntrackData, ok := a.data["conntrack"].(*ConntrackMetrics); ok {
+		systemData.Conntrack = convertConntrackMetrics(conntrackData)
+	}
+	if upsData, ok := a.data["ups"].(*UPSMetrics); ok {
+		systemData.UPS = convertUPSMetrics(upsData)
+	}
+	if updatesData, ok := a.data["updates"].(*UpdatesMetrics); ok {
+		systemData.Updates = convertUpdatesMetrics(updatesData)
+	}
+	if certData, ok := a.data["certs"].(*CertMetrics); ok {
+		systemData.Certs = convertCertMetrics(certData)
+	}
+	for _, name := range a.customNames {
+		switch customData := a.data[name].(type) {
+		case *ScriptMetrics:
+			systemData.Custom = append(systemData.Custom, convertScriptMetrics(customData))
+		case []*ScriptMetrics:
+			for _, m := range customData {
+				systemData.Custom = append(systemData.Custom, convertScriptMetrics(m))
+			}
+		}
+	}
 
 	return systemData
 }
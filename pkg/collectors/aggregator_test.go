@@ -0,0 +1,221 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeCollector returns the results of collect, one call at a time
+type fakeCollector struct {
+	name    string
+	collect func(calls int) (any, error)
+	calls   int
+}
+
+func (f *fakeCollector) Name() string   { return f.name }
+func (f *fakeCollector) Interval() uint { return 1 }
+
+func (f *fakeCollector) Collect(ctx context.Context) (interface{}, error) {
+	f.calls++
+	return f.collect(f.calls)
+}
+
+// newTestAggregator returns an aggregator running only the given collectors
+func newTestAggregator(collectors ...Collector) *Aggregator {
+	agg := NewAggregator(&AggregatorConfig{})
+	agg.collectors = make(map[string]Collector)
+	for _, collector := range collectors {
+		agg.collectors[collector.Name()] = collector
+	}
+	return agg
+}
+
+func TestCollectorStatus(t *testing.T) {
+	errBroken := errors.New("broken")
+	fake := &fakeCollector{name: "fake", collect: func(calls int) (any, error) {
+		if calls == 1 {
+			return "ok", nil
+		}
+		return nil, errBroken
+	}}
+	agg := newTestAggregator(fake)
+
+	if status := agg.CollectorStatus()["fake"]; !status.LastAttempt.IsZero() || status.Interval != time.Second {
+		t.Fatalf("status before collecting = %+v", status)
+	}
+
+	agg.collectFrom(fake)
+	first := agg.CollectorStatus()["fake"]
+	if first.LastAttempt.IsZero() || !first.LastSuccess.Equal(first.LastAttempt) {
+		t.Errorf("after success: LastAttempt = %v, LastSuccess = %v", first.LastAttempt, first.LastSuccess)
+	}
+	if first.SuccessCount != 1 || first.FailureCount != 0 || first.LastError != nil {
+		t.Errorf("after success: %+v", first)
+	}
+
+	agg.collectFrom(fake)
+	second := agg.CollectorStatus()["fake"]
+	if second.LastAttempt.Before(first.LastAttempt) {
+		t.Errorf("after failure: LastAttempt went back from %v to %v", first.LastAttempt, second.LastAttempt)
+	}
+	if !second.LastSuccess.Equal(first.LastSuccess) {
+		t.Errorf("after failure: LastSuccess = %v, want %v", second.LastSuccess, first.LastSuccess)
+	}
+	if second.SuccessCount != 1 || second.FailureCount != 1 {
+		t.Errorf("after failure: SuccessCount = %d, FailureCount = %d, want 1, 1", second.SuccessCount, second.FailureCount)
+	}
+	if !errors.Is(second.LastError, errBroken) {
+		t.Errorf("after failure: LastError = %v, want %v", second.LastError, errBroken)
+	}
+}
+
+func TestSafeCollectRecoversPanic(t *testing.T) {
+	panicky := &fakeCollector{name: "panicky", collect: func(int) (any, error) {
+		panic("boom")
+	}}
+	memory := &fakeCollector{name: "memory", collect: func(int) (any, error) {
+		return &MemoryMetrics{Total: 1024}, nil
+	}}
+	agg := newTestAggregator(panicky, memory)
+
+	for range 2 {
+		agg.collectFrom(panicky)
+		agg.collectFrom(memory)
+	}
+
+	status := agg.CollectorStatus()
+	if err := status["panicky"].LastError; err == nil || !strings.Contains(err.Error(), "panicked: boom") {
+		t.Errorf("panicky LastError = %v, want the panic", err)
+	}
+	if got := status["panicky"].FailureCount; got != 2 {
+		t.Errorf("panicky FailureCount = %d, want 2", got)
+	}
+	if got := status["memory"]; got.SuccessCount != 2 || got.LastError != nil {
+		t.Errorf("memory status = %+v, want two clean successes", got)
+	}
+	if mem := agg.GetSystemData().Memory; mem == nil || mem.Total != 1024 {
+		t.Errorf("Memory = %+v, want the fake's data", mem)
+	}
+}
+
+func TestStartReturnsBeforeStarted(t *testing.T) {
+	release := make(chan struct{})
+	memory := &fakeCollector{name: "memory", collect: func(int) (any, error) {
+		<-release
+		return &MemoryMetrics{Total: 1024}, nil
+	}}
+	agg := newTestAggregator(memory)
+	defer agg.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		agg.Start()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Start blocked on the first collection")
+	}
+
+	select {
+	case <-agg.Started():
+		t.Fatal("Started closed before the first collection finished")
+	default:
+	}
+
+	close(release)
+	select {
+	case <-agg.Started():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Started not closed after the first collection")
+	}
+	if mem := agg.GetSystemData().Memory; mem == nil || mem.Total != 1024 {
+		t.Errorf("Memory = %+v, want data once started", mem)
+	}
+}
+
+func TestCollectNowWaitsForRunningCollection(t *testing.T) {
+	var running, overlaps atomic.Int32
+	slow := &fakeCollector{name: "slow", collect: func(int) (any, error) {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return "ok", nil
+	}}
+	agg := newTestAggregator(slow)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			agg.collectFrom(slow)
+		}()
+		go func() {
+			defer wg.Done()
+			agg.CollectNow("slow", 0)
+		}()
+	}
+	wg.Wait()
+
+	if got := overlaps.Load(); got != 0 {
+		t.Errorf("%d collections overlapped a running one", got)
+	}
+	if got := agg.CollectorStatus()["slow"].SuccessCount; got != 8 {
+		t.Errorf("SuccessCount = %d, want 8", got)
+	}
+}
+
+func TestCollectNowUnknownOrRecent(t *testing.T) {
+	fake := &fakeCollector{name: "fake", collect: func(int) (any, error) { return "ok", nil }}
+	agg := newTestAggregator(fake)
+
+	if agg.CollectNow("missing", 0) {
+		t.Error("CollectNow(missing) = true, want false")
+	}
+	if !agg.CollectNow("fake", time.Hour) {
+		t.Error("first CollectNow = false, want true")
+	}
+	if agg.CollectNow("fake", time.Hour) {
+		t.Error("CollectNow within minGap = true, want false")
+	}
+}
+
+func TestCollectAllWaitsForRunningCollection(t *testing.T) {
+	var running, overlaps atomic.Int32
+	collect := func(int) (any, error) {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return "ok", nil
+	}
+	slow := &fakeCollector{name: "slow", collect: collect}
+	agg := newTestAggregator(slow)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		agg.collectFrom(slow)
+	}()
+	if got := agg.CollectAll(0); got != 1 {
+		t.Errorf("CollectAll = %d, want 1", got)
+	}
+	<-done
+
+	if got := overlaps.Load(); got != 0 {
+		t.Errorf("%d collections overlapped a running one", got)
+	}
+	if got := agg.CollectAll(time.Hour); got != 0 {
+		t.Errorf("CollectAll within minGap = %d, want 0", got)
+	}
+}
@@ -0,0 +1,210 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProcessNetStat holds the TCP traffic of a single process
+type ProcessNetStat struct {
+	PID         int32 // 0 for traffic on sockets no visible process owns
+	Name        string
+	Connections int     // Open TCP sockets
+	RxPerSec    float64 // Bytes per second received
+	TxPerSec    float64 // Bytes per second sent (acknowledged by the peer)
+}
+
+// BandwidthMetrics holds the processes using the network, busiest first
+type BandwidthMetrics struct {
+	Processes  []ProcessNetStat
+	LastUpdate time.Time
+}
+
+// socketCounters are the bytes a socket has received and sent so far
+type socketCounters struct {
+	rx, tx uint64
+}
+
+// BandwidthCollector attributes network traffic to processes, like
+// nethogs: the kernel's per-socket TCP byte counters are matched to
+// processes through the socket inodes in /proc/<pid>/fd. UDP sockets have no
+// byte counters and aren't included. Without root only the sockets of the
+// current user's processes can be matched; the rest is reported as one
+// "unknown" entry.
+type BandwidthCollector struct {
+	interval uint
+	limit    int // Processes kept, busiest first
+	mu       sync.RWMutex
+	lastData *BandwidthMetrics
+
+	// Byte counters per socket inode from the previous sample
+	lastSockets map[uint64]socketCounters
+	lastSample  time.Time
+}
+
+// NewBandwidthCollector creates a new per-process bandwidth collector keeping
+// the top limit processes by traffic
+func NewBandwidthCollector(interval uint, limit int) *BandwidthCollector {
+	return &BandwidthCollector{
+		interval: interval,
+		limit:    limit,
+	}
+}
+
+// Name returns the collector name
+func (c *BandwidthCollector) Name() string {
+	return "bandwidth"
+}
+
+// Interval returns the update interval in seconds
+func (c *BandwidthCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers per-process bandwidth metrics. Rates need two samples, so
+// the first collection lists processes with zero traffic.
+func (c *BandwidthCollector) Collect(ctx context.Context) (interface{}, error) {
+	sockets, err := tcpSocketCounters()
+	if err != nil {
+		return nil, err
+	}
+	owners, err := socketOwners(sockets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	now := time.Now()
+	c.mu.RLock()
+	prev := c.lastSockets
+	elapsed := now.Sub(c.lastSample).Seconds()
+	c.mu.RUnlock()
+
+	stats := make(map[int32]*ProcessNetStat)
+	for inode, counters := range sockets {
+		owner, ok := owners[inode]
+		if !ok {
+			owner = socketOwner{name: "unknown"}
+		}
+		stat, ok := stats[owner.pid]
+		if !ok {
+			stat = &ProcessNetStat{PID: owner.pid, Name: owner.name}
+			stats[owner.pid] = stat
+		}
+		stat.Connections++
+
+		if prev == nil || elapsed <= 0 {
+			continue
+		}
+		// A socket missing from the previous sample was opened since, so
+		// all of its traffic falls into this interval
+		last := prev[inode]
+		if counters.rx >= last.rx {
+			stat.RxPerSec += float64(counters.rx-last.rx) / elapsed
+		}
+		if counters.tx >= last.tx {
+			stat.TxPerSec += float64(counters.tx-last.tx) / elapsed
+		}
+	}
+
+	processes := make([]ProcessNetStat, 0, len(stats))
+	for _, stat := range stats {
+		// Unowned sockets only matter when they carry traffic
+		if stat.PID == 0 && stat.RxPerSec+stat.TxPerSec == 0 {
+			continue
+		}
+		processes = append(processes, *stat)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		a, b := processes[i], processes[j]
+		if a.RxPerSec+a.TxPerSec != b.RxPerSec+b.TxPerSec {
+			return a.RxPerSec+a.TxPerSec > b.RxPerSec+b.TxPerSec
+		}
+		return a.Name < b.Name
+	})
+	if c.limit > 0 && len(processes) > c.limit {
+		processes = processes[:c.limit]
+	}
+
+	metrics := &BandwidthMetrics{
+		Processes:  processes,
+		LastUpdate: now,
+	}
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.lastSockets = sockets
+	c.lastSample = now
+	c.mu.Unlock()
+
+	return metrics, nil
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *BandwidthCollector) GetLastData() *BandwidthMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// socketOwner is the process holding a socket open
+type socketOwner struct {
+	pid  int32
+	name string
+}
+
+// socketOwners maps the given socket inodes to the process holding each,
+// by reading the "socket:[inode]" links in /proc/<pid>/fd. Sockets shared
+// after a fork go to the lowest PID. Processes that can't be read (other
+// users' without root, or ones that exit during the scan) are skipped.
+func socketOwners(sockets map[uint64]socketCounters) (map[uint64]socketOwner, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[uint64]socketOwner, len(sockets))
+	for _, entry := range entries {
+		pid, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		dir := "/proc/" + entry.Name()
+		fds, err := os.ReadDir(dir + "/fd")
+		if err != nil {
+			continue
+		}
+
+		var name string
+		for _, fd := range fds {
+			link, err := os.Readlink(dir + "/fd/" + fd.Name())
+			if err != nil {
+				continue
+			}
+			target, ok := strings.CutPrefix(link, "socket:[")
+			if !ok {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(target, "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			if _, ok := sockets[inode]; !ok {
+				continue
+			}
+			if _, ok := owners[inode]; ok {
+				continue
+			}
+			if name == "" {
+				name = readSysfsString(dir + "/comm")
+			}
+			owners[inode] = socketOwner{pid: int32(pid), name: name}
+		}
+	}
+	return owners, nil
+}
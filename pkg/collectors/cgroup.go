@@ -0,0 +1,173 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cgroupV1Root holds one mounted hierarchy per cgroup v1 controller
+const cgroupV1Root = "/sys/fs/cgroup"
+
+// cgroupCPUSample is the CPU quota of this process's cgroup and the CPU
+// time its processes used up to a point in time
+type cgroupCPUSample struct {
+	quota float64       // Cores the cgroup may use
+	usage time.Duration // CPU time used since the cgroup was created
+	at    time.Time
+}
+
+// busySince returns the cgroup's CPU usage as a percentage of its quota
+// since an earlier sample. A counter that went back yields false.
+func (s *cgroupCPUSample) busySince(prev *cgroupCPUSample) (float64, bool) {
+	if prev == nil || s.usage < prev.usage {
+		return 0, false
+	}
+	elapsed := s.at.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	percent := (s.usage - prev.usage).Seconds() / elapsed / s.quota * 100
+	return min(percent, 100), true
+}
+
+// ownCgroup returns this process's cgroup v2 directory. Inside a container
+// without a cgroup namespace the path names a host cgroup that isn't
+// mounted, and the container's own cgroup is the mount itself. It returns
+// "" on cgroup v1 only hosts.
+func ownCgroup() string {
+	dir := processCgroup("self")
+	if dir == "" {
+		return ""
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return cgroupRoot()
+	}
+	return dir
+}
+
+// ownCgroupV1 returns this process's directory in the cgroup v1 hierarchy of
+// a controller, falling back to the hierarchy's root like ownCgroup. It
+// returns "" when the controller isn't mounted.
+func ownCgroupV1(controller string) string {
+	content, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 || !slices.Contains(strings.Split(parts[1], ","), controller) {
+			continue
+		}
+		// Co-mounted controllers share a directory, e.g. cpu,cpuacct
+		mount := filepath.Join(cgroupV1Root, parts[1])
+		if _, err := os.Stat(mount); err != nil {
+			mount = filepath.Join(cgroupV1Root, controller)
+		}
+		if _, err := os.Stat(filepath.Join(mount, parts[2])); err == nil {
+			return filepath.Join(mount, parts[2])
+		}
+		if _, err := os.Stat(mount); err == nil {
+			return mount
+		}
+		return ""
+	}
+	return ""
+}
+
+// readCgroupMemory returns the memory limit of this process's cgroup and
+// its working set: usage minus inactive page cache the kernel can drop,
+// which is what container runtimes report and enforce. ok is false
+// without a limit.
+func readCgroupMemory() (limit, used uint64, ok bool) {
+	if dir := ownCgroup(); dir != "" {
+		if _, err := os.Stat(filepath.Join(dir, "memory.max")); err == nil {
+			limit, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, "memory.max")), 10, 64)
+			if err != nil {
+				// "max" means no limit
+				return 0, 0, false
+			}
+			usage, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, "memory.current")), 10, 64)
+			if err != nil {
+				return 0, 0, false
+			}
+			inactive, _ := readKeyedValue(filepath.Join(dir, "memory.stat"), "inactive_file")
+			return limit, usage - min(inactive, usage), true
+		}
+	}
+
+	dir := ownCgroupV1("memory")
+	if dir == "" {
+		return 0, 0, false
+	}
+	// An unlimited v1 cgroup reports a huge page-aligned number; callers
+	// compare the limit with physical memory
+	limit, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, "memory.limit_in_bytes")), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	usage, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, "memory.usage_in_bytes")), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	inactive, _ := readKeyedValue(filepath.Join(dir, "memory.stat"), "total_inactive_file")
+	return limit, usage - min(inactive, usage), true
+}
+
+// readCgroupCPU reads the CPU quota and cumulative usage of this process's
+// cgroup. It returns nil without a quota.
+func readCgroupCPU() *cgroupCPUSample {
+	now := time.Now()
+	if dir := ownCgroup(); dir != "" {
+		if _, err := os.Stat(filepath.Join(dir, "cpu.max")); err == nil {
+			// "$MAX $PERIOD", with "max" for no limit
+			fields := strings.Fields(readSysfsString(filepath.Join(dir, "cpu.max")))
+			if len(fields) != 2 {
+				return nil
+			}
+			quota, err1 := strconv.ParseFloat(fields[0], 64)
+			period, err2 := strconv.ParseFloat(fields[1], 64)
+			if err1 != nil || err2 != nil || quota <= 0 || period <= 0 {
+				return nil
+			}
+			usec, ok := readKeyedValue(filepath.Join(dir, "cpu.stat"), "usage_usec")
+			if !ok {
+				return nil
+			}
+			return &cgroupCPUSample{
+				quota: quota / period,
+				usage: time.Duration(usec) * time.Microsecond,
+				at:    now,
+			}
+		}
+	}
+
+	dir := ownCgroupV1("cpu")
+	if dir == "" {
+		return nil
+	}
+	// cfs_quota_us is -1 without a limit
+	quota, err1 := strconv.ParseFloat(readSysfsString(filepath.Join(dir, "cpu.cfs_quota_us")), 64)
+	period, err2 := strconv.ParseFloat(readSysfsString(filepath.Join(dir, "cpu.cfs_period_us")), 64)
+	if err1 != nil || err2 != nil || quota <= 0 || period <= 0 {
+		return nil
+	}
+	// cpuacct is usually co-mounted with cpu, but may be separate
+	usageDir := dir
+	if _, err := os.Stat(filepath.Join(usageDir, "cpuacct.usage")); err != nil {
+		usageDir = ownCgroupV1("cpuacct")
+	}
+	nsec, err := strconv.ParseUint(readSysfsString(filepath.Join(usageDir, "cpuacct.usage")), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &cgroupCPUSample{
+		quota: quota / period,
+		usage: time.Duration(nsec),
+		at:    now,
+	}
+}
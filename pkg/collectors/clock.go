@@ -0,0 +1,252 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// timeToolTimeout caps a single chronyc, ntpq or timedatectl call
+const timeToolTimeout = 3 * time.Second
+
+// ClockMetrics holds the NTP synchronization state of the system clock
+type ClockMetrics struct {
+	Source     string  // "chrony", "ntpd" or "timesyncd"; empty when no time service answered
+	Synced     bool    // The time service considers the clock synchronized
+	Offset     float64 // Milliseconds the clock is ahead of the reference; negative when behind
+	HasOffset  bool    // Offset could be read
+	Server     string  // Reference the clock follows, when known
+	Stratum    int
+	LastUpdate time.Time
+}
+
+// ClockCollector reads the clock offset and sync state from the running
+// time service: chrony, ntpd or systemd-timesyncd, tried in that order
+type ClockCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *ClockMetrics
+}
+
+// NewClockCollector creates a new clock collector
+func NewClockCollector(interval uint) *ClockCollector {
+	return &ClockCollector{
+		interval: interval,
+	}
+}
+
+// Name returns the collector name
+func (c *ClockCollector) Name() string {
+	return "clock"
+}
+
+// Interval returns the update interval in seconds
+func (c *ClockCollector) Interval() uint {
+	return c.interval
+}
+
+// timeSources lists the time services in the order they are asked. A tool
+// that is installed but whose daemon isn't running fails and the next one
+// is tried.
+var timeSources = []struct {
+	tool  string
+	query func(ctx context.Context, path string) (*ClockMetrics, error)
+}{
+	{"chronyc", queryChrony},
+	{"ntpq", queryNTPd},
+	{"timedatectl", queryTimesyncd},
+}
+
+// Collect asks the first time service that answers
+func (c *ClockCollector) Collect(ctx context.Context) (interface{}, error) {
+	metrics := &ClockMetrics{}
+	failed := make(map[string]error)
+	for _, source := range timeSources {
+		path, err := exec.LookPath(source.tool)
+		if err != nil {
+			continue
+		}
+		result, err := source.query(ctx, path)
+		if err != nil {
+			failed[source.tool] = err
+			continue
+		}
+		metrics = result
+		// The services that did answer are all that matter
+		failed = nil
+		break
+	}
+	metrics.LastUpdate = time.Now()
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, data.NewPartialError(failed)
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *ClockCollector) GetLastData() *ClockMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// queryChrony reads chronyd's tracking report
+func queryChrony(ctx context.Context, path string) (*ClockMetrics, error) {
+	out, err := runTimeTool(ctx, path, "-c", "tracking")
+	if err != nil {
+		return nil, err
+	}
+	return parseChronyTracking(string(out))
+}
+
+// parseChronyTracking parses `chronyc -c tracking`, e.g.
+//
+//	A9FEA97B,169.254.169.123,4,1718026812.123456789,-0.000012345,...,Normal
+//
+// The fields are the reference ID and name, stratum, reference time, the
+// system time correction in seconds (positive when the clock is slow),
+// eight more statistics and the leap status.
+func parseChronyTracking(output string) (*ClockMetrics, error) {
+	record, err := csv.NewReader(strings.NewReader(output)).Read()
+	if err != nil || len(record) < 14 {
+		return nil, fmt.Errorf("unexpected chronyc output %q", firstLine(output))
+	}
+	correction, err := strconv.ParseFloat(record[4], 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected chronyc system time %q", record[4])
+	}
+	stratum, _ := strconv.Atoi(record[2])
+
+	return &ClockMetrics{
+		Source:    "chrony",
+		Synced:    record[13] != "Not synchronised",
+		Offset:    -correction * 1000,
+		HasOffset: true,
+		Server:    record[1],
+		Stratum:   stratum,
+	}, nil
+}
+
+// queryNTPd reads ntpd's system variables
+func queryNTPd(ctx context.Context, path string) (*ClockMetrics, error) {
+	out, err := runTimeTool(ctx, path, "-c", "rv")
+	if err != nil {
+		return nil, err
+	}
+	return parseNTPVariables(string(out))
+}
+
+// parseNTPVariables parses `ntpq -c rv`, a comma separated list of
+// variables spread over several lines, e.g.
+//
+//	associd=0 status=0615 leap_none, sync_ntp, 1 event, clock_sync,
+//	leap=00, stratum=2, refid=192.168.1.1, offset=-0.123, frequency=-12.5
+//
+// The offset is in milliseconds. Leap indicator 11 means unsynchronized.
+func parseNTPVariables(output string) (*ClockMetrics, error) {
+	vars := make(map[string]string)
+	for _, field := range strings.FieldsFunc(output, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	}) {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			vars[key] = strings.Trim(value, `"`)
+		}
+	}
+
+	leap, ok := vars["leap"]
+	if !ok {
+		return nil, fmt.Errorf("unexpected ntpq output %q", firstLine(output))
+	}
+	metrics := &ClockMetrics{
+		Source: "ntpd",
+		Synced: leap != "11" && leap != "3",
+		Server: vars["refid"],
+	}
+	metrics.Stratum, _ = strconv.Atoi(vars["stratum"])
+	if offset, err := strconv.ParseFloat(vars["offset"], 64); err == nil {
+		metrics.Offset = offset
+		metrics.HasOffset = true
+	}
+	return metrics, nil
+}
+
+// queryTimesyncd reads the sync state timedatectl reports and, when
+// systemd-timesyncd is the time service, its last measured offset
+func queryTimesyncd(ctx context.Context, path string) (*ClockMetrics, error) {
+	out, err := runTimeTool(ctx, path, "show", "-p", "NTPSynchronized", "--value")
+	if err != nil {
+		return nil, err
+	}
+	metrics := &ClockMetrics{
+		Source: "timesyncd",
+		Synced: strings.TrimSpace(string(out)) == "yes",
+	}
+
+	// timesync-status only works with systemd-timesyncd running
+	if out, err := runTimeTool(ctx, path, "timesync-status"); err == nil {
+		parseTimesyncStatus(string(out), metrics)
+	}
+	return metrics, nil
+}
+
+// parseTimesyncStatus fills in the server, stratum and offset from
+// `timedatectl timesync-status`, e.g.
+//
+//	 Server: 185.125.190.56 (ntp.ubuntu.com)
+//	Stratum: 2
+//	 Offset: -2.016ms
+func parseTimesyncStatus(output string, metrics *ClockMetrics) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Server":
+			metrics.Server = value
+		case "Stratum":
+			metrics.Stratum, _ = strconv.Atoi(value)
+		case "Offset":
+			if offset, err := time.ParseDuration(value); err == nil {
+				metrics.Offset = float64(offset) / float64(time.Millisecond)
+				metrics.HasOffset = true
+			}
+		}
+	}
+}
+
+// runTimeTool runs a time service client and returns its output
+func runTimeTool(ctx context.Context, path string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeToolTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s timed out after %s", filepath.Base(path), timeToolTimeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
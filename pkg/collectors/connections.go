@@ -0,0 +1,150 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	gonet "github.com/shirou/gopsutil/v4/net"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// ConnectionStat describes a single TCP or UDP socket
+type ConnectionStat struct {
+	Protocol   string // "tcp", "tcp6", "udp" or "udp6"
+	LocalAddr  string
+	RemoteAddr string // Empty for listening and unconnected sockets
+	Status     string // TCP state such as ESTABLISHED; empty for UDP
+	PID        int32  // 0 when the owning process isn't visible
+	Process    string
+}
+
+// ConnectionMetrics holds every open TCP and UDP socket
+type ConnectionMetrics struct {
+	Connections []ConnectionStat // Grouped by process, then state
+	States      map[string]int   // Socket count per TCP state, and UDP sockets under "UDP"
+	LastUpdate  time.Time
+}
+
+// ConnectionCollector lists open TCP and UDP sockets and counts them by
+// state, to help spot connection leaks (e.g. piling up in CLOSE_WAIT).
+// Without root, the owning process is only known for the current user's
+// sockets.
+type ConnectionCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *ConnectionMetrics
+}
+
+// NewConnectionCollector creates a new connection collector
+func NewConnectionCollector(interval uint) *ConnectionCollector {
+	return &ConnectionCollector{
+		interval: interval,
+	}
+}
+
+// Name returns the collector name
+func (c *ConnectionCollector) Name() string {
+	return "connections"
+}
+
+// Interval returns the update interval in seconds
+func (c *ConnectionCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers connection metrics
+func (c *ConnectionCollector) Collect(ctx context.Context) (interface{}, error) {
+	conns, err := gonet.ConnectionsWithoutUidsWithContext(ctx, "inet")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	names := make(map[int32]string)
+	connections := make([]ConnectionStat, 0, len(conns))
+	states := make(map[string]int)
+	for _, conn := range conns {
+		stat := ConnectionStat{
+			Protocol:  connectionProtocol(conn),
+			LocalAddr: formatConnAddr(conn.Laddr),
+			PID:       conn.Pid,
+		}
+		if conn.Raddr.IP != "" && conn.Raddr.Port != 0 {
+			stat.RemoteAddr = formatConnAddr(conn.Raddr)
+		}
+		if conn.Type == syscall.SOCK_STREAM {
+			stat.Status = conn.Status
+			states[conn.Status]++
+		} else {
+			states["UDP"]++
+		}
+
+		if conn.Pid > 0 {
+			name, ok := names[conn.Pid]
+			if !ok {
+				if proc, err := process.NewProcessWithContext(ctx, conn.Pid); err == nil {
+					name, _ = proc.NameWithContext(ctx)
+				}
+				names[conn.Pid] = name
+			}
+			stat.Process = name
+		}
+		connections = append(connections, stat)
+	}
+
+	sort.Slice(connections, func(i, j int) bool {
+		a, b := connections[i], connections[j]
+		if a.Process != b.Process {
+			// Sockets of unknown processes go last
+			return b.Process == "" || (a.Process != "" && a.Process < b.Process)
+		}
+		if a.PID != b.PID {
+			return a.PID < b.PID
+		}
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		return a.LocalAddr < b.LocalAddr
+	})
+
+	metrics := &ConnectionMetrics{
+		Connections: connections,
+		States:      states,
+		LastUpdate:  time.Now(),
+	}
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, nil
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *ConnectionCollector) GetLastData() *ConnectionMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// connectionProtocol names a socket's protocol the way netstat does
+func connectionProtocol(conn gonet.ConnectionStat) string {
+	protocol := "udp"
+	if conn.Type == syscall.SOCK_STREAM {
+		protocol = "tcp"
+	}
+	if conn.Family == syscall.AF_INET6 {
+		protocol += "6"
+	}
+	return protocol
+}
+
+// formatConnAddr formats an address as host:port, bracketing IPv6 hosts
+func formatConnAddr(addr gonet.Addr) string {
+	return net.JoinHostPort(addr.IP, strconv.FormatUint(uint64(addr.Port), 10))
+}
@@ -0,0 +1,398 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// defaultDockerSocket is where the Docker daemon listens unless DOCKER_HOST
+// points elsewhere
+const defaultDockerSocket = "/var/run/docker.sock"
+
+// rootPodmanSocket is where a rootful Podman service listens; rootless
+// Podman uses $XDG_RUNTIME_DIR/podman/podman.sock
+const rootPodmanSocket = "/run/podman/podman.sock"
+
+// containerAPITimeout caps a whole collection's worth of API calls
+const containerAPITimeout = 5 * time.Second
+
+// ContainerStat holds usage data for a single running container
+type ContainerStat struct {
+	ID          string // Short (12 character) ID
+	Name        string
+	Image       string
+	Runtime     string  // "docker", "podman" or "containerd"
+	CPU         float64 // Percent of one core since the previous sample
+	MemoryUsed  uint64  // Excluding reclaimable page cache, like docker stats
+	MemoryLimit uint64  // 0 when unlimited
+	NetRxPerSec float64 // Bytes per second over all container interfaces
+	NetTxPerSec float64
+}
+
+// ContainerMetrics holds data for every running container
+type ContainerMetrics struct {
+	Runtimes   []string // Runtimes found, empty when there are none
+	Containers []ContainerStat
+	LastUpdate time.Time
+}
+
+// ContainerCollector collects per-container CPU, memory and network usage.
+// Docker and Podman are read through their Docker-compatible Engine API
+// sockets, found at a unix:// DOCKER_HOST or CONTAINER_HOST, the default
+// Docker socket, and the rootless and rootful Podman sockets. containerd
+// (including k3s) is read from its task state and cgroup v2 counters, see
+// collectContainerd. Runtimes that aren't present are skipped.
+type ContainerCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *ContainerMetrics
+
+	// HTTP clients per socket path, created on first use
+	clients map[string]*http.Client
+
+	// Counters per runtime and container ID from the previous sample
+	lastSamples map[string]containerSample
+}
+
+// containerSample is a container's cumulative counters at one point in time
+type containerSample struct {
+	cpu    uint64 // Container CPU time in nanoseconds
+	system uint64 // Host CPU time in nanoseconds, summed over all CPUs (Engine API only)
+	rx     uint64
+	tx     uint64
+	at     time.Time
+}
+
+// engineSocket is a Docker Engine API compatible socket
+type engineSocket struct {
+	runtime string
+	path    string
+}
+
+// NewContainerCollector creates a new container collector
+func NewContainerCollector(interval uint) *ContainerCollector {
+	return &ContainerCollector{
+		interval:    interval,
+		clients:     make(map[string]*http.Client),
+		lastSamples: make(map[string]containerSample),
+	}
+}
+
+// Name returns the collector name
+func (c *ContainerCollector) Name() string {
+	return "containers"
+}
+
+// Interval returns the update interval in seconds
+func (c *ContainerCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers container metrics from every runtime found. Finding no
+// runtime is not an error; a runtime or container that can't be read is
+// reported as a partial failure.
+func (c *ContainerCollector) Collect(ctx context.Context) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, containerAPITimeout)
+	defer cancel()
+
+	c.mu.RLock()
+	prev := c.lastSamples
+	c.mu.RUnlock()
+
+	// Docker reports the host's total memory as the limit of unconstrained
+	// containers
+	var hostTotal uint64
+	if vmem, err := mem.VirtualMemoryWithContext(ctx); err == nil {
+		hostTotal = vmem.Total
+	}
+
+	now := time.Now()
+	samples := make(map[string]containerSample)
+	failed := make(map[string]error)
+	metrics := &ContainerMetrics{LastUpdate: now}
+
+	for _, socket := range engineSockets() {
+		containers, err := c.collectEngine(ctx, socket, hostTotal, now, prev, samples, failed)
+		if err != nil {
+			failed[socket.runtime] = err
+			continue
+		}
+		metrics.Runtimes = append(metrics.Runtimes, socket.runtime)
+		metrics.Containers = append(metrics.Containers, containers...)
+	}
+
+	containers, found, err := collectContainerd(now, prev, samples)
+	if found {
+		metrics.Runtimes = append(metrics.Runtimes, "containerd")
+		metrics.Containers = append(metrics.Containers, containers...)
+	} else if err != nil && len(metrics.Runtimes) == 0 {
+		// containerd's state is often root-only even on hosts that only use
+		// it under Docker, so only complain when nothing else was found
+		failed["containerd"] = err
+	}
+
+	if len(metrics.Runtimes) == 0 && len(failed) > 0 {
+		return nil, data.NewPartialError(failed)
+	}
+
+	sort.SliceStable(metrics.Containers, func(i, j int) bool {
+		return metrics.Containers[i].CPU > metrics.Containers[j].CPU
+	})
+
+	c.mu.Lock()
+	c.lastSamples = samples
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, data.NewPartialError(failed)
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *ContainerCollector) GetLastData() *ContainerMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// engineSockets returns the Engine API sockets that exist, each once even
+// when several paths lead to it. /var/run/docker.sock is often a symlink to
+// Podman's socket (podman-docker), so the runtime is named after where the
+// socket really lives.
+func engineSockets() []engineSocket {
+	var candidates []engineSocket
+	for _, env := range []string{"DOCKER_HOST", "CONTAINER_HOST"} {
+		if host := os.Getenv(env); strings.HasPrefix(host, "unix://") {
+			candidates = append(candidates, engineSocket{"docker", strings.TrimPrefix(host, "unix://")})
+		}
+	}
+	candidates = append(candidates, engineSocket{"docker", defaultDockerSocket})
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		candidates = append(candidates, engineSocket{"podman", dir + "/podman/podman.sock"})
+	}
+	candidates = append(candidates, engineSocket{"podman", rootPodmanSocket})
+
+	var sockets []engineSocket
+	seen := make(map[string]bool, len(candidates))
+	for _, socket := range candidates {
+		resolved, err := filepath.EvalSymlinks(socket.path)
+		if err != nil || seen[resolved] {
+			continue
+		}
+		seen[resolved] = true
+		if strings.Contains(resolved, "podman") {
+			socket.runtime = "podman"
+		}
+		sockets = append(sockets, socket)
+	}
+	return sockets
+}
+
+// collectEngine reads every running container from an Engine API socket.
+// Containers whose stats can't be read are added to failed.
+func (c *ContainerCollector) collectEngine(ctx context.Context, socket engineSocket, hostTotal uint64, now time.Time, prev, samples map[string]containerSample, failed map[string]error) ([]ContainerStat, error) {
+	client := c.client(socket.path)
+
+	var list []dockerContainer
+	if err := engineGet(ctx, client, "/containers/json", &list); err != nil {
+		if errors.Is(err, syscall.EACCES) {
+			return nil, fmt.Errorf("no permission to use %s (is your user in the %s group?)", socket.path, socket.runtime)
+		}
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	// Fetch stats concurrently; each call can take a moment per container
+	stats := make([]*dockerStats, len(list))
+	errs := make([]error, len(list))
+	var wg sync.WaitGroup
+	for i, container := range list {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var s dockerStats
+			errs[i] = engineGet(ctx, client, "/containers/"+container.ID+"/stats?stream=false&one-shot=true", &s)
+			stats[i] = &s
+		}()
+	}
+	wg.Wait()
+
+	containers := make([]ContainerStat, 0, len(list))
+	for i, container := range list {
+		name := container.name()
+		if errs[i] != nil {
+			failed[name] = errs[i]
+			continue
+		}
+		s := stats[i]
+
+		key := socket.runtime + "/" + container.ID
+		sample := containerSample{
+			cpu:    s.CPUStats.CPUUsage.TotalUsage,
+			system: s.CPUStats.SystemCPUUsage,
+			at:     now,
+		}
+		for _, iface := range s.Networks {
+			sample.rx += iface.RxBytes
+			sample.tx += iface.TxBytes
+		}
+		samples[key] = sample
+
+		stat := ContainerStat{
+			ID:          shortID(container.ID),
+			Name:        name,
+			Image:       container.Image,
+			Runtime:     socket.runtime,
+			MemoryUsed:  s.MemoryStats.used(),
+			MemoryLimit: s.MemoryStats.Limit,
+		}
+		if hostTotal > 0 && stat.MemoryLimit >= hostTotal {
+			stat.MemoryLimit = 0
+		}
+
+		if last, ok := prev[key]; ok {
+			if sample.cpu >= last.cpu && sample.system > last.system {
+				cpus := float64(s.CPUStats.OnlineCPUs)
+				if cpus == 0 {
+					cpus = float64(len(s.CPUStats.CPUUsage.PercpuUsage))
+				}
+				stat.CPU = float64(sample.cpu-last.cpu) / float64(sample.system-last.system) * cpus * 100
+			}
+			stat.NetRxPerSec, stat.NetTxPerSec = netRates(sample, last)
+		}
+
+		containers = append(containers, stat)
+	}
+
+	return containers, nil
+}
+
+// netRates returns receive and transmit rates between two samples
+func netRates(sample, last containerSample) (rx, tx float64) {
+	elapsed := sample.at.Sub(last.at).Seconds()
+	if elapsed <= 0 || sample.rx < last.rx || sample.tx < last.tx {
+		return 0, 0
+	}
+	return float64(sample.rx-last.rx) / elapsed, float64(sample.tx-last.tx) / elapsed
+}
+
+// client returns the HTTP client for a socket
+func (c *ContainerCollector) client(socket string) *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	client, ok := c.clients[socket]
+	if !ok {
+		client = unixSocketClient(socket)
+		c.clients[socket] = client
+	}
+	return client
+}
+
+// engineGet performs a GET against the Engine API and decodes the JSON response
+func engineGet(ctx context.Context, client *http.Client, path string, out any) error {
+	// The host part is ignored; requests always go to the socket
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker"+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s: %s", resp.Status, apiErr.Message)
+		}
+		return errors.New(resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// unixSocketClient returns an HTTP client that sends every request over the
+// given unix socket
+func unixSocketClient(socket string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+			MaxIdleConnsPerHost: 4,
+		},
+	}
+}
+
+// dockerContainer is an entry of GET /containers/json
+type dockerContainer struct {
+	ID    string   `json:"Id"`
+	Names []string `json:"Names"`
+	Image string   `json:"Image"`
+}
+
+// name returns the container's primary name without the leading slash
+func (d dockerContainer) name() string {
+	if len(d.Names) == 0 {
+		return shortID(d.ID)
+	}
+	return strings.TrimPrefix(d.Names[0], "/")
+}
+
+// dockerStats is the subset of GET /containers/{id}/stats that is used
+type dockerStats struct {
+	CPUStats struct {
+		CPUUsage struct {
+			TotalUsage  uint64   `json:"total_usage"`
+			PercpuUsage []uint64 `json:"percpu_usage"`
+		} `json:"cpu_usage"`
+		SystemCPUUsage uint64 `json:"system_cpu_usage"`
+		OnlineCPUs     uint32 `json:"online_cpus"`
+	} `json:"cpu_stats"`
+	MemoryStats dockerMemoryStats `json:"memory_stats"`
+	Networks    map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+}
+
+// dockerMemoryStats is a container's memory usage as reported by Docker
+type dockerMemoryStats struct {
+	Usage uint64            `json:"usage"`
+	Limit uint64            `json:"limit"`
+	Stats map[string]uint64 `json:"stats"`
+}
+
+// used returns memory usage minus inactive page cache, matching the
+// docker stats command for both cgroup v1 and v2 hosts
+func (m dockerMemoryStats) used() uint64 {
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if inactive, ok := m.Stats[key]; ok && inactive < m.Usage {
+			return m.Usage - inactive
+		}
+	}
+	return m.Usage
+}
+
+// shortID shortens a container ID the way the docker CLI does
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,216 @@
+package collectors
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// containerdTaskRoots are where containerd keeps the state of running
+// containers, one directory per namespace: stock containerd and k3s
+var containerdTaskRoots = []string{
+	"/run/containerd/io.containerd.runtime.v2.task",
+	"/run/k3s/containerd/io.containerd.runtime.v2.task",
+}
+
+// cgroupRoot is where the unified (v2) cgroup hierarchy is mounted. Hybrid
+// hosts mount it under unified/, with memory still accounted in cgroup v1.
+func cgroupRoot() string {
+	if _, err := os.Stat("/sys/fs/cgroup/cgroup.controllers"); err != nil {
+		if _, err := os.Stat("/sys/fs/cgroup/unified/cgroup.controllers"); err == nil {
+			return "/sys/fs/cgroup/unified"
+		}
+	}
+	return "/sys/fs/cgroup"
+}
+
+// OCI annotations naming Kubernetes (CRI) and nerdctl containers
+const (
+	criContainerType = "io.kubernetes.cri.container-type"
+	criContainerName = "io.kubernetes.cri.container-name"
+	criSandboxName   = "io.kubernetes.cri.sandbox-name"
+	criImageName     = "io.kubernetes.cri.image-name"
+	nerdctlName      = "nerdctl/name"
+)
+
+// collectContainerd reads every running containerd container from the task
+// state directories and the container's cgroup v2 and /proc counters, which
+// needs no client library or API socket. The "moby" namespace is skipped
+// since those are Docker's containers, read through its API instead. found
+// is false when containerd has no other namespaces; err is set when its
+// state exists but can't be read (usually it needs root).
+func collectContainerd(now time.Time, prev, samples map[string]containerSample) (containers []ContainerStat, found bool, err error) {
+	for _, root := range containerdTaskRoots {
+		namespaces, readErr := os.ReadDir(root)
+		if readErr != nil {
+			if !errors.Is(readErr, fs.ErrNotExist) {
+				err = fmt.Errorf("failed to read containerd state: %w", readErr)
+			}
+			continue
+		}
+
+		for _, namespace := range namespaces {
+			if !namespace.IsDir() || namespace.Name() == "moby" {
+				continue
+			}
+			found = true
+
+			dir := filepath.Join(root, namespace.Name())
+			tasks, readErr := os.ReadDir(dir)
+			if readErr != nil {
+				err = fmt.Errorf("failed to read containerd namespace %s: %w", namespace.Name(), readErr)
+				continue
+			}
+			for _, task := range tasks {
+				key := "containerd/" + namespace.Name() + "/" + task.Name()
+				stat, sample, ok := readContainerdTask(filepath.Join(dir, task.Name()), now)
+				if !ok {
+					continue
+				}
+				samples[key] = sample
+
+				if last, ok := prev[key]; ok {
+					if elapsed := sample.at.Sub(last.at); elapsed > 0 && sample.cpu >= last.cpu {
+						stat.CPU = float64(sample.cpu-last.cpu) / float64(elapsed) * 100
+					}
+					stat.NetRxPerSec, stat.NetTxPerSec = netRates(sample, last)
+				}
+				containers = append(containers, stat)
+			}
+		}
+	}
+
+	if found {
+		err = nil
+	}
+	return containers, found, err
+}
+
+// readContainerdTask reads one task (container) bundle directory. ok is
+// false for pod sandboxes and for tasks that exited or can't be read.
+func readContainerdTask(bundle string, now time.Time) (stat ContainerStat, sample containerSample, ok bool) {
+	var spec struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+	content, err := os.ReadFile(bundle + "/config.json")
+	if err != nil || json.Unmarshal(content, &spec) != nil {
+		return stat, sample, false
+	}
+	// Kubernetes pause containers only hold the pod's namespaces
+	if spec.Annotations[criContainerType] == "sandbox" {
+		return stat, sample, false
+	}
+
+	pid := readSysfsString(bundle + "/init.pid")
+	if pid == "" {
+		return stat, sample, false
+	}
+	cgroup := processCgroup(pid)
+	if cgroup == "" {
+		return stat, sample, false
+	}
+
+	id := filepath.Base(bundle)
+	stat = ContainerStat{
+		ID:      shortID(id),
+		Name:    shortID(id),
+		Image:   spec.Annotations[criImageName],
+		Runtime: "containerd",
+	}
+	if pod, name := spec.Annotations[criSandboxName], spec.Annotations[criContainerName]; name != "" {
+		stat.Name = pod + "/" + name
+	} else if name := spec.Annotations[nerdctlName]; name != "" {
+		stat.Name = name
+	}
+
+	usec, ok := readKeyedValue(cgroup+"/cpu.stat", "usage_usec")
+	if !ok {
+		return stat, sample, false
+	}
+	sample.cpu = usec * 1000
+	sample.at = now
+
+	if current, err := strconv.ParseUint(readSysfsString(cgroup+"/memory.current"), 10, 64); err == nil {
+		stat.MemoryUsed = current
+		if inactive, ok := readKeyedValue(cgroup+"/memory.stat", "inactive_file"); ok && inactive < current {
+			stat.MemoryUsed = current - inactive
+		}
+	}
+	// memory.max reads "max" when unlimited, which leaves the limit at 0
+	if limit, err := strconv.ParseUint(readSysfsString(cgroup+"/memory.max"), 10, 64); err == nil {
+		stat.MemoryLimit = limit
+	}
+
+	sample.rx, sample.tx = readNetDev("/proc/" + pid + "/net/dev")
+	return stat, sample, true
+}
+
+// processCgroup returns the cgroup v2 directory of a process, or "" on
+// cgroup v1 only hosts and when the process is gone
+func processCgroup(pid string) string {
+	content, err := os.ReadFile("/proc/" + pid + "/cgroup")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			return cgroupRoot() + path
+		}
+	}
+	return ""
+}
+
+// readKeyedValue reads the value of one "key value" line from a cgroup
+// file such as cpu.stat or memory.stat
+func readKeyedValue(path, key string) (uint64, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == key {
+			value, err := strconv.ParseUint(fields[1], 10, 64)
+			return value, err == nil
+		}
+	}
+	return 0, false
+}
+
+// readNetDev sums received and transmitted bytes over all interfaces but
+// loopback in a /proc/<pid>/net/dev file, i.e. in that process's network
+// namespace
+func readNetDev(path string) (rx, tx uint64) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		name, counters, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "lo" {
+			continue
+		}
+		// Receive bytes is the first field, transmit bytes the ninth
+		fields := strings.Fields(counters)
+		if len(fields) < 9 {
+			continue
+		}
+		if value, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			rx += value
+		}
+		if value, err := strconv.ParseUint(fields[8], 10, 64); err == nil {
+			tx += value
+		}
+	}
+	return rx, tx
+}
@@ -0,0 +1,174 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cpuSysfsPath is where Linux describes each logical CPU's topology
+const cpuSysfsPath = "/sys/devices/system/cpu"
+
+// hwmonCoreTemperatures maps each logical CPU to the temperature of the
+// core it runs on, from the hwmon sensors in sysfs. Intel's coretemp has a
+// sensor per physical core; AMD's k10temp only has one per CCD (core
+// complex die), which every core on that die shares. Returns nil when
+// neither driver is loaded.
+func hwmonCoreTemperatures() map[int]float64 {
+	entries, err := os.ReadDir("/sys/class/hwmon")
+	if err != nil {
+		return nil
+	}
+
+	coreTemps := make(map[[2]int]float64) // {package, core} -> °C
+	var ccdTemps []float64                // Tccd1, Tccd2, ... in order
+	k10temps := 0
+	for _, entry := range entries {
+		devicePath := filepath.Join("/sys/class/hwmon", entry.Name())
+		name, err := readDeviceName(devicePath)
+		if err != nil {
+			continue
+		}
+		switch name {
+		case "coretemp":
+			readCoretemp(devicePath, coreTemps)
+		case "k10temp":
+			k10temps++
+			ccdTemps = readK10tempCCDs(devicePath)
+		}
+	}
+
+	temps := make(map[int]float64)
+	if len(coreTemps) > 0 {
+		for _, cpu := range logicalCPUs() {
+			pkg, pkgErr := strconv.Atoi(readSysfsString(filepath.Join(cpuSysfsPath, cpu.name, "topology/physical_package_id")))
+			core, coreErr := strconv.Atoi(readSysfsString(filepath.Join(cpuSysfsPath, cpu.name, "topology/core_id")))
+			if pkgErr != nil || coreErr != nil {
+				continue
+			}
+			if temp, ok := coreTemps[[2]int{pkg, core}]; ok {
+				temps[cpu.id] = temp
+			}
+		}
+	}
+	// With several sockets the CCD readings of each can't be told apart
+	if len(ccdTemps) > 0 && k10temps == 1 {
+		mapCCDTemperatures(ccdTemps, temps)
+	}
+	if len(temps) == 0 {
+		return nil
+	}
+	return temps
+}
+
+// readCoretemp reads the "Core N" sensors of one coretemp device, which
+// covers one package, into temps keyed by package and core ID
+func readCoretemp(devicePath string, temps map[[2]int]float64) {
+	labels, _ := filepath.Glob(filepath.Join(devicePath, "temp*_label"))
+	pkg := -1
+	cores := make(map[int]float64)
+	for _, labelPath := range labels {
+		label := readSysfsString(labelPath)
+		fields := strings.Fields(label)
+		if len(fields) == 0 {
+			continue
+		}
+		id, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(label, "Package id"):
+			pkg = id
+		case strings.HasPrefix(label, "Core"):
+			temp, err := readHwmonTemp(strings.TrimSuffix(labelPath, "_label") + "_input")
+			if err == nil {
+				cores[id] = temp
+			}
+		}
+	}
+	if pkg < 0 {
+		return
+	}
+	for core, temp := range cores {
+		temps[[2]int{pkg, core}] = temp
+	}
+}
+
+// readK10tempCCDs reads the Tccd sensors of a k10temp device, ordered by
+// CCD number. Zen CPUs before Zen 2 report none.
+func readK10tempCCDs(devicePath string) []float64 {
+	labels, _ := filepath.Glob(filepath.Join(devicePath, "temp*_label"))
+	ccds := make(map[int]float64)
+	for _, labelPath := range labels {
+		n, err := strconv.Atoi(strings.TrimPrefix(readSysfsString(labelPath), "Tccd"))
+		if err != nil {
+			continue
+		}
+		temp, err := readHwmonTemp(strings.TrimSuffix(labelPath, "_label") + "_input")
+		if err == nil {
+			ccds[n] = temp
+		}
+	}
+
+	numbers := make([]int, 0, len(ccds))
+	for n := range ccds {
+		numbers = append(numbers, n)
+	}
+	slices.Sort(numbers)
+	temps := make([]float64, len(numbers))
+	for i, n := range numbers {
+		temps[i] = ccds[n]
+	}
+	return temps
+}
+
+// mapCCDTemperatures assigns each CCD's reading to its cores. sysfs doesn't
+// name a core's CCD, but cores on one CCX share an L3 cache and each CCD
+// holds the same number of CCXs (two on Zen 2, one since Zen 3), so the
+// L3 caches in ID order are split evenly between the CCDs.
+func mapCCDTemperatures(ccdTemps []float64, temps map[int]float64) {
+	l3 := make(map[int]int) // Logical CPU -> L3 cache ID
+	var ids []int
+	for _, cpu := range logicalCPUs() {
+		id, err := strconv.Atoi(readSysfsString(filepath.Join(cpuSysfsPath, cpu.name, "cache/index3/id")))
+		if err != nil {
+			continue
+		}
+		l3[cpu.id] = id
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 || len(ids)%len(ccdTemps) != 0 {
+		return
+	}
+	slices.Sort(ids)
+	perCCD := len(ids) / len(ccdTemps)
+	for cpu, id := range l3 {
+		temps[cpu] = ccdTemps[slices.Index(ids, id)/perCCD]
+	}
+}
+
+// logicalCPU is an online or offline CPU directory in sysfs
+type logicalCPU struct {
+	name string // e.g. "cpu3"
+	id   int
+}
+
+// logicalCPUs lists the CPUs in sysfs. Offline CPUs have no topology and
+// are skipped by the callers' reads.
+func logicalCPUs() []logicalCPU {
+	paths, _ := filepath.Glob(filepath.Join(cpuSysfsPath, "cpu[0-9]*"))
+	cpus := make([]logicalCPU, 0, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		id, err := strconv.Atoi(strings.TrimPrefix(name, "cpu"))
+		if err == nil {
+			cpus = append(cpus, logicalCPU{name: name, id: id})
+		}
+	}
+	return cpus
+}
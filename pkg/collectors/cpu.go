@@ -3,33 +3,73 @@ package collectors
 import (
 	"context"
 	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ctcac00/metrics-tui/internal/data"
 	"github.com/shirou/gopsutil/v4/cpu"
 )
 
 // CPUMetrics holds CPU usage data
 type CPUMetrics struct {
-	Usage      []float64 // Per-core usage percentage
-	Total      float64   // Combined usage percentage
-	CoreCount  int       // Number of logical cores
-	Times      []cpu.TimesStat
-	LastUpdate time.Time
+	Usage       []float64 // Per-core usage percentage
+	Total       float64   // Combined usage percentage
+	CoreCount   int       // Number of logical cores
+	LoadCores   int       // Cores load percent is relative to (physical when not logical)
+	CoreIDs     []int     // CPU number for each Usage entry
+	Offline     []int     // CPU numbers present but offline (Linux)
+	Times       []cpu.TimesStat
+	Breakdown   *CPUBreakdown // Aggregate split since the last sample, nil on the first
+	Power       []PowerStat   // Power per domain from RAPL or powermetrics, nil when unavailable
+	CgroupCores float64       // CPU quota of this process's cgroup in cores; Total is relative to it when set
+	LastUpdate  time.Time
+}
+
+// CPUBreakdown splits aggregate CPU time into categories, in percent of
+// total time across all cores
+type CPUBreakdown struct {
+	User   float64 // user + nice
+	System float64
+	IOWait float64
+	Other  float64 // irq, softirq, steal
+	Idle   float64
 }
 
 // CPUCollector collects CPU metrics
 type CPUCollector struct {
-	interval uint
-	mu       sync.RWMutex
-	lastData *CPUMetrics
+	interval   uint
+	logical    bool // Relate load to logical cores rather than physical ones
+	power      bool // Read RAPL energy counters, or powermetrics on macOS
+	cgroup     bool // Report usage against the cgroup's CPU quota when one is set
+	mu         sync.RWMutex
+	lastData   *CPUMetrics
+	lastTimes  *cpu.TimesStat  // Aggregate times from the previous sample
+	lastCores  []cpu.TimesStat // Per-core times from the previous sample
+	raplZones  []raplZone      // Found on the first collection
+	lastEnergy map[string]raplSample
+	lastCgroup *cgroupCPUSample // Cgroup CPU usage from the previous sample
 }
 
+// initialSampleWindow is how long the first collection measures usage over.
+// Later collections compare against the previous sample instead of blocking.
+const initialSampleWindow = 250 * time.Millisecond
+
 // NewCPUCollector creates a new CPU collector
-func NewCPUCollector(interval uint) *CPUCollector {
-	return &CPUCollector{
+func NewCPUCollector(interval uint, logical, power, cgroup bool) *CPUCollector {
+	c := &CPUCollector{
 		interval: interval,
+		logical:  logical,
+		power:    power,
+		cgroup:   cgroup,
 	}
+	if power {
+		c.raplZones = findRAPLZones()
+	}
+	return c
 }
 
 // Name returns the collector name
@@ -50,11 +90,49 @@ func (c *CPUCollector) Collect(ctx context.Context) (interface{}, error) {
 		return nil, fmt.Errorf("failed to get CPU counts: %w", err)
 	}
 
-	// Get per-core and total usage
-	percentages, err := cpu.Percent(time.Duration(c.interval)*time.Second, true)
+	// Load percent can be relative to physical cores instead; per-core
+	// usage below is always per logical CPU
+	failed := make(map[string]error)
+	loadCores := cores
+	if !c.logical {
+		physical, err := cpu.Counts(false)
+		if err != nil {
+			failed["physical CPU count"] = err
+		} else if physical > 0 {
+			loadCores = physical
+		}
+	}
+
+	// Per-core usage is the busy share of CPU time since the previous
+	// sample. Without one yet, take a baseline and measure over a short
+	// window so the first result doesn't wait a full interval.
+	times, err := cpu.TimesWithContext(ctx, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get CPU percentages: %w", err)
+		return nil, fmt.Errorf("failed to get CPU times: %w", err)
 	}
+	c.mu.RLock()
+	prev := c.lastCores
+	lastEnergy := c.lastEnergy
+	lastCgroup := c.lastCgroup
+	c.mu.RUnlock()
+	if len(prev) != len(times) {
+		prev = times
+		// Baseline the energy and cgroup counters over the same window
+		_, lastEnergy = readRAPLPower(c.raplZones, nil, time.Now())
+		if c.cgroup {
+			lastCgroup = readCgroupCPU()
+		}
+		select {
+		case <-time.After(initialSampleWindow):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		times, err = cpu.TimesWithContext(ctx, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CPU times: %w", err)
+		}
+	}
+	percentages := corePercents(prev, times)
 
 	// Calculate total usage from individual cores
 	var total float64
@@ -66,26 +144,69 @@ func (c *CPUCollector) Collect(ctx context.Context) (interface{}, error) {
 		total = sum / float64(len(percentages))
 	}
 
-	// Get CPU times for more detailed info
-	times, err := cpu.Times(true)
-	if err != nil {
-		// Times are optional, continue without them
-		times = []cpu.TimesStat{}
+	// Inside a container with a CPU quota, usage is relative to the quota:
+	// 100% means the cgroup is being throttled, however idle the host is.
+	// Quotas of at least every core don't limit anything.
+	var cgroupCPU *cgroupCPUSample
+	if c.cgroup {
+		cgroupCPU = readCgroupCPU()
+	}
+	var cgroupCores float64
+	if cgroupCPU != nil && cgroupCPU.quota < float64(cores) {
+		if busy, ok := cgroupCPU.busySince(lastCgroup); ok {
+			total = busy
+			cgroupCores = cgroupCPU.quota
+		}
+	}
+
+	power, energy := readRAPLPower(c.raplZones, lastEnergy, time.Now())
+	if len(c.raplZones) > 0 && len(energy) == 0 {
+		if err := raplReadError(c.raplZones); err != nil {
+			failed["RAPL power"] = err
+		}
+	}
+	if c.power && len(c.raplZones) == 0 {
+		if platform, err := platformPower(ctx); err != nil {
+			failed["power (powermetrics)"] = err
+		} else if platform != nil {
+			power = platform
+		}
 	}
 
 	metrics := &CPUMetrics{
-		Usage:      percentages,
-		Total:      total,
-		CoreCount:  cores,
-		Times:      times,
-		LastUpdate: time.Now(),
+		Usage:       percentages,
+		Total:       total,
+		CoreCount:   cores,
+		LoadCores:   loadCores,
+		CoreIDs:     coreIDs(times, len(percentages)),
+		Offline:     readOfflineCores(),
+		Times:       times,
+		Power:       power,
+		CgroupCores: cgroupCores,
+		LastUpdate:  time.Now(),
 	}
 
 	c.mu.Lock()
+	if len(times) > 0 {
+		aggregate := sumTimes(times)
+		if c.lastTimes == nil {
+			baseline := sumTimes(prev)
+			c.lastTimes = &baseline
+		}
+		// The breakdown covers the whole host, which doesn't add up to a
+		// cgroup-relative total
+		if c.lastTimes != nil && cgroupCores == 0 {
+			metrics.Breakdown = timesBreakdown(*c.lastTimes, aggregate)
+		}
+		c.lastTimes = &aggregate
+	}
+	c.lastCores = times
+	c.lastEnergy = energy
+	c.lastCgroup = cgroupCPU
 	c.lastData = metrics
 	c.mu.Unlock()
 
-	return metrics, nil
+	return metrics, data.NewPartialError(failed)
 }
 
 // GetLastData returns the last collected data (thread-safe)
@@ -94,3 +215,146 @@ func (c *CPUCollector) GetLastData() *CPUMetrics {
 	defer c.mu.RUnlock()
 	return c.lastData
 }
+
+// sumTimes adds up per-core times into a single aggregate
+func sumTimes(times []cpu.TimesStat) cpu.TimesStat {
+	var sum cpu.TimesStat
+	sum.CPU = "cpu-total"
+	for _, t := range times {
+		sum.User += t.User
+		sum.System += t.System
+		sum.Idle += t.Idle
+		sum.Nice += t.Nice
+		sum.Iowait += t.Iowait
+		sum.Irq += t.Irq
+		sum.Softirq += t.Softirq
+		sum.Steal += t.Steal
+	}
+	return sum
+}
+
+// corePercents returns each core's busy percentage between two per-core
+// samples, counting idle and iowait time as not busy
+func corePercents(prev, cur []cpu.TimesStat) []float64 {
+	percents := make([]float64, len(cur))
+	for i := range cur {
+		if i >= len(prev) {
+			break
+		}
+		total := timesTotal(cur[i]) - timesTotal(prev[i])
+		idle := (cur[i].Idle + cur[i].Iowait) - (prev[i].Idle + prev[i].Iowait)
+		if total <= 0 {
+			continue
+		}
+		percents[i] = math.Min(100, math.Max(0, (total-idle)/total*100))
+	}
+	return percents
+}
+
+// timesTotal is the total time a sample accounts for
+func timesTotal(t cpu.TimesStat) float64 {
+	return t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq + t.Softirq + t.Steal
+}
+
+// timesBreakdown converts the delta between two aggregate samples into
+// percentages; it returns nil if no time has passed or counters went back
+func timesBreakdown(prev, cur cpu.TimesStat) *CPUBreakdown {
+	user := (cur.User + cur.Nice) - (prev.User + prev.Nice)
+	system := cur.System - prev.System
+	iowait := cur.Iowait - prev.Iowait
+	other := (cur.Irq + cur.Softirq + cur.Steal) - (prev.Irq + prev.Softirq + prev.Steal)
+	idle := cur.Idle - prev.Idle
+
+	total := user + system + iowait + other + idle
+	if total <= 0 || user < 0 || system < 0 || iowait < 0 || other < 0 || idle < 0 {
+		return nil
+	}
+
+	return &CPUBreakdown{
+		User:   user / total * 100,
+		System: system / total * 100,
+		IOWait: iowait / total * 100,
+		Other:  other / total * 100,
+		Idle:   idle / total * 100,
+	}
+}
+
+// coreIDs maps each per-core usage entry to its CPU number. Per-core stats
+// only cover online CPUs, so with offline cores the slice index and the
+// CPU number differ; the names in cpu.Times ("cpu3") carry the real number.
+func coreIDs(times []cpu.TimesStat, count int) []int {
+	ids := make([]int, count)
+	for i := range ids {
+		ids[i] = i
+	}
+
+	if len(times) != count {
+		return ids
+	}
+	for i, t := range times {
+		id, err := strconv.Atoi(strings.TrimPrefix(t.CPU, "cpu"))
+		if err != nil {
+			// Names we can't parse: keep sequential numbering
+			return ids
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+// readOfflineCores returns CPUs that are present but offline, read from
+// sysfs on Linux. Returns nil on other platforms or when unreadable.
+func readOfflineCores() []int {
+	present, err := os.ReadFile("/sys/devices/system/cpu/present")
+	if err != nil {
+		return nil
+	}
+	online, err := os.ReadFile("/sys/devices/system/cpu/online")
+	if err != nil {
+		return nil
+	}
+
+	return offlineCores(string(present), string(online))
+}
+
+// offlineCores returns the CPUs in the present list that are missing from
+// the online list
+func offlineCores(present, online string) []int {
+	onlineSet := make(map[int]bool)
+	for _, id := range parseCPUList(online) {
+		onlineSet[id] = true
+	}
+
+	var offline []int
+	for _, id := range parseCPUList(present) {
+		if !onlineSet[id] {
+			offline = append(offline, id)
+		}
+	}
+	return offline
+}
+
+// parseCPUList parses a kernel CPU list such as "0-3,6,8-9"
+func parseCPUList(s string) []int {
+	var ids []int
+	for _, part := range strings.Split(strings.TrimSpace(s), ",") {
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(lo)
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(hi); err != nil {
+				continue
+			}
+		}
+		for id := start; id <= end; id++ {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+func TestParseCPUList(t *testing.T) {
+	tests := []struct {
+		list string
+		want []int
+	}{
+		{"0-3", []int{0, 1, 2, 3}},
+		{"0-3,6,8-9\n", []int{0, 1, 2, 3, 6, 8, 9}},
+		{"5", []int{5}},
+		{"", nil},
+		{"x,2", []int{2}},
+	}
+	for _, tt := range tests {
+		if got := parseCPUList(tt.list); !slices.Equal(got, tt.want) {
+			t.Errorf("parseCPUList(%q) = %v, want %v", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestOfflineCores(t *testing.T) {
+	tests := []struct {
+		present, online string
+		want            []int
+	}{
+		{"0-7\n", "0-7\n", nil},
+		{"0-7\n", "0-1,4-7\n", []int{2, 3}},
+		{"0-3\n", "0\n", []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		if got := offlineCores(tt.present, tt.online); !slices.Equal(got, tt.want) {
+			t.Errorf("offlineCores(%q, %q) = %v, want %v", tt.present, tt.online, got, tt.want)
+		}
+	}
+}
+
+func TestCoreIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		times []cpu.TimesStat
+		count int
+		want  []int
+	}{
+		{"no times", nil, 3, []int{0, 1, 2}},
+		{"offline cores skipped", []cpu.TimesStat{{CPU: "cpu0"}, {CPU: "cpu1"}, {CPU: "cpu4"}}, 3, []int{0, 1, 4}},
+		{"count mismatch", []cpu.TimesStat{{CPU: "cpu0"}, {CPU: "cpu5"}}, 3, []int{0, 1, 2}},
+		{"unparsable name", []cpu.TimesStat{{CPU: "cpu0"}, {CPU: "total"}}, 2, []int{0, 1}},
+	}
+	for _, tt := range tests {
+		if got := coreIDs(tt.times, tt.count); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: coreIDs = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCPUCollectorLoadCores(t *testing.T) {
+	logical, err := cpu.Counts(true)
+	if err != nil {
+		t.Skipf("no CPU counts: %v", err)
+	}
+	physical, err := cpu.Counts(false)
+	if err != nil || physical == 0 {
+		t.Skipf("no physical CPU count: %v", err)
+	}
+
+	tests := []struct {
+		logical bool
+		want    int
+	}{
+		{true, logical},
+		{false, physical},
+	}
+	for _, tt := range tests {
+		result, err := NewCPUCollector(1, tt.logical, false, false).Collect(context.Background())
+		if err != nil {
+			t.Fatalf("Collect(logical=%v): %v", tt.logical, err)
+		}
+		metrics := result.(*CPUMetrics)
+		if metrics.LoadCores != tt.want || metrics.CoreCount != logical {
+			t.Errorf("logical=%v: LoadCores = %d, CoreCount = %d, want %d, %d",
+				tt.logical, metrics.LoadCores, metrics.CoreCount, tt.want, logical)
+		}
+	}
+}
@@ -3,9 +3,14 @@ package collectors
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ctcac00/metrics-tui/internal/data"
 	"github.com/shirou/gopsutil/v4/disk"
 )
 
@@ -14,27 +19,43 @@ type DiskMetrics struct {
 	Partitions []disk.PartitionStat
 	Usage      map[string]disk.UsageStat
 	IO         map[string]disk.IOCountersStat
+	Devices    map[string]string     // Mountpoint -> backing device model/serial, when enabled
+	Temps      map[string]float64    // Mountpoint -> backing device temperature (°C), when enabled
+	Health     map[string]DiskHealth // Mountpoint -> backing NVMe drive health, when enabled
+	RAID       []RAIDArray           // Software RAID arrays (Linux)
+	Rates      map[string]IORate     // Whole disk -> I/O rate since the previous collection
+	MountRates map[string]IORate     // Mountpoint -> I/O rate of its device since the previous collection
 	LastUpdate time.Time
 }
 
 // DiskCollector collects disk metrics
 type DiskCollector struct {
-	interval     uint
-	partitions   []string // Specific partitions to monitor
-	includeAll   bool
-	mu           sync.RWMutex
-	lastData     *DiskMetrics
-	lastIO       map[string]disk.IOCountersStat
-	lastIOTime   time.Time
+	interval    uint
+	partitions  []string // Specific partitions to monitor
+	includeAll  bool
+	deviceInfo  bool                        // Look up device model/serial in sysfs
+	temperature bool                        // Read device temperature from hwmon
+	health      bool                        // Read NVMe SMART logs through nvme-cli
+	devices     map[string]*blockDevice     // Sysfs lookups keyed by partition device
+	healthCache map[string]nvmeHealthSample // Last SMART log per NVMe controller
+	mu          sync.RWMutex
+	lastData    *DiskMetrics
+	lastIO      map[string]disk.IOCountersStat
+	lastIOTime  time.Time
 }
 
 // NewDiskCollector creates a new disk collector
-func NewDiskCollector(interval uint, partitions []string, includeAll bool) *DiskCollector {
+func NewDiskCollector(interval uint, partitions []string, includeAll, deviceInfo, temperature, health bool) *DiskCollector {
 	return &DiskCollector{
-		interval:   interval,
-		partitions: partitions,
-		includeAll: includeAll,
-		lastIO:     make(map[string]disk.IOCountersStat),
+		interval:    interval,
+		partitions:  partitions,
+		includeAll:  includeAll,
+		deviceInfo:  deviceInfo,
+		temperature: temperature,
+		health:      health,
+		devices:     make(map[string]*blockDevice),
+		healthCache: make(map[string]nvmeHealthSample),
+		lastIO:      make(map[string]disk.IOCountersStat),
 	}
 }
 
@@ -83,22 +104,26 @@ func (c *DiskCollector) Collect(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	// Get usage for each partition
+	// Get usage for each partition, recording the ones we can't read
+	failed := make(map[string]error)
 	usageMap := make(map[string]disk.UsageStat)
 	for _, p := range filteredPartitions {
 		usage, err := disk.Usage(p.Mountpoint)
 		if err != nil {
-			// Skip partitions we can't read
+			failed[p.Mountpoint] = err
 			continue
 		}
 		usageMap[p.Mountpoint] = *usage
 	}
 
-	// Get IO counters
+	// Get IO counters; gopsutil may return counters for some devices
+	// alongside an error for others
 	ioCounters, err := disk.IOCounters()
 	if err != nil {
-		// IO counters might not be available on all systems
-		ioCounters = make(map[string]disk.IOCountersStat)
+		failed["I/O counters"] = err
+		if ioCounters == nil {
+			ioCounters = make(map[string]disk.IOCountersStat)
+		}
 	}
 
 	// Store IO data
@@ -107,20 +132,55 @@ func (c *DiskCollector) Collect(ctx context.Context) (interface{}, error) {
 		ioMap[device] = stats
 	}
 
+	now := time.Now()
 	metrics := &DiskMetrics{
 		Partitions: filteredPartitions,
 		Usage:      usageMap,
 		IO:         ioMap,
-		LastUpdate: time.Now(),
+		RAID:       readMDStat(),
+		LastUpdate: now,
 	}
 
 	c.mu.Lock()
+	elapsed := now.Sub(c.lastIOTime).Seconds()
+	metrics.Rates = diskIORates(c.lastIO, ioMap, elapsed)
+	metrics.MountRates = mountIORates(filteredPartitions, c.lastIO, ioMap, elapsed)
+	if c.deviceInfo || c.temperature || c.health {
+		// Devices don't change while running, so each is resolved once;
+		// only the temperature itself is read on every collection
+		metrics.Devices = make(map[string]string)
+		metrics.Temps = make(map[string]float64)
+		metrics.Health = make(map[string]DiskHealth)
+		for _, p := range filteredPartitions {
+			device, ok := c.devices[p.Device]
+			if !ok {
+				device = lookupBlockDevice(p.Device)
+				c.devices[p.Device] = device
+			}
+			if c.deviceInfo && device.info != "" {
+				metrics.Devices[p.Mountpoint] = device.info
+			}
+			if c.temperature && device.tempPath != "" {
+				if temp, err := readHwmonTemp(device.tempPath); err == nil {
+					metrics.Temps[p.Mountpoint] = temp
+				}
+			}
+			if c.health && device.controller != "" {
+				health, err := c.nvmeHealth(ctx, device.controller)
+				if err != nil {
+					failed["NVMe health ("+device.controller+")"] = err
+				} else {
+					metrics.Health[p.Mountpoint] = health
+				}
+			}
+		}
+	}
 	c.lastData = metrics
 	c.lastIO = ioMap
-	c.lastIOTime = time.Now()
+	c.lastIOTime = now
 	c.mu.Unlock()
 
-	return metrics, nil
+	return metrics, data.NewPartialError(failed)
 }
 
 // GetLastData returns the last collected data (thread-safe)
@@ -130,35 +190,120 @@ func (c *DiskCollector) GetLastData() *DiskMetrics {
 	return c.lastData
 }
 
-// GetIORate calculates IO rate since last collection (thread-safe)
+// nvmeHealth returns a controller's SMART log, re-reading it through
+// nvme-cli once nvmeHealthInterval has passed. Failures are cached as well,
+// so a missing nvme-cli or permission isn't retried on every collection.
+// Callers hold c.mu.
+func (c *DiskCollector) nvmeHealth(ctx context.Context, controller string) (DiskHealth, error) {
+	if sample, ok := c.healthCache[controller]; ok && time.Since(sample.at) < nvmeHealthInterval {
+		return sample.health, sample.err
+	}
+	health, err := readNVMeHealth(ctx, controller)
+	c.healthCache[controller] = nvmeHealthSample{health: health, err: err, at: time.Now()}
+	return health, err
+}
+
+// GetIORate returns each whole disk's IO rate between the last two
+// collections, or nil before the second (thread-safe)
 func (c *DiskCollector) GetIORate() map[string]IORate {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	if len(c.lastIO) == 0 {
+	if c.lastData == nil {
 		return nil
 	}
+	return c.lastData.Rates
+}
 
-	elapsed := time.Since(c.lastIOTime).Seconds()
-	if elapsed == 0 {
+// diskIORates computes per-device rates from two counter samples taken
+// elapsed seconds apart, for whole disks only. Devices missing from either
+// sample, or whose counters went back, are left out.
+func diskIORates(prev, current map[string]disk.IOCountersStat, elapsed float64) map[string]IORate {
+	if len(prev) == 0 || elapsed <= 0 {
 		return nil
 	}
 
 	rates := make(map[string]IORate)
-	for device, currentIO := range c.lastIO {
-		if lastIO, ok := c.lastIO[device]; ok {
-			rates[device] = IORate{
-				ReadBytesPerSec:  float64(currentIO.ReadBytes-lastIO.ReadBytes) / elapsed,
-				WriteBytesPerSec: float64(currentIO.WriteBytes-lastIO.WriteBytes) / elapsed,
-				ReadCountPerSec:  float64(currentIO.ReadCount-lastIO.ReadCount) / elapsed,
-				WriteCountPerSec: float64(currentIO.WriteCount-lastIO.WriteCount) / elapsed,
-			}
+	for device, cur := range current {
+		if !isWholeDisk(device) {
+			continue
+		}
+		if rate, ok := ioRate(prev, cur, device, elapsed); ok {
+			rates[device] = rate
 		}
 	}
+	return rates
+}
 
+// mountIORates computes the rate of the device behind each partition from
+// two counter samples, keyed by mountpoint. Partitions whose device has no
+// counters of its own (network filesystems, overlays) are left out.
+func mountIORates(partitions []disk.PartitionStat, prev, current map[string]disk.IOCountersStat, elapsed float64) map[string]IORate {
+	if len(prev) == 0 || elapsed <= 0 {
+		return nil
+	}
+
+	rates := make(map[string]IORate)
+	for _, p := range partitions {
+		device := ioCounterName(p.Device)
+		cur, ok := current[device]
+		if !ok {
+			continue
+		}
+		if rate, ok := ioRate(prev, cur, device, elapsed); ok {
+			rates[p.Mountpoint] = rate
+		}
+	}
 	return rates
 }
 
+// ioRate computes a device's rates against its previous sample, which must
+// exist and have counters no higher than cur
+func ioRate(prev map[string]disk.IOCountersStat, cur disk.IOCountersStat, device string, elapsed float64) (IORate, bool) {
+	old, ok := prev[device]
+	if !ok ||
+		cur.ReadBytes < old.ReadBytes || cur.WriteBytes < old.WriteBytes ||
+		cur.ReadCount < old.ReadCount || cur.WriteCount < old.WriteCount {
+		return IORate{}, false
+	}
+	return IORate{
+		ReadBytesPerSec:  float64(cur.ReadBytes-old.ReadBytes) / elapsed,
+		WriteBytesPerSec: float64(cur.WriteBytes-old.WriteBytes) / elapsed,
+		ReadCountPerSec:  float64(cur.ReadCount-old.ReadCount) / elapsed,
+		WriteCountPerSec: float64(cur.WriteCount-old.WriteCount) / elapsed,
+	}, true
+}
+
+// ioCounterName maps a partition device to the name its I/O counters are
+// keyed by: /dev/sda1 to sda1, and /dev/mapper/root through its symlink to
+// dm-0. Other devices, such as Windows drive letters, are used as they are.
+func ioCounterName(device string) string {
+	if !strings.HasPrefix(device, "/dev/") {
+		return device
+	}
+	if resolved, err := filepath.EvalSymlinks(device); err == nil {
+		device = resolved
+	}
+	return filepath.Base(device)
+}
+
+// isWholeDisk reports whether an I/O counter device is a whole disk, not a
+// partition (whose I/O its disk already counts) or a loop or RAM disk.
+// Without /sys/block, as on other systems than Linux, every other device
+// counts.
+func isWholeDisk(device string) bool {
+	for _, prefix := range []string{"loop", "ram", "zram"} {
+		if strings.HasPrefix(device, prefix) {
+			return false
+		}
+	}
+	if _, err := os.Stat("/sys/block"); err != nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join("/sys/block", device))
+	return err == nil
+}
+
 // IORate represents IO rates between two samples
 type IORate struct {
 	ReadBytesPerSec  float64
@@ -166,3 +311,103 @@ type IORate struct {
 	ReadCountPerSec  float64
 	WriteCountPerSec float64
 }
+
+// blockDevice is what sysfs tells about the physical disk behind a partition
+type blockDevice struct {
+	info       string // Model and serial, e.g. "Samsung SSD 980 1TB (S/N S64DNF0R123456)"
+	tempPath   string // hwmon temp1_input of the disk (nvme, drivetemp), if any
+	controller string // NVMe controller of the disk, e.g. "nvme0"
+}
+
+// lookupBlockDevice resolves a partition device such as /dev/nvme0n1p2 to
+// its disk in sysfs. Fields stay empty on other platforms and for devices
+// that don't resolve to a single disk (network filesystems, RAID, multi-disk
+// LVM).
+func lookupBlockDevice(device string) *blockDevice {
+	result := &blockDevice{}
+	if !strings.HasPrefix(device, "/dev/") {
+		return result
+	}
+	// /dev/mapper/* and /dev/disk/by-* entries are symlinks to the kernel name
+	if resolved, err := filepath.EvalSymlinks(device); err == nil {
+		device = resolved
+	}
+
+	sysPath := resolveDisk(filepath.Base(device), 0)
+	if sysPath == "" {
+		return result
+	}
+
+	model := readSysfsString(sysPath + "/device/model")
+	if serial := readSysfsString(sysPath + "/device/serial"); model != "" && serial != "" {
+		result.info = fmt.Sprintf("%s (S/N %s)", model, serial)
+	} else {
+		result.info = model
+	}
+
+	// NVMe controllers carry hwmonN directly; SCSI disks with the drivetemp
+	// driver nest it under hwmon/
+	for _, pattern := range []string{"/device/hwmon*/temp1_input", "/device/hwmon/hwmon*/temp1_input"} {
+		if matches, _ := filepath.Glob(sysPath + pattern); len(matches) > 0 {
+			result.tempPath = matches[0]
+			break
+		}
+	}
+
+	// Multipath NVMe disks hang off a subsystem, so look the sensor up on
+	// the controller itself
+	result.controller = nvmeController(sysPath)
+	if result.tempPath == "" && result.controller != "" {
+		if matches, _ := filepath.Glob(nvmeClassPath + "/" + result.controller + "/hwmon*/temp1_input"); len(matches) > 0 {
+			result.tempPath = matches[0]
+		}
+	}
+	return result
+}
+
+// resolveDisk returns the sysfs directory of the disk for a kernel block
+// device name, walking from a partition to its disk and from a
+// device-mapper volume (LVM, dm-crypt) to its single underlying device
+func resolveDisk(name string, depth int) string {
+	if depth > 3 {
+		return ""
+	}
+
+	// /sys/class/block/<part> links into its parent disk's directory
+	sysPath, err := filepath.EvalSymlinks("/sys/class/block/" + name)
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(sysPath + "/partition"); err == nil {
+		sysPath = filepath.Dir(sysPath)
+	}
+
+	if _, err := os.Stat(sysPath + "/device"); err == nil {
+		return sysPath
+	}
+
+	// Mapper devices have no hardware of their own; follow a lone slave
+	slaves, err := os.ReadDir(sysPath + "/slaves")
+	if err != nil || len(slaves) != 1 {
+		return ""
+	}
+	return resolveDisk(slaves[0].Name(), depth+1)
+}
+
+// readHwmonTemp reads an hwmon temperature input in millidegrees Celsius
+func readHwmonTemp(path string) (float64, error) {
+	milli, err := strconv.Atoi(readSysfsString(path))
+	if err != nil {
+		return 0, err
+	}
+	return float64(milli) / 1000, nil
+}
+
+// readSysfsString reads a sysfs attribute, trimming padding and newlines
+func readSysfsString(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
@@ -0,0 +1,169 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+const (
+	// smartctlTimeout caps a single smartctl run; a spun-down disk may have
+	// to wake up first
+	smartctlTimeout = 10 * time.Second
+
+	// smartTempInterval is how often a disk without a drivetemp sensor is
+	// asked for its SMART temperature. Drive temperatures change slowly and
+	// smartctl is an extra process per disk.
+	smartTempInterval = time.Minute
+)
+
+// smartTempSample is a SMART temperature reading (or the error reading it)
+// and when it was taken
+type smartTempSample struct {
+	temp float64
+	ok   bool // The drive reported a temperature
+	err  error
+	at   time.Time
+}
+
+// driveTemperatures reads the temperature of every SATA and SAS disk, keyed
+// by disk (e.g. "sda_drive") so drives can be told apart. The drivetemp
+// hwmon driver is used where loaded; other disks fall back to SMART through
+// smartctl, which needs root to open the disk and is only tried as root.
+func (c *SensorsCollector) driveTemperatures(ctx context.Context) ([]sensors.TemperatureStat, map[string]error) {
+	disks, _ := filepath.Glob("/sys/block/sd*")
+
+	var temps []sensors.TemperatureStat
+	failed := make(map[string]error)
+	for _, disk := range disks {
+		name := filepath.Base(disk)
+		stat := sensors.TemperatureStat{SensorKey: name + "_drive"}
+
+		if inputs, _ := filepath.Glob(disk + "/device/hwmon/hwmon*/temp1_input"); len(inputs) > 0 {
+			temp, err := readHwmonTemp(inputs[0])
+			if err != nil {
+				continue
+			}
+			stat.Temperature = temp
+			hwmon := filepath.Dir(inputs[0])
+			if high, err := readHwmonTemp(hwmon + "/temp1_max"); err == nil {
+				stat.High = high
+			}
+			if critical, err := readHwmonTemp(hwmon + "/temp1_crit"); err == nil {
+				stat.Critical = critical
+			}
+			temps = append(temps, stat)
+			continue
+		}
+
+		if os.Geteuid() != 0 {
+			continue
+		}
+		sample := c.smartTemperature(ctx, name)
+		if sample.err != nil {
+			failed["SMART ("+name+")"] = sample.err
+		} else if sample.ok {
+			stat.Temperature = sample.temp
+			temps = append(temps, stat)
+		}
+	}
+	return temps, failed
+}
+
+// smartTemperature returns a disk's SMART temperature, re-reading it through
+// smartctl once smartTempInterval has passed. Failures are cached as well,
+// so a missing smartctl isn't retried on every collection.
+func (c *SensorsCollector) smartTemperature(ctx context.Context, disk string) smartTempSample {
+	c.smartMu.Lock()
+	defer c.smartMu.Unlock()
+
+	if sample, ok := c.smartCache[disk]; ok && time.Since(sample.at) < smartTempInterval {
+		return sample
+	}
+	temp, ok, err := readSmartTemperature(ctx, disk)
+	sample := smartTempSample{temp: temp, ok: ok, err: err, at: time.Now()}
+	c.smartCache[disk] = sample
+	return sample
+}
+
+// readSmartTemperature asks smartctl for a disk's current temperature. ok
+// is false for disks that don't report one, such as virtual disks.
+func readSmartTemperature(ctx context.Context, disk string) (temp float64, ok bool, err error) {
+	path, err := exec.LookPath("smartctl")
+	if err != nil {
+		return 0, false, fmt.Errorf("smartctl not found: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, smartctlTimeout)
+	defer cancel()
+
+	// -n standby leaves spun-down disks asleep instead of waking them
+	cmd := newCommand(ctx, path, "-A", "-n", "standby", "-j", "/dev/"+disk)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// smartctl sets exit status bits for the disk's health as well, so the
+	// output is parsed whatever the status
+	runErr := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return 0, false, fmt.Errorf("smartctl timed out after %s", smartctlTimeout)
+	}
+
+	temp, ok, err = parseSmartTemperature(stdout.Bytes())
+	if err != nil && runErr != nil {
+		if msg := firstLine(stderr.String()); msg != "" {
+			return 0, false, fmt.Errorf("smartctl failed: %w: %s", runErr, msg)
+		}
+		return 0, false, fmt.Errorf("smartctl failed: %w", runErr)
+	}
+	return temp, ok, err
+}
+
+// parseSmartTemperature parses `smartctl -A -j` output, e.g.
+//
+//	{"temperature":{"current":34},
+//	 "ata_smart_attributes":{"table":[{"id":194,"raw":{"value":193274462242}},...]}}
+//
+// The summary temperature is used when present; otherwise attribute 194,
+// whose raw value packs the lowest and highest temperature seen above the
+// current one in its low byte.
+func parseSmartTemperature(output []byte) (temp float64, ok bool, err error) {
+	var report struct {
+		Temperature *struct {
+			Current *float64 `json:"current"`
+		} `json:"temperature"`
+		ATASmartAttributes struct {
+			Table []struct {
+				ID  int `json:"id"`
+				Raw struct {
+					Value uint64 `json:"value"`
+				} `json:"raw"`
+			} `json:"table"`
+		} `json:"ata_smart_attributes"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		if len(bytes.TrimSpace(output)) == 0 {
+			return 0, false, errors.New("smartctl printed nothing")
+		}
+		return 0, false, fmt.Errorf("unexpected smartctl output: %w", err)
+	}
+
+	if report.Temperature != nil && report.Temperature.Current != nil {
+		return *report.Temperature.Current, true, nil
+	}
+	for _, attribute := range report.ATASmartAttributes.Table {
+		if attribute.ID == 194 {
+			return float64(attribute.Raw.Value & 0xff), true, nil
+		}
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,23 @@
+package collectors
+
+import (
+	"context"
+	"os/exec"
+	"time"
+)
+
+// execWaitDelay bounds how long a cancelled command may keep its output
+// pipes open. A grandchild that inherited them (a backgrounded process, or
+// the right side of a shell pipeline) would otherwise keep Run waiting
+// long after the timeout.
+const execWaitDelay = time.Second
+
+// newCommand is exec.CommandContext for collector helpers: the command runs
+// in its own process group where supported, the whole group is killed when
+// ctx ends, and Wait gives up on the output pipes execWaitDelay later
+func newCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = execWaitDelay
+	killProcessGroup(cmd)
+	return cmd
+}
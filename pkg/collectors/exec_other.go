@@ -0,0 +1,9 @@
+//go:build !unix
+
+package collectors
+
+import "os/exec"
+
+// killProcessGroup leaves cancellation to exec.CommandContext, which kills
+// only the command itself; WaitDelay still bounds the wait for its pipes
+func killProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,318 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// nvidiaSMITimeout caps a single nvidia-smi run; it can stall for seconds
+// while the driver wakes an idle GPU
+const nvidiaSMITimeout = 5 * time.Second
+
+// nvidiaSMIFields are the nvidia-smi query fields, in GPUStat parse order
+var nvidiaSMIFields = []string{
+	"index",
+	"name",
+	"utilization.gpu",
+	"memory.used",
+	"memory.total",
+	"temperature.gpu",
+	"power.draw",
+	"power.limit",
+	"clocks.gr",
+	"clocks.mem",
+}
+
+// drmClassPath is where the kernel lists DRM (graphics) devices
+const drmClassPath = "/sys/class/drm"
+
+// GPUStat holds usage data for a single GPU. Readings the driver doesn't
+// report are -1 (MemoryTotal is 0 when VRAM usage is unknown).
+type GPUStat struct {
+	Index         int
+	Name          string
+	Vendor        string  // "nvidia" or "intel"
+	Utilization   float64 // Percent busy
+	MemoryUsed    uint64  // VRAM in bytes
+	MemoryTotal   uint64
+	Temperature   float64 // °C
+	PowerDraw     float64 // Watts
+	PowerLimit    float64
+	GraphicsClock float64 // MHz
+	MemoryClock   float64
+}
+
+// GPUMetrics holds data for every GPU found
+type GPUMetrics struct {
+	GPUs       []GPUStat
+	Processes  []GPUProcessStat // Processes using an NVIDIA GPU, when requested
+	LastUpdate time.Time
+}
+
+// GPUCollector collects GPU utilization, memory, temperature, power and
+// clocks. NVIDIA GPUs are read through nvidia-smi, which ships with the
+// driver; without it no NVIDIA GPUs are reported. Intel GPUs using the
+// i915 driver are read from sysfs on Linux: utilization is estimated from
+// RC6 (GPU sleep) residency and the clock is the current GT frequency.
+type GPUCollector struct {
+	interval  uint
+	processes bool // Sample per-process usage as well
+	mu        sync.RWMutex
+	lastData  *GPUMetrics
+
+	// RC6 residency per Intel card from the previous sample
+	lastRC6 map[string]rc6Sample
+}
+
+// rc6Sample is an RC6 residency reading and when it was taken
+type rc6Sample struct {
+	residency time.Duration
+	at        time.Time
+}
+
+// NewGPUCollector creates a new GPU collector. With processes set it also
+// reports which processes use NVIDIA GPUs, at the cost of a one second
+// nvidia-smi sample per collection.
+func NewGPUCollector(interval uint, processes bool) *GPUCollector {
+	return &GPUCollector{
+		interval:  interval,
+		processes: processes,
+		lastRC6:   make(map[string]rc6Sample),
+	}
+}
+
+// Name returns the collector name
+func (c *GPUCollector) Name() string {
+	return "gpu"
+}
+
+// Interval returns the update interval in seconds
+func (c *GPUCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers GPU metrics. Finding no GPUs is not an error; the
+// metrics simply list none.
+func (c *GPUCollector) Collect(ctx context.Context) (interface{}, error) {
+	var gpus []GPUStat
+	var processes []GPUProcessStat
+	failed := make(map[string]error)
+
+	if path, err := exec.LookPath("nvidia-smi"); err == nil {
+		stats, err := collectNVIDIA(ctx, path)
+		if err != nil {
+			failed["nvidia"] = err
+		}
+		gpus = append(gpus, stats...)
+
+		if c.processes && len(stats) > 0 {
+			processes, err = collectNVIDIAProcesses(ctx, path)
+			if err != nil {
+				failed["nvidia processes"] = err
+			}
+		}
+	}
+
+	for _, gpu := range c.collectIntel() {
+		gpu.Index = len(gpus)
+		gpus = append(gpus, gpu)
+	}
+
+	if len(gpus) == 0 && len(failed) > 0 {
+		return nil, fmt.Errorf("failed to read GPUs: %w", failed["nvidia"])
+	}
+
+	metrics := &GPUMetrics{
+		GPUs:       gpus,
+		Processes:  processes,
+		LastUpdate: time.Now(),
+	}
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, data.NewPartialError(failed)
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *GPUCollector) GetLastData() *GPUMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// collectNVIDIA queries every NVIDIA GPU through nvidia-smi
+func collectNVIDIA(ctx context.Context, path string) ([]GPUStat, error) {
+	ctx, cancel := context.WithTimeout(ctx, nvidiaSMITimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path,
+		"--query-gpu="+strings.Join(nvidiaSMIFields, ","),
+		"--format=csv,noheader,nounits",
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("nvidia-smi timed out after %s", nvidiaSMITimeout)
+		}
+		// nvidia-smi reports driver problems on stdout
+		if msg := firstLine(stderr.String() + "\n" + stdout.String()); msg != "" {
+			return nil, fmt.Errorf("nvidia-smi failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("nvidia-smi failed: %w", err)
+	}
+
+	return parseNVIDIASMI(stdout.String())
+}
+
+// parseNVIDIASMI parses nvidia-smi CSV output, one GPU per line, e.g.
+//
+//	0, NVIDIA GeForce RTX 3080, 12, 1024, 10240, 45, 30.50, 320.00, 210, 405
+//
+// Memory is in MiB. Fields the GPU doesn't support read "[N/A]" or
+// "[Not Supported]" and are stored as -1.
+func parseNVIDIASMI(output string) ([]GPUStat, error) {
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = len(nvidiaSMIFields)
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected nvidia-smi output: %w", err)
+	}
+
+	gpus := make([]GPUStat, 0, len(records))
+	for _, record := range records {
+		index, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected nvidia-smi GPU index %q", record[0])
+		}
+
+		gpu := GPUStat{
+			Index:         index,
+			Name:          strings.TrimSpace(record[1]),
+			Vendor:        "nvidia",
+			Utilization:   parseSMIValue(record[2]),
+			Temperature:   parseSMIValue(record[5]),
+			PowerDraw:     parseSMIValue(record[6]),
+			PowerLimit:    parseSMIValue(record[7]),
+			GraphicsClock: parseSMIValue(record[8]),
+			MemoryClock:   parseSMIValue(record[9]),
+		}
+		used, total := parseSMIValue(record[3]), parseSMIValue(record[4])
+		if used >= 0 && total > 0 {
+			gpu.MemoryUsed = uint64(used * 1024 * 1024)
+			gpu.MemoryTotal = uint64(total * 1024 * 1024)
+		}
+
+		gpus = append(gpus, gpu)
+	}
+
+	return gpus, nil
+}
+
+// collectIntel reads every i915 GPU from sysfs. Utilization needs two RC6
+// samples, so it is -1 on the first collection.
+func (c *GPUCollector) collectIntel() []GPUStat {
+	cards, _ := filepath.Glob(drmClassPath + "/card[0-9]*")
+
+	var gpus []GPUStat
+	seen := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		// Skip connectors such as card0-HDMI-A-1
+		if strings.Contains(filepath.Base(card), "-") {
+			continue
+		}
+		driver, err := filepath.EvalSymlinks(card + "/device/driver")
+		if err != nil || filepath.Base(driver) != "i915" {
+			continue
+		}
+		name := filepath.Base(card)
+		seen[name] = true
+
+		gpu := GPUStat{
+			Name:          "Intel Graphics (" + name + ")",
+			Vendor:        "intel",
+			Utilization:   -1,
+			Temperature:   -1,
+			PowerDraw:     -1,
+			PowerLimit:    -1,
+			GraphicsClock: -1,
+			MemoryClock:   -1,
+		}
+
+		// Newer kernels move the GT attributes under gt/gt0
+		for _, attr := range []string{"/gt_act_freq_mhz", "/gt/gt0/rps_act_freq_mhz", "/gt_cur_freq_mhz"} {
+			if mhz, err := strconv.ParseFloat(readSysfsString(card+attr), 64); err == nil {
+				gpu.GraphicsClock = mhz
+				break
+			}
+		}
+
+		for _, attr := range []string{"/power/rc6_residency_ms", "/gt/gt0/rc6_residency_ms"} {
+			ms, err := strconv.ParseUint(readSysfsString(card+attr), 10, 64)
+			if err != nil {
+				continue
+			}
+			gpu.Utilization = c.rc6Utilization(name, time.Duration(ms)*time.Millisecond)
+			break
+		}
+
+		gpus = append(gpus, gpu)
+	}
+
+	// Forget cards that went away
+	c.mu.Lock()
+	for name := range c.lastRC6 {
+		if !seen[name] {
+			delete(c.lastRC6, name)
+		}
+	}
+	c.mu.Unlock()
+
+	return gpus
+}
+
+// rc6Utilization records an RC6 residency reading for a card and returns
+// the share of time since the previous reading the GPU spent awake, or -1
+// without a usable previous reading. Awake is not quite busy (the GPU idles
+// briefly before entering RC6) so this slightly overstates light loads.
+func (c *GPUCollector) rc6Utilization(card string, residency time.Duration) float64 {
+	now := time.Now()
+
+	c.mu.Lock()
+	prev, ok := c.lastRC6[card]
+	c.lastRC6[card] = rc6Sample{residency: residency, at: now}
+	c.mu.Unlock()
+
+	elapsed := now.Sub(prev.at)
+	if !ok || elapsed <= 0 || residency < prev.residency {
+		return -1
+	}
+	asleep := float64(residency-prev.residency) / float64(elapsed)
+	return (1 - min(asleep, 1)) * 100
+}
+
+// parseSMIValue parses a numeric nvidia-smi field, returning -1 for
+// unsupported or unavailable readings
+func parseSMIValue(field string) float64 {
+	value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+	if err != nil || value < 0 {
+		return -1
+	}
+	return value
+}
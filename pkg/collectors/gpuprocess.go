@@ -0,0 +1,112 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GPUProcessStat holds the GPU usage of a single process, summed over
+// every GPU it runs on
+type GPUProcessStat struct {
+	PID         int32
+	Name        string  // Command name as the driver reports it
+	Utilization float64 // Percent of a GPU's streaming multiprocessors busy; -1 when unknown
+	MemoryUsed  uint64  // VRAM in bytes
+}
+
+// collectNVIDIAProcesses samples per-process GPU usage through
+// `nvidia-smi pmon`, which watches for a second before printing, so it is
+// only run when the process list is shown
+func collectNVIDIAProcesses(ctx context.Context, path string) ([]GPUProcessStat, error) {
+	ctx, cancel := context.WithTimeout(ctx, nvidiaSMITimeout)
+	defer cancel()
+
+	// -s um selects utilization and framebuffer memory; -c 1 a single sample
+	cmd := newCommand(ctx, path, "pmon", "-c", "1", "-s", "um")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("nvidia-smi pmon timed out after %s", nvidiaSMITimeout)
+		}
+		if msg := firstLine(stderr.String() + "\n" + stdout.String()); msg != "" {
+			return nil, fmt.Errorf("nvidia-smi pmon failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("nvidia-smi pmon failed: %w", err)
+	}
+
+	return parseNVIDIAPmon(stdout.String()), nil
+}
+
+// parseNVIDIAPmon parses `nvidia-smi pmon -s um` output, e.g.
+//
+//	# gpu         pid   type     sm    mem    enc    dec    jpg    ofa     fb   command
+//	# Idx           #    C/G      %      %      %      %      %      %     MB   name
+//	    0       4711     C     87     41      -      -      -      -   9120   python
+//	    1          -     -      -      -      -      -      -      -      -   -
+//
+// The columns differ between driver versions, so they are found by name
+// in the first header line. "mem" is memory bandwidth; "fb" is the VRAM in
+// use. A process on several GPUs is listed once per GPU and summed.
+func parseNVIDIAPmon(output string) []GPUProcessStat {
+	columns := make(map[string]int)
+	byPID := make(map[int32]*GPUProcessStat)
+	var order []int32
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if header, ok := strings.CutPrefix(line, "#"); ok {
+			if len(columns) == 0 {
+				for i, name := range strings.Fields(header) {
+					columns[name] = i
+				}
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pidColumn, ok := columns["pid"]
+		if !ok || pidColumn >= len(fields) {
+			continue
+		}
+		pid, err := strconv.ParseInt(fields[pidColumn], 10, 32)
+		if err != nil {
+			// "-" on GPUs without processes
+			continue
+		}
+
+		stat, ok := byPID[int32(pid)]
+		if !ok {
+			stat = &GPUProcessStat{PID: int32(pid), Utilization: -1}
+			byPID[int32(pid)] = stat
+			order = append(order, int32(pid))
+		}
+		if i, ok := columns["sm"]; ok && i < len(fields) {
+			if sm := parseSMIValue(fields[i]); sm >= 0 {
+				stat.Utilization = max(stat.Utilization, 0) + sm
+			}
+		}
+		if i, ok := columns["fb"]; ok && i < len(fields) {
+			if fb := parseSMIValue(fields[i]); fb >= 0 {
+				stat.MemoryUsed += uint64(fb * 1024 * 1024)
+			}
+		}
+		// The command is the last column and may contain spaces
+		if i, ok := columns["command"]; ok && i < len(fields) {
+			stat.Name = strings.Join(fields[i:], " ")
+		}
+	}
+
+	processes := make([]GPUProcessStat, 0, len(order))
+	for _, pid := range order {
+		processes = append(processes, *byPID[pid])
+	}
+	return processes
+}
@@ -2,10 +2,15 @@ package collectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/ctcac00/metrics-tui/internal/data"
 	"github.com/shirou/gopsutil/v4/host"
 	"github.com/shirou/gopsutil/v4/load"
 )
@@ -14,14 +19,27 @@ import (
 type HostMetrics struct {
 	Info       host.InfoStat
 	LoadAvg    *load.AvgStat
+	Activity   *KernelActivity // Context switch, interrupt and fork rates (Linux); nil until two samples
+	Users      []host.UserStat // Logged-in sessions from utmp, oldest first; nil when not available
+	Entropy    *EntropyStat    // Kernel entropy pool (Linux)
 	LastUpdate time.Time
 }
 
+// EntropyStat holds the fill level of the kernel's random entropy pool
+type EntropyStat struct {
+	Available int // Bits of entropy available
+	PoolSize  int // Bits the pool holds when full
+}
+
 // HostCollector collects host information
 type HostCollector struct {
 	interval uint
 	mu       sync.RWMutex
 	lastData *HostMetrics
+
+	// /proc/stat counters from the previous sample, for rates
+	lastCounters kernelCounters
+	lastSample   time.Time
 }
 
 // NewHostCollector creates a new host collector
@@ -48,23 +66,60 @@ func (c *HostCollector) Collect(ctx context.Context) (interface{}, error) {
 		return nil, fmt.Errorf("failed to get host info: %w", err)
 	}
 
+	// Load average might not be available on all systems
+	failed := make(map[string]error)
 	loadAvg, err := load.Avg()
 	if err != nil {
-		// Load average might not be available on all systems
-		loadAvg = &load.AvgStat{}
+		failed["load average"] = err
+		loadAvg = nil
 	}
 
 	metrics := &HostMetrics{
 		Info:       *info,
 		LoadAvg:    loadAvg,
+		Entropy:    readEntropy(),
 		LastUpdate: time.Now(),
 	}
 
+	// Sessions come from utmp. Systems without one (newer systemd setups,
+	// Windows) simply list none; only an unreadable utmp is worth reporting.
+	users, err := host.UsersWithContext(ctx)
+	switch {
+	case err == nil:
+		sort.SliceStable(users, func(i, j int) bool { return users[i].Started < users[j].Started })
+		metrics.Users = users
+	case errors.Is(err, fs.ErrPermission):
+		failed["users"] = err
+	}
+
+	now := time.Now()
 	c.mu.Lock()
+	if counters, ok := readKernelCounters(); ok {
+		if !c.lastSample.IsZero() {
+			if activity, ok := activitySince(c.lastCounters, counters, now.Sub(c.lastSample).Seconds()); ok {
+				metrics.Activity = &activity
+			}
+		}
+		c.lastCounters, c.lastSample = counters, now
+	}
 	c.lastData = metrics
 	c.mu.Unlock()
 
-	return metrics, nil
+	return metrics, data.NewPartialError(failed)
+}
+
+// readEntropy reads the kernel's entropy pool level. It returns nil on other
+// platforms. Since Linux 5.18 the pool is always reported as a full 256 bits.
+func readEntropy() *EntropyStat {
+	available, err := strconv.Atoi(readSysfsString("/proc/sys/kernel/random/entropy_avail"))
+	if err != nil {
+		return nil
+	}
+	poolSize, err := strconv.Atoi(readSysfsString("/proc/sys/kernel/random/poolsize"))
+	if err != nil || poolSize <= 0 {
+		return nil
+	}
+	return &EntropyStat{Available: available, PoolSize: poolSize}
 }
 
 // GetLastData returns the last collected data (thread-safe)
@@ -0,0 +1,160 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+const (
+	// ipmitoolTimeout caps a single ipmitool run; a slow BMC can take
+	// several seconds to walk its sensor repository
+	ipmitoolTimeout = 20 * time.Second
+
+	// ipmiInterval is how often the BMC is asked for its sensors. Reading
+	// them is slow and chassis readings change slowly.
+	ipmiInterval = 30 * time.Second
+)
+
+// ipmiDevices are the device nodes of the kernel IPMI driver (OpenIPMI's
+// ipmi_devintf) that ipmitool's default interface talks through
+var ipmiDevices = []string{"/dev/ipmi0", "/dev/ipmi/0", "/dev/ipmidev/0"}
+
+// PSUStat holds the state of a power supply reported by the BMC
+type PSUStat struct {
+	Name   string
+	Status string // The BMC's description, e.g. "Presence detected, Failure detected"
+	Failed bool   // The supply reported a failure or lost its input
+}
+
+// ipmiSample is one reading of the BMC's sensors (or the error reading
+// them) and when it was taken
+type ipmiSample struct {
+	temps []sensors.TemperatureStat
+	fans  []FanStat
+	psus  []PSUStat
+	err   error
+	at    time.Time
+}
+
+// ipmiSensors returns the BMC's temperature, fan and power supply sensors
+// on server hardware with an IPMI device, re-reading them through ipmitool
+// once ipmiInterval has passed. It returns an empty sample without an
+// accessible IPMI device or ipmitool, which need root on most systems.
+func (c *SensorsCollector) ipmiSensors(ctx context.Context) ipmiSample {
+	accessible := false
+	for _, device := range ipmiDevices {
+		if file, err := os.OpenFile(device, os.O_RDWR, 0); err == nil {
+			file.Close()
+			accessible = true
+			break
+		}
+	}
+	if !accessible {
+		return ipmiSample{}
+	}
+	path, err := exec.LookPath("ipmitool")
+	if err != nil {
+		return ipmiSample{}
+	}
+
+	c.ipmiMu.Lock()
+	defer c.ipmiMu.Unlock()
+
+	if time.Since(c.ipmiCache.at) < ipmiInterval {
+		return c.ipmiCache
+	}
+	sample := readIPMISensors(ctx, path)
+	sample.at = time.Now()
+	c.ipmiCache = sample
+	return sample
+}
+
+// readIPMISensors lists the BMC's sensors through ipmitool
+func readIPMISensors(ctx context.Context, path string) ipmiSample {
+	ctx, cancel := context.WithTimeout(ctx, ipmitoolTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, "sdr", "elist")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return ipmiSample{err: fmt.Errorf("ipmitool timed out after %s", ipmitoolTimeout)}
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return ipmiSample{err: fmt.Errorf("ipmitool failed: %w: %s", err, msg)}
+		}
+		return ipmiSample{err: fmt.Errorf("ipmitool failed: %w", err)}
+	}
+
+	return parseIPMISDR(stdout.String())
+}
+
+// parseIPMISDR parses `ipmitool sdr elist` output, one sensor per line:
+// name, record ID, status, entity ID and reading, e.g.
+//
+//	Inlet Temp       | 04h | ok  |  7.1 | 23 degrees C
+//	Fan1A            | 30h | ok  |  7.1 | 5520 RPM
+//	PS2 Status       | 63h | ok  | 10.2 | Presence detected, Failure detected
+//
+// Entity 10 is a power supply. Sensors without a reading ("ns" status or
+// "No Reading") and other sensor kinds are skipped.
+func parseIPMISDR(output string) ipmiSample {
+	var sample ipmiSample
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "|")
+		if len(fields) != 5 {
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		name, status, entity, reading := fields[0], fields[2], fields[3], fields[4]
+		if status == "ns" || reading == "" || reading == "No Reading" {
+			continue
+		}
+		key := strings.ReplaceAll(name, " ", "_")
+
+		switch {
+		case strings.HasSuffix(reading, " degrees C"):
+			value, err := strconv.ParseFloat(strings.TrimSuffix(reading, " degrees C"), 64)
+			if err == nil {
+				sample.temps = append(sample.temps, sensors.TemperatureStat{
+					SensorKey:   "ipmi_" + key,
+					Temperature: value,
+				})
+			}
+		case strings.HasSuffix(reading, " RPM"):
+			value, err := strconv.ParseFloat(strings.TrimSuffix(reading, " RPM"), 64)
+			if err == nil && value > 0 {
+				sample.fans = append(sample.fans, FanStat{Name: "ipmi_" + key, RPM: uint64(value)})
+			}
+		case strings.HasPrefix(entity, "10."):
+			// Discrete power supply sensors; the numeric ones (watts, volts)
+			// aren't supply states
+			if _, err := strconv.ParseFloat(strings.Fields(reading)[0], 64); err == nil {
+				continue
+			}
+			lower := strings.ToLower(reading)
+			sample.psus = append(sample.psus, PSUStat{
+				Name:   name,
+				Status: reading,
+				Failed: status == "cr" || status == "nr" ||
+					strings.Contains(lower, "failure") || strings.Contains(lower, "lost"),
+			})
+		}
+	}
+	return sample
+}
@@ -0,0 +1,334 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// kubectlTimeout caps a single kubectl call
+const kubectlTimeout = 5 * time.Second
+
+// k3sKubeconfig is where k3s writes its admin kubeconfig; kubectl only finds
+// it through KUBECONFIG or --kubeconfig
+const k3sKubeconfig = "/etc/rancher/k3s/k3s.yaml"
+
+// PodStat holds the requests and usage of a pod scheduled on this node.
+// CPU is in cores and memory in bytes, each summed over the pod's containers.
+type PodStat struct {
+	Namespace     string
+	Name          string
+	Phase         string // "Running", "Pending", "Succeeded", "Failed" or "Unknown"
+	Ready         int    // Containers passing their readiness checks
+	Containers    int
+	Restarts      int
+	CPURequest    float64
+	CPUUsage      float64 // From metrics-server; 0 when it isn't installed
+	MemoryRequest uint64
+	MemoryUsage   uint64
+}
+
+// KubernetesMetrics holds the pods running on the local node
+type KubernetesMetrics struct {
+	Configured bool   // A kubeconfig or in-cluster service account was found
+	Node       string // Node the pods were listed for
+	Pods       []PodStat
+	HasUsage   bool // metrics-server answered, so CPUUsage and MemoryUsage are set
+	LastUpdate time.Time
+}
+
+// KubernetesCollector lists the pods scheduled on the local node with
+// kubectl, along with their CPU and memory requests and, when metrics-server
+// is installed, their usage. It runs when a kubeconfig ($KUBECONFIG,
+// ~/.kube/config or the k3s one) or an in-cluster service account is found.
+// The node is $NODE_NAME when set (e.g. through the downward API) and the
+// hostname otherwise.
+type KubernetesCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *KubernetesMetrics
+}
+
+// NewKubernetesCollector creates a new Kubernetes pod collector
+func NewKubernetesCollector(interval uint) *KubernetesCollector {
+	return &KubernetesCollector{
+		interval: interval,
+	}
+}
+
+// Name returns the collector name
+func (c *KubernetesCollector) Name() string {
+	return "kubernetes"
+}
+
+// Interval returns the update interval in seconds
+func (c *KubernetesCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect lists the local node's pods. Finding no cluster configuration is
+// not an error; missing usage data is reported as a partial failure.
+func (c *KubernetesCollector) Collect(ctx context.Context) (interface{}, error) {
+	metrics := &KubernetesMetrics{LastUpdate: time.Now()}
+
+	args, ok := kubectlArgs()
+	if !ok {
+		c.mu.Lock()
+		c.lastData = metrics
+		c.mu.Unlock()
+		return metrics, nil
+	}
+	metrics.Configured = true
+
+	node, err := kubernetesNode()
+	if err != nil {
+		return nil, err
+	}
+	metrics.Node = node
+
+	output, err := runKubectl(ctx, append(args, "get", "pods", "--all-namespaces",
+		"--field-selector", "spec.nodeName="+node, "--output", "json")...)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := parsePodList(output)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make(map[string]error)
+	output, err = runKubectl(ctx, append(args, "get", "--raw", "/apis/metrics.k8s.io/v1beta1/pods")...)
+	if err == nil {
+		var usage map[string]podUsage
+		if usage, err = parsePodMetrics(output); err == nil {
+			metrics.HasUsage = true
+			for i := range pods {
+				u := usage[pods[i].Namespace+"/"+pods[i].Name]
+				pods[i].CPUUsage = u.cpu
+				pods[i].MemoryUsage = u.memory
+			}
+		}
+	}
+	if err != nil {
+		failed["pod usage (metrics-server)"] = err
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pods[i].CPUUsage != pods[j].CPUUsage {
+			return pods[i].CPUUsage > pods[j].CPUUsage
+		}
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	metrics.Pods = pods
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, data.NewPartialError(failed)
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *KubernetesCollector) GetLastData() *KubernetesMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// kubectlArgs returns the arguments that point kubectl at the cluster, and
+// false when there is no cluster configuration to use
+func kubectlArgs() ([]string, bool) {
+	// kubectl falls back to the pod's service account on its own
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" || os.Getenv("KUBECONFIG") != "" {
+		return nil, true
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if _, err := os.Stat(filepath.Join(home, ".kube", "config")); err == nil {
+			return nil, true
+		}
+	}
+	if _, err := os.Stat(k3sKubeconfig); err == nil {
+		return []string{"--kubeconfig", k3sKubeconfig}, true
+	}
+	return nil, false
+}
+
+// kubernetesNode returns the name of the node this host runs as. Node names
+// are the lowercased hostname unless the kubelet was told otherwise.
+func kubernetesNode() (string, error) {
+	if node := os.Getenv("NODE_NAME"); node != "" {
+		return node, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname for the node name: %w", err)
+	}
+	return strings.ToLower(hostname), nil
+}
+
+// runKubectl runs kubectl and returns its output
+func runKubectl(ctx context.Context, args ...string) ([]byte, error) {
+	path, err := exec.LookPath("kubectl")
+	if err != nil {
+		return nil, fmt.Errorf("kubectl not found: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, kubectlTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("kubectl timed out after %s", kubectlTimeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("kubectl failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("kubectl failed: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// parsePodList parses the output of kubectl get pods --output json
+func parsePodList(output []byte) ([]PodStat, error) {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				Containers []struct {
+					Resources struct {
+						Requests map[string]string `json:"requests"`
+					} `json:"resources"`
+				} `json:"containers"`
+			} `json:"spec"`
+			Status struct {
+				Phase             string `json:"phase"`
+				ContainerStatuses []struct {
+					Ready        bool `json:"ready"`
+					RestartCount int  `json:"restartCount"`
+				} `json:"containerStatuses"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse pod list: %w", err)
+	}
+
+	pods := make([]PodStat, 0, len(list.Items))
+	for _, item := range list.Items {
+		pod := PodStat{
+			Namespace:  item.Metadata.Namespace,
+			Name:       item.Metadata.Name,
+			Phase:      item.Status.Phase,
+			Containers: len(item.Spec.Containers),
+		}
+		for _, container := range item.Spec.Containers {
+			if cpu, err := parseQuantity(container.Resources.Requests["cpu"]); err == nil {
+				pod.CPURequest += cpu
+			}
+			if memory, err := parseQuantity(container.Resources.Requests["memory"]); err == nil {
+				pod.MemoryRequest += uint64(memory)
+			}
+		}
+		for _, status := range item.Status.ContainerStatuses {
+			if status.Ready {
+				pod.Ready++
+			}
+			pod.Restarts += status.RestartCount
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// podUsage is a pod's usage as reported by metrics-server
+type podUsage struct {
+	cpu    float64
+	memory uint64
+}
+
+// parsePodMetrics parses a metrics.k8s.io PodMetricsList into usage per
+// "namespace/name"
+func parsePodMetrics(output []byte) (map[string]podUsage, error) {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"metadata"`
+			Containers []struct {
+				Usage map[string]string `json:"usage"`
+			} `json:"containers"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse pod metrics: %w", err)
+	}
+
+	usage := make(map[string]podUsage, len(list.Items))
+	for _, item := range list.Items {
+		var u podUsage
+		for _, container := range item.Containers {
+			if cpu, err := parseQuantity(container.Usage["cpu"]); err == nil {
+				u.cpu += cpu
+			}
+			if memory, err := parseQuantity(container.Usage["memory"]); err == nil {
+				u.memory += uint64(memory)
+			}
+		}
+		usage[item.Metadata.Namespace+"/"+item.Metadata.Name] = u
+	}
+	return usage, nil
+}
+
+// quantitySuffixes are the Kubernetes resource quantity suffixes, binary
+// ones first so "Mi" isn't read as "M"
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"n", 1e-9}, {"u", 1e-6}, {"m", 1e-3},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// parseQuantity parses a Kubernetes resource quantity such as "250m",
+// "1.5", "128Mi" or "2e9" into base units (cores or bytes)
+func parseQuantity(s string) (float64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty quantity")
+	}
+	multiplier := 1.0
+	for _, q := range quantitySuffixes {
+		if strings.HasSuffix(s, q.suffix) {
+			s, multiplier = strings.TrimSuffix(s, q.suffix), q.multiplier
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q: %w", s, err)
+	}
+	return value * multiplier, nil
+}
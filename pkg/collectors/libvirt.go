@@ -0,0 +1,258 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// virshTimeout caps a single virsh call
+const virshTimeout = 5 * time.Second
+
+// defaultLibvirtURI is the system QEMU/KVM driver, which virsh only uses by
+// default when run as root
+const defaultLibvirtURI = "qemu:///system"
+
+// domainStates names libvirt's virDomainState values
+var domainStates = []string{"no state", "running", "blocked", "paused", "shutting down", "shut off", "crashed", "suspended"}
+
+// VMStat holds the state and usage of a single libvirt domain
+type VMStat struct {
+	Name            string
+	State           string  // "running", "paused", "shut off", ...
+	VCPUs           int     // Current virtual CPUs
+	CPU             float64 // Percent of one host core since the previous sample
+	MemoryUsed      uint64  // Guest memory as reported by the balloon driver
+	MemoryMax       uint64
+	DiskReadPerSec  float64 // Bytes per second over all block devices
+	DiskWritePerSec float64
+	NetRxPerSec     float64 // Bytes per second over all interfaces
+	NetTxPerSec     float64
+}
+
+// VMMetrics holds every libvirt domain, running ones first
+type VMMetrics struct {
+	URI        string // libvirt connection used; empty when virsh isn't installed
+	VMs        []VMStat
+	LastUpdate time.Time
+}
+
+// VMCollector collects per-domain state and usage from libvirt with
+// virsh domstats. It connects to $LIBVIRT_DEFAULT_URI when set and the
+// system QEMU/KVM driver otherwise. Hosts without virsh get an empty result.
+type VMCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *VMMetrics
+
+	// Counters per domain from the previous sample
+	lastSamples map[string]vmSample
+}
+
+// vmSample is a domain's cumulative counters at one point in time
+type vmSample struct {
+	cpu     uint64 // CPU time in nanoseconds
+	rdBytes uint64
+	wrBytes uint64
+	rxBytes uint64
+	txBytes uint64
+	at      time.Time
+}
+
+// NewVMCollector creates a new libvirt virtual machine collector
+func NewVMCollector(interval uint) *VMCollector {
+	return &VMCollector{
+		interval:    interval,
+		lastSamples: make(map[string]vmSample),
+	}
+}
+
+// Name returns the collector name
+func (c *VMCollector) Name() string {
+	return "vms"
+}
+
+// Interval returns the update interval in seconds
+func (c *VMCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers the state and usage of every libvirt domain
+func (c *VMCollector) Collect(ctx context.Context) (interface{}, error) {
+	now := time.Now()
+	metrics := &VMMetrics{LastUpdate: now}
+
+	path, err := exec.LookPath("virsh")
+	if err != nil {
+		c.mu.Lock()
+		c.lastData = metrics
+		c.mu.Unlock()
+		return metrics, nil
+	}
+
+	metrics.URI = os.Getenv("LIBVIRT_DEFAULT_URI")
+	if metrics.URI == "" {
+		metrics.URI = defaultLibvirtURI
+	}
+
+	output, err := runVirsh(ctx, path, "--connect", metrics.URI, "domstats",
+		"--state", "--cpu-total", "--balloon", "--vcpu", "--interface", "--block")
+	if err != nil {
+		return nil, err
+	}
+	domains := parseDomStats(bytes.NewReader(output))
+
+	c.mu.RLock()
+	prev := c.lastSamples
+	c.mu.RUnlock()
+
+	samples := make(map[string]vmSample, len(domains))
+	for _, domain := range domains {
+		vm := domain.stat
+		sample := domain.sample
+		sample.at = now
+		samples[vm.Name] = sample
+
+		if last, ok := prev[vm.Name]; ok {
+			if elapsed := now.Sub(last.at).Seconds(); elapsed > 0 {
+				vm.CPU = counterRate(sample.cpu, last.cpu, elapsed) / 1e9 * 100
+				vm.DiskReadPerSec = counterRate(sample.rdBytes, last.rdBytes, elapsed)
+				vm.DiskWritePerSec = counterRate(sample.wrBytes, last.wrBytes, elapsed)
+				vm.NetRxPerSec = counterRate(sample.rxBytes, last.rxBytes, elapsed)
+				vm.NetTxPerSec = counterRate(sample.txBytes, last.txBytes, elapsed)
+			}
+		}
+		metrics.VMs = append(metrics.VMs, vm)
+	}
+
+	sort.SliceStable(metrics.VMs, func(i, j int) bool {
+		a, b := metrics.VMs[i], metrics.VMs[j]
+		if aRunning, bRunning := a.State == "running", b.State == "running"; aRunning != bRunning {
+			return aRunning
+		}
+		if a.CPU != b.CPU {
+			return a.CPU > b.CPU
+		}
+		return a.Name < b.Name
+	})
+
+	c.mu.Lock()
+	c.lastSamples = samples
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, nil
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *VMCollector) GetLastData() *VMMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// counterRate returns how fast a counter grew per second; a counter that
+// went backwards (the domain restarted) counts as no change
+func counterRate(current, last uint64, elapsed float64) float64 {
+	if current < last {
+		return 0
+	}
+	return float64(current-last) / elapsed
+}
+
+// runVirsh runs virsh and returns its output
+func runVirsh(ctx context.Context, path string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, virshTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("virsh timed out after %s", virshTimeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("virsh domstats failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("virsh domstats failed: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// domStats is one domain parsed from virsh domstats
+type domStats struct {
+	stat   VMStat
+	sample vmSample
+}
+
+// parseDomStats parses the output of virsh domstats, e.g.
+//
+//	Domain: 'web'
+//	  state.state=1
+//	  cpu.time=123456789000
+//	  balloon.current=2097152
+//	  balloon.maximum=4194304
+//	  vcpu.current=2
+//	  net.0.rx.bytes=1024
+//	  block.0.rd.bytes=4096
+//
+// Memory is reported in KiB and CPU time in nanoseconds.
+func parseDomStats(r io.Reader) []domStats {
+	var domains []domStats
+	var current *domStats
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if name, ok := strings.CutPrefix(line, "Domain: "); ok {
+			domains = append(domains, domStats{stat: VMStat{Name: strings.Trim(name, "'\"")}})
+			current = &domains[len(domains)-1]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if current == nil || !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			// Device names and paths
+			continue
+		}
+
+		switch {
+		case key == "state.state":
+			if int(n) < len(domainStates) {
+				current.stat.State = domainStates[n]
+			}
+		case key == "cpu.time":
+			current.sample.cpu = n
+		case key == "balloon.current":
+			current.stat.MemoryUsed = n * 1024
+		case key == "balloon.maximum":
+			current.stat.MemoryMax = n * 1024
+		case key == "vcpu.current":
+			current.stat.VCPUs = int(n)
+		case strings.HasPrefix(key, "net.") && strings.HasSuffix(key, ".rx.bytes"):
+			current.sample.rxBytes += n
+		case strings.HasPrefix(key, "net.") && strings.HasSuffix(key, ".tx.bytes"):
+			current.sample.txBytes += n
+		case strings.HasPrefix(key, "block.") && strings.HasSuffix(key, ".rd.bytes"):
+			current.sample.rdBytes += n
+		case strings.HasPrefix(key, "block.") && strings.HasSuffix(key, ".wr.bytes"):
+			current.sample.wrBytes += n
+		}
+	}
+	return domains
+}
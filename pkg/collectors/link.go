@@ -0,0 +1,41 @@
+package collectors
+
+import "strconv"
+
+// sysClassNet holds one directory per network interface on Linux
+const sysClassNet = "/sys/class/net/"
+
+// LinkStat holds the negotiated link state of a network interface.
+// Readings that aren't known are zero.
+type LinkStat struct {
+	Speed     int    // Mb/s; 0 when unknown (Wi-Fi, virtual, or no carrier)
+	Duplex    string // "full" or "half"
+	OperState string // "up", "down", "dormant", ...
+}
+
+// readLinks reads the speed, duplex and operational state of each
+// interface from /sys/class/net. It returns nil on other platforms.
+func readLinks(names []string) map[string]LinkStat {
+	links := make(map[string]LinkStat)
+	for _, name := range names {
+		dir := sysClassNet + name + "/"
+		link := LinkStat{OperState: readSysfsString(dir + "operstate")}
+		if link.OperState == "" {
+			// No sysfs entry: not Linux, or the interface just went away
+			continue
+		}
+		// Reading speed fails with EINVAL without a carrier, and reports
+		// -1 for drivers that don't know it
+		if speed, err := strconv.Atoi(readSysfsString(dir + "speed")); err == nil && speed > 0 {
+			link.Speed = speed
+		}
+		if duplex := readSysfsString(dir + "duplex"); duplex == "full" || duplex == "half" {
+			link.Duplex = duplex
+		}
+		links[name] = link
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return links
+}
@@ -0,0 +1,139 @@
+package collectors
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// procMDStat lists the Linux software RAID (md) arrays
+const procMDStat = "/proc/mdstat"
+
+// RAIDArray holds the state of a Linux software RAID array
+type RAIDArray struct {
+	Name        string // e.g. "md0"
+	Level       string // e.g. "raid1"; empty for inactive arrays
+	State       string // "active", "inactive", "active (auto-read-only)", ...
+	Devices     int    // Member devices the array is built for
+	Active      int    // Member devices currently in sync
+	Failed      int    // Members marked faulty
+	Spares      int
+	Status      string  // Member map such as "UU_", "_" marks a missing device
+	Sync        string  // "resync", "recovery", "check", "reshape" or "repair"; empty when idle
+	SyncPercent float64 // Progress of Sync
+	SyncFinish  string  // Estimated time left as reported, e.g. "12.3min"
+}
+
+var (
+	// mdCounts matches the configured and active member counts, e.g. [2/1]
+	mdCounts = regexp.MustCompile(`\[(\d+)/(\d+)\]`)
+	// mdStatus matches the member map, e.g. [U_]
+	mdStatus = regexp.MustCompile(`\[([U_]+)\]`)
+	// mdSync matches a running or pending sync, e.g. "recovery = 28.9%" or "resync=DELAYED"
+	mdSync = regexp.MustCompile(`(resync|recovery|check|reshape|repair)\s*=\s*(?:([\d.]+)%|(\w+))`)
+	// mdFinish matches the sync's estimated time left
+	mdFinish = regexp.MustCompile(`finish=(\S+)`)
+)
+
+// readMDStat reads every array from /proc/mdstat. It returns nil on other
+// platforms and when the md driver isn't loaded.
+func readMDStat() []RAIDArray {
+	file, err := os.Open(procMDStat)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	return parseMDStat(file)
+}
+
+// parseMDStat parses /proc/mdstat, e.g.
+//
+//	Personalities : [raid1] [raid5]
+//	md1 : active raid5 sdc1[3] sdb1[1] sda1[0]
+//	      2095104 blocks super 1.2 level 5, 512k chunk, algorithm 2 [3/2] [UU_]
+//	      [=====>...............]  recovery = 28.9% (303360/1047552) finish=0.5min speed=25280K/sec
+//
+//	md0 : active raid1 sdb1[1](F) sda1[0]
+//	      1048512 blocks super 1.2 [2/1] [U_]
+//
+//	unused devices: <none>
+func parseMDStat(r io.Reader) []RAIDArray {
+	var arrays []RAIDArray
+	var current *RAIDArray
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+
+		// Array lines start in the first column, details are indented
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			current = nil
+			name, rest, ok := strings.Cut(line, " : ")
+			if !ok || !strings.HasPrefix(name, "md") {
+				continue
+			}
+			arrays = append(arrays, parseMDArray(strings.TrimSpace(name), strings.Fields(rest)))
+			current = &arrays[len(arrays)-1]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if m := mdCounts.FindStringSubmatch(line); m != nil {
+			current.Devices, _ = strconv.Atoi(m[1])
+			current.Active, _ = strconv.Atoi(m[2])
+		}
+		if m := mdStatus.FindStringSubmatch(line); m != nil {
+			current.Status = m[1]
+		}
+		if m := mdSync.FindStringSubmatch(line); m != nil {
+			current.Sync = m[1]
+			if m[2] != "" {
+				current.SyncPercent, _ = strconv.ParseFloat(m[2], 64)
+			} else {
+				// DELAYED or PENDING
+				current.SyncFinish = strings.ToLower(m[3])
+			}
+			if f := mdFinish.FindStringSubmatch(line); f != nil {
+				current.SyncFinish = f[1]
+			}
+		}
+	}
+	return arrays
+}
+
+// parseMDArray parses the fields after "mdN : ": the state, the RAID level
+// of active arrays, then the member devices such as sdb1[1](F)
+func parseMDArray(name string, fields []string) RAIDArray {
+	array := RAIDArray{Name: name}
+	if len(fields) == 0 {
+		return array
+	}
+
+	array.State = fields[0]
+	i := 1
+	for ; i < len(fields) && strings.HasPrefix(fields[i], "("); i++ {
+		array.State += " " + fields[i]
+	}
+	if i < len(fields) && !strings.Contains(fields[i], "[") {
+		array.Level = fields[i]
+		i++
+	}
+	for _, member := range fields[i:] {
+		switch {
+		case strings.HasSuffix(member, "(F)"):
+			array.Failed++
+		case strings.HasSuffix(member, "(S)"):
+			array.Spares++
+		}
+	}
+	return array
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ctcac00/metrics-tui/internal/data"
 	"github.com/shirou/gopsutil/v4/mem"
 )
 
@@ -15,6 +16,25 @@ type SwapMemoryStat struct {
 	Used        uint64
 	Free        uint64
 	UsedPercent float64
+	InPerSec    float64 // Bytes swapped in per second (Linux; 0 until two samples)
+	OutPerSec   float64 // Bytes swapped out per second
+}
+
+// HugePagesStat holds the static huge page pool (Linux)
+type HugePagesStat struct {
+	Total    uint64 // Pages in the pool
+	Free     uint64 // Pages not allocated
+	PageSize uint64 // Bytes per page
+}
+
+// MemoryBreakdown splits total memory by use, in percent. Free memory is
+// what's left over.
+type MemoryBreakdown struct {
+	Apps      float64 // Process memory and unreclaimable kernel allocations outside slab
+	Buffers   float64
+	Cache     float64 // Page cache, without reclaimable slab
+	Slab      float64
+	HugePages float64 // Reserved for the huge page pool, used or not
 }
 
 // MemoryMetrics holds memory usage data
@@ -25,22 +45,38 @@ type MemoryMetrics struct {
 	UsedPercent float64
 	Free        uint64
 	Buffers     uint64 // Linux-specific
-	Cached      uint64 // Linux-specific
+	Cached      uint64 // Page cache plus reclaimable slab, like free(1)'s cache (Linux)
+	Shared      uint64 // tmpfs and shared memory, part of Cached (Linux)
+	Slab        uint64 // Kernel data structures, reclaimable or not (Linux)
+	Dirty       uint64 // Modified pages waiting to be written back (Linux)
+	HugePages   HugePagesStat
+	Breakdown   *MemoryBreakdown // Split of total memory by use, nil when unavailable
 	Swap        SwapMemoryStat
+	NUMA        []NUMANode // Per-node breakdown on multi-node machines (Linux)
+	Cgroup      bool       // Total and usage are this process's cgroup limit and working set
 	LastUpdate  time.Time
 }
 
 // MemoryCollector collects memory metrics
 type MemoryCollector struct {
-	interval uint
-	mu       sync.RWMutex
-	lastData *MemoryMetrics
+	interval  uint
+	usedBasis string // "gopsutil" or "available", see Collect
+	cgroup    bool   // Report the cgroup's memory limit when one is set
+	mu        sync.RWMutex
+	lastData  *MemoryMetrics
+
+	// Swap-in/out byte counters from the previous sample, for rates
+	lastSin  uint64
+	lastSout uint64
+	lastSwap time.Time
 }
 
 // NewMemoryCollector creates a new memory collector
-func NewMemoryCollector(interval uint) *MemoryCollector {
+func NewMemoryCollector(interval uint, usedBasis string, cgroup bool) *MemoryCollector {
 	return &MemoryCollector{
-		interval: interval,
+		interval:  interval,
+		usedBasis: usedBasis,
+		cgroup:    cgroup,
 	}
 }
 
@@ -61,9 +97,11 @@ func (c *MemoryCollector) Collect(ctx context.Context) (interface{}, error) {
 		return nil, fmt.Errorf("failed to get virtual memory: %w", err)
 	}
 
+	failed := make(map[string]error)
 	swapMem, err := mem.SwapMemory()
 	if err != nil {
 		// Swap stats are optional, continue without them
+		failed["swap"] = err
 		swapMem = &mem.SwapMemoryStat{}
 	}
 
@@ -79,19 +117,60 @@ func (c *MemoryCollector) Collect(ctx context.Context) (interface{}, error) {
 			Free:        swapMem.Free,
 			UsedPercent: swapMem.UsedPercent,
 		},
+		NUMA:       readNUMANodes(),
 		LastUpdate: time.Now(),
 	}
 
-	// Try to get extended stats (buffers/cached) on Linux
-	if vmem.SwapCached > 0 {
-		metrics.Cached = vmem.SwapCached
+	// With the "available" basis, reclaimable cache doesn't count as used,
+	// matching the available column of free(1)
+	if c.usedBasis == "available" && vmem.Total > 0 && vmem.Available <= vmem.Total {
+		metrics.Used = vmem.Total - vmem.Available
+		metrics.UsedPercent = float64(metrics.Used) / float64(vmem.Total) * 100
+	}
+
+	// Inside a container with a memory limit, the limit is what the
+	// processes can use before the OOM killer steps in, not the host's RAM.
+	// Unlimited cgroup v1 limits read as a huge number, hence the comparison.
+	if c.cgroup {
+		if limit, used, ok := readCgroupMemory(); ok && limit > 0 && limit < vmem.Total {
+			metrics.Total = limit
+			metrics.Used = min(used, limit)
+			metrics.Available = limit - metrics.Used
+			metrics.Free = metrics.Available
+			metrics.UsedPercent = float64(metrics.Used) / float64(limit) * 100
+			metrics.NUMA = nil
+			metrics.Cgroup = true
+		}
+	}
+
+	// The /proc/meminfo details only describe the host's memory
+	if !metrics.Cgroup {
+		metrics.Buffers = vmem.Buffers
+		metrics.Cached = vmem.Cached
+		metrics.Shared = vmem.Shared
+		metrics.Slab = vmem.Slab
+		metrics.Dirty = vmem.Dirty
+		metrics.HugePages = HugePagesStat{
+			Total:    vmem.HugePagesTotal,
+			Free:     vmem.HugePagesFree,
+			PageSize: vmem.HugePageSize,
+		}
+		metrics.Breakdown = memoryBreakdown(vmem)
 	}
 
+	now := time.Now()
 	c.mu.Lock()
+	// Counters that went back (reset) are skipped like a first sample
+	if elapsed := now.Sub(c.lastSwap).Seconds(); !c.lastSwap.IsZero() && elapsed > 0 &&
+		swapMem.Sin >= c.lastSin && swapMem.Sout >= c.lastSout {
+		metrics.Swap.InPerSec = float64(swapMem.Sin-c.lastSin) / elapsed
+		metrics.Swap.OutPerSec = float64(swapMem.Sout-c.lastSout) / elapsed
+	}
+	c.lastSin, c.lastSout, c.lastSwap = swapMem.Sin, swapMem.Sout, now
 	c.lastData = metrics
 	c.mu.Unlock()
 
-	return metrics, nil
+	return metrics, data.NewPartialError(failed)
 }
 
 // GetLastData returns the last collected data (thread-safe)
@@ -100,3 +179,32 @@ func (c *MemoryCollector) GetLastData() *MemoryMetrics {
 	defer c.mu.RUnlock()
 	return c.lastData
 }
+
+// memoryBreakdown splits total memory into apps, buffers, cache, slab and
+// huge pages. gopsutil counts reclaimable slab as cache, like free(1), so it
+// is moved back to slab here. It returns nil where the kernel doesn't
+// report buffers and cache.
+func memoryBreakdown(vmem *mem.VirtualMemoryStat) *MemoryBreakdown {
+	if vmem.Total == 0 || vmem.Buffers+vmem.Cached == 0 {
+		return nil
+	}
+	cache := vmem.Cached - min(vmem.Sreclaimable, vmem.Cached)
+	huge := vmem.HugePagesTotal * vmem.HugePageSize
+
+	// Apps is whatever isn't free or accounted for by the others
+	var apps uint64
+	if accounted := vmem.Free + vmem.Buffers + cache + vmem.Slab + huge; accounted < vmem.Total {
+		apps = vmem.Total - accounted
+	}
+
+	percent := func(bytes uint64) float64 {
+		return float64(bytes) / float64(vmem.Total) * 100
+	}
+	return &MemoryBreakdown{
+		Apps:      percent(apps),
+		Buffers:   percent(vmem.Buffers),
+		Cache:     percent(cache),
+		Slab:      percent(vmem.Slab),
+		HugePages: percent(huge),
+	}
+}
@@ -3,9 +3,12 @@ package collectors
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ctcac00/metrics-tui/internal/data"
 	"github.com/shirou/gopsutil/v4/net"
 )
 
@@ -13,6 +16,9 @@ import (
 type NetworkMetrics struct {
 	Interfaces  []net.InterfaceStat
 	IO          map[string]net.IOCountersStat
+	Wireless    map[string]WirelessStat // Wi-Fi link per wireless interface (Linux)
+	Links       map[string]LinkStat     // Speed, duplex and state per interface (Linux)
+	Rates       map[string]NetIORate    // Per interface since the previous collection; empty after the first
 	LastUpdate  time.Time
 }
 
@@ -21,19 +27,23 @@ type NetworkCollector struct {
 	interval      uint
 	interfaces    []string // Specific interfaces to monitor (empty = all)
 	excludeVirtual bool
+	excludeLoopback bool
 	mu            sync.RWMutex
 	lastData      *NetworkMetrics
 	lastIO        map[string]net.IOCountersStat
 	lastIOTime    time.Time
+	wirelessLinks map[string]wirelessLink // Last iw reading per interface
 }
 
 // NewNetworkCollector creates a new network collector
-func NewNetworkCollector(interval uint, interfaces []string, excludeVirtual bool) *NetworkCollector {
+func NewNetworkCollector(interval uint, interfaces []string, excludeVirtual, excludeLoopback bool) *NetworkCollector {
 	return &NetworkCollector{
-		interval:       interval,
-		interfaces:     interfaces,
-		excludeVirtual: excludeVirtual,
+		interval:        interval,
+		interfaces:      interfaces,
+		excludeVirtual:  excludeVirtual,
+		excludeLoopback: excludeLoopback,
 		lastIO:         make(map[string]net.IOCountersStat),
+		wirelessLinks:   make(map[string]wirelessLink),
 	}
 }
 
@@ -65,6 +75,11 @@ func (c *NetworkCollector) Collect(ctx context.Context) (interface{}, error) {
 			continue
 		}
 
+		// Skip loopback if requested; its counters dwarf real traffic
+		if c.excludeLoopback && isLoopbackInterface(iface) {
+			continue
+		}
+
 		// Skip interfaces with no addresses (down)
 		if iface.Addrs == nil || len(iface.Addrs) == 0 {
 			continue
@@ -86,10 +101,11 @@ func (c *NetworkCollector) Collect(ctx context.Context) (interface{}, error) {
 		}
 	}
 
-	// Get IO counters (per NIC)
+	// Get IO counters (per NIC); keep the interface list if they fail
+	failed := make(map[string]error)
 	ioCounters, err := net.IOCounters(true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get network IO counters: %w", err)
+		failed["I/O counters"] = err
 	}
 
 	// Filter IO counters to monitored interfaces
@@ -103,19 +119,32 @@ func (c *NetworkCollector) Collect(ctx context.Context) (interface{}, error) {
 		}
 	}
 
+	// Note monitored interfaces the kernel reported no counters for
+	if err == nil {
+		for _, name := range interfacesToMonitor {
+			if _, ok := ioMap[name]; !ok {
+				failed[name] = fmt.Errorf("no I/O counters reported")
+			}
+		}
+	}
+
+	now := time.Now()
 	metrics := &NetworkMetrics{
 		Interfaces: filteredInterfaces,
 		IO:         ioMap,
-		LastUpdate: time.Now(),
+		Wireless:   c.collectWireless(ctx, interfacesToMonitor),
+		Links:      readLinks(interfacesToMonitor),
+		LastUpdate: now,
 	}
 
 	c.mu.Lock()
+	metrics.Rates = ioRates(c.lastIO, ioMap, now.Sub(c.lastIOTime).Seconds())
 	c.lastData = metrics
 	c.lastIO = ioMap
-	c.lastIOTime = time.Now()
+	c.lastIOTime = now
 	c.mu.Unlock()
 
-	return metrics, nil
+	return metrics, data.NewPartialError(failed)
 }
 
 // GetLastData returns the last collected data (thread-safe)
@@ -125,44 +154,92 @@ func (c *NetworkCollector) GetLastData() *NetworkMetrics {
 	return c.lastData
 }
 
-// GetIORate calculates network IO rate since last collection (thread-safe)
+// collectWireless reads the Wi-Fi link of each monitored wireless
+// interface. The signal is read on every collection; the slower-changing
+// SSID, bitrate and frequency come from iw every wirelessLinkInterval.
+func (c *NetworkCollector) collectWireless(ctx context.Context, monitored []string) map[string]WirelessStat {
+	stats := readProcWireless()
+	for name, stat := range stats {
+		if !slices.Contains(monitored, name) {
+			delete(stats, name)
+			continue
+		}
+
+		c.mu.RLock()
+		link, ok := c.wirelessLinks[name]
+		c.mu.RUnlock()
+		if !ok || time.Since(link.at) >= wirelessLinkInterval {
+			link = readIWLink(ctx, name)
+			c.mu.Lock()
+			c.wirelessLinks[name] = link
+			c.mu.Unlock()
+		}
+
+		stat.SSID = link.ssid
+		stat.Bitrate = link.bitrate
+		stat.Frequency = link.frequency
+		stat.Channel = wifiChannel(link.frequency)
+		stats[name] = stat
+	}
+	return stats
+}
+
+// GetIORate returns each interface's IO rate between the last two
+// collections, or nil before the second (thread-safe)
 func (c *NetworkCollector) GetIORate() map[string]NetIORate {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	if len(c.lastIO) == 0 {
+	if c.lastData == nil {
 		return nil
 	}
+	return c.lastData.Rates
+}
 
-	elapsed := time.Since(c.lastIOTime).Seconds()
-	if elapsed == 0 {
+// ioRates computes per-interface rates from two counter samples taken
+// elapsed seconds apart. Interfaces missing from either sample, or whose
+// counters went back (the interface was recreated), are left out.
+func ioRates(prev, current map[string]net.IOCountersStat, elapsed float64) map[string]NetIORate {
+	if len(prev) == 0 || elapsed <= 0 {
 		return nil
 	}
 
-	rates := make(map[string]NetIORate)
-	for iface, currentIO := range c.lastIO {
+	rates := make(map[string]NetIORate, len(current))
+	for iface, cur := range current {
+		old, ok := prev[iface]
+		if !ok || cur.BytesSent < old.BytesSent || cur.BytesRecv < old.BytesRecv {
+			continue
+		}
+		rate := func(now, before uint64) float64 {
+			if now < before {
+				return 0
+			}
+			return float64(now-before) / elapsed
+		}
 		rates[iface] = NetIORate{
-			BytesSentPerSec:   float64(currentIO.BytesSent) / elapsed,
-			BytesRecvPerSec:   float64(currentIO.BytesRecv) / elapsed,
-			PacketsSentPerSec: float64(currentIO.PacketsSent) / elapsed,
-			PacketsRecvPerSec: float64(currentIO.PacketsRecv) / elapsed,
-			ErrInPerSec:       float64(currentIO.Errin) / elapsed,
-			ErrOutPerSec:      float64(currentIO.Errout) / elapsed,
+			BytesSentPerSec:   rate(cur.BytesSent, old.BytesSent),
+			BytesRecvPerSec:   rate(cur.BytesRecv, old.BytesRecv),
+			PacketsSentPerSec: rate(cur.PacketsSent, old.PacketsSent),
+			PacketsRecvPerSec: rate(cur.PacketsRecv, old.PacketsRecv),
+			ErrInPerSec:       rate(cur.Errin, old.Errin),
+			ErrOutPerSec:      rate(cur.Errout, old.Errout),
 		}
 	}
-
 	return rates
 }
 
-// isVirtualInterface checks if an interface is virtual
+// isVirtualInterface checks if an interface is virtual (container, VM,
+// or tunnel plumbing). Bridges like br0 are kept since they often carry
+// the host's real traffic; only Docker's br-<id> networks are skipped.
 func isVirtualInterface(name string) bool {
 	virtualPrefixes := []string{
 		"veth", "docker", "br-", "virbr", "tun", "tap",
 		"vnet", "kube", "flannel", "cali", "cni",
+		"podman", "cilium", "lxc", "vxlan", "weave",
 	}
 
 	for _, prefix := range virtualPrefixes {
-		if len(name) >= len(prefix) && name[:len(prefix)] == prefix {
+		if strings.HasPrefix(name, prefix) {
 			return true
 		}
 	}
@@ -170,6 +247,16 @@ func isVirtualInterface(name string) bool {
 	return false
 }
 
+// isLoopbackInterface checks if an interface is a loopback device
+func isLoopbackInterface(iface net.InterfaceStat) bool {
+	for _, flag := range iface.Flags {
+		if flag == "loopback" {
+			return true
+		}
+	}
+	return iface.Name == "lo" || iface.Name == "lo0"
+}
+
 // NetIORate represents network IO rate between two samples
 type NetIORate struct {
 	BytesSentPerSec   float64
@@ -0,0 +1,32 @@
+package collectors
+
+import "testing"
+
+func TestIsVirtualInterface(t *testing.T) {
+	tests := []struct {
+		name    string
+		virtual bool
+	}{
+		{"eth0", false},
+		{"wlan0", false},
+		{"enp3s0", false},
+		{"br0", false},
+		{"lo", false}, // Loopback is filtered separately
+		{"docker0", true},
+		{"br-abc123", true},
+		{"veth1234", true},
+		{"virbr0", true},
+		{"tun0", true},
+		{"cni0", true},
+		{"podman0", true},
+		{"cilium_host", true},
+		{"lxcbr0", true},
+		{"vxlan.calico", true},
+		{"weave", true},
+	}
+	for _, tt := range tests {
+		if got := isVirtualInterface(tt.name); got != tt.virtual {
+			t.Errorf("isVirtualInterface(%q) = %v, want %v", tt.name, got, tt.virtual)
+		}
+	}
+}
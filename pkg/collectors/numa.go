@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sysNodeDir lists the NUMA nodes of a Linux system
+const sysNodeDir = "/sys/devices/system/node"
+
+// NUMANode holds the memory usage and allocation counters of a NUMA node
+type NUMANode struct {
+	ID        int
+	Total     uint64
+	Free      uint64
+	Used      uint64
+	FilePages uint64 // Page cache on this node
+	Hit       uint64 // Pages allocated here as intended, since boot
+	Miss      uint64 // Pages allocated here though another node was preferred
+	Foreign   uint64 // Pages meant for this node but allocated elsewhere
+	Local     uint64 // Pages allocated here for a process running here
+	Other     uint64 // Pages allocated here for a process on another node
+}
+
+// readNUMANodes reads every node's meminfo and numastat. It returns nil on
+// other platforms and on single-node machines, where the breakdown would
+// only repeat the totals.
+func readNUMANodes() []NUMANode {
+	dirs, err := filepath.Glob(filepath.Join(sysNodeDir, "node[0-9]*"))
+	if err != nil || len(dirs) < 2 {
+		return nil
+	}
+
+	var nodes []NUMANode
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+		node := NUMANode{ID: id}
+
+		// Memory-less nodes (CPU-only sockets, some CXL setups) have no meminfo
+		if file, err := os.Open(filepath.Join(dir, "meminfo")); err == nil {
+			parseNodeMeminfo(file, &node)
+			file.Close()
+		}
+		if file, err := os.Open(filepath.Join(dir, "numastat")); err == nil {
+			parseNodeNumastat(file, &node)
+			file.Close()
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// parseNodeMeminfo parses a node's meminfo, e.g.
+//
+//	Node 0 MemTotal:       16318168 kB
+//	Node 0 MemFree:         9023584 kB
+//	Node 0 MemUsed:         7294584 kB
+//	Node 0 FilePages:       4821024 kB
+func parseNodeMeminfo(r io.Reader, node *NUMANode) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// "Node", the node number, the key and the value in kB
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch strings.TrimSuffix(fields[2], ":") {
+		case "MemTotal":
+			node.Total = kb * 1024
+		case "MemFree":
+			node.Free = kb * 1024
+		case "MemUsed":
+			node.Used = kb * 1024
+		case "FilePages":
+			node.FilePages = kb * 1024
+		}
+	}
+}
+
+// parseNodeNumastat parses a node's numastat page counters, e.g.
+//
+//	numa_hit 1205740364
+//	numa_miss 0
+//	numa_foreign 0
+//	interleave_hit 46713
+//	local_node 1205664437
+//	other_node 75927
+func parseNodeNumastat(r io.Reader, node *NUMANode) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "numa_hit":
+			node.Hit = count
+		case "numa_miss":
+			node.Miss = count
+		case "numa_foreign":
+			node.Foreign = count
+		case "local_node":
+			node.Local = count
+		case "other_node":
+			node.Other = count
+		}
+	}
+}
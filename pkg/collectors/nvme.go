@@ -0,0 +1,168 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+// nvmeClassPath is where the kernel lists NVMe controllers
+const nvmeClassPath = "/sys/class/nvme"
+
+// nvmeCLITimeout caps a single nvme-cli run
+const nvmeCLITimeout = 5 * time.Second
+
+// nvmeHealthInterval is how often a controller's SMART log is re-read. Wear
+// and error counters move slowly, and nvme-cli is an extra process per
+// drive, so it isn't run on every disk collection.
+const nvmeHealthInterval = time.Minute
+
+// DiskHealth is the SMART / health information log of an NVMe drive
+type DiskHealth struct {
+	CriticalWarning uint8  // Bit field; 0 when the drive reports no problem
+	PercentUsed     int    // Estimated share of rated endurance used; may exceed 100
+	AvailableSpare  int    // Percent of spare blocks left
+	SpareThreshold  int    // AvailableSpare at or below this triggers a warning
+	MediaErrors     uint64 // Unrecovered data integrity errors
+}
+
+// nvmeHealthSample is a SMART log reading (or the error reading it) and
+// when it was taken
+type nvmeHealthSample struct {
+	health DiskHealth
+	err    error
+	at     time.Time
+}
+
+// nvmeController returns the controller name (e.g. "nvme0") of an NVMe disk
+// from its sysfs directory, or "" for other disks. With native multipathing
+// the disk belongs to a subsystem and the first controller is used.
+func nvmeController(sysPath string) string {
+	device, err := filepath.EvalSymlinks(sysPath + "/device")
+	if err != nil {
+		return ""
+	}
+	name := filepath.Base(device)
+	if strings.HasPrefix(name, "nvme-subsys") {
+		controllers, _ := filepath.Glob(device + "/nvme[0-9]*")
+		if len(controllers) == 0 {
+			return ""
+		}
+		name = filepath.Base(controllers[0])
+	}
+	if !strings.HasPrefix(name, "nvme") || readSysfsString(nvmeClassPath+"/"+name+"/model") == "" {
+		return ""
+	}
+	return name
+}
+
+// readNVMeHealth reads a controller's SMART log through nvme-cli, which
+// opens /dev/nvmeN and therefore usually needs root
+func readNVMeHealth(ctx context.Context, controller string) (DiskHealth, error) {
+	path, err := exec.LookPath("nvme")
+	if err != nil {
+		return DiskHealth{}, fmt.Errorf("nvme-cli not found: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, nvmeCLITimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, "smart-log", "/dev/"+controller, "--output-format=json")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return DiskHealth{}, fmt.Errorf("nvme smart-log timed out after %s", nvmeCLITimeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return DiskHealth{}, fmt.Errorf("nvme smart-log failed: %w: %s", err, msg)
+		}
+		return DiskHealth{}, fmt.Errorf("nvme smart-log failed: %w", err)
+	}
+
+	return parseNVMeSmartLog(stdout.Bytes())
+}
+
+// parseNVMeSmartLog parses `nvme smart-log -o json` output, e.g.
+//
+//	{"critical_warning":0,"temperature":309,"avail_spare":100,
+//	 "spare_thresh":10,"percent_used":2,"media_errors":0,...}
+//
+// Depending on the nvme-cli version numbers may be quoted and percent_used
+// may be named percentage_used.
+func parseNVMeSmartLog(output []byte) (DiskHealth, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(output, &fields); err != nil {
+		return DiskHealth{}, fmt.Errorf("unexpected nvme smart-log output: %w", err)
+	}
+
+	number := func(keys ...string) (uint64, bool) {
+		for _, key := range keys {
+			raw, ok := fields[key]
+			if !ok {
+				continue
+			}
+			text := strings.TrimSuffix(strings.Trim(string(raw), `"`), "%")
+			if value, err := strconv.ParseUint(strings.TrimSpace(text), 10, 64); err == nil {
+				return value, true
+			}
+		}
+		return 0, false
+	}
+
+	used, ok := number("percent_used", "percentage_used")
+	if !ok {
+		return DiskHealth{}, fmt.Errorf("nvme smart-log output has no percent_used")
+	}
+	warning, _ := number("critical_warning")
+	spare, _ := number("avail_spare")
+	threshold, _ := number("spare_thresh")
+	mediaErrors, _ := number("media_errors")
+
+	return DiskHealth{
+		CriticalWarning: uint8(warning),
+		PercentUsed:     int(used),
+		AvailableSpare:  int(spare),
+		SpareThreshold:  int(threshold),
+		MediaErrors:     mediaErrors,
+	}, nil
+}
+
+// nvmeTemperatures reads the composite temperature of every NVMe controller
+// from its hwmon sensor. The hwmon driver names them all "nvme", so they are
+// keyed by controller instead (e.g. "nvme0_composite") to tell drives apart.
+func nvmeTemperatures() []sensors.TemperatureStat {
+	inputs, _ := filepath.Glob(nvmeClassPath + "/nvme[0-9]*/hwmon*/temp1_input")
+
+	var temps []sensors.TemperatureStat
+	for _, input := range inputs {
+		temp, err := readHwmonTemp(input)
+		if err != nil {
+			continue
+		}
+		controller := filepath.Base(filepath.Dir(filepath.Dir(input)))
+		stat := sensors.TemperatureStat{
+			SensorKey:   controller + "_composite",
+			Temperature: temp,
+		}
+		hwmon := filepath.Dir(input)
+		if high, err := readHwmonTemp(hwmon + "/temp1_max"); err == nil {
+			stat.High = high
+		}
+		if critical, err := readHwmonTemp(hwmon + "/temp1_crit"); err == nil {
+			stat.Critical = critical
+		}
+		temps = append(temps, stat)
+	}
+	return temps
+}
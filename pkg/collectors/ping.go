@@ -0,0 +1,192 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+const (
+	// pingCount is the number of echo requests sent to each target per
+	// collection
+	pingCount = 3
+
+	// pingTimeout caps a single ping run; with one probe a second the
+	// replies arrive within about pingCount seconds
+	pingTimeout = pingCount*time.Second + 2*time.Second
+)
+
+var (
+	// pingLoss matches the loss summary of iputils, BSD, busybox and Windows
+	// ping, e.g. "0% packet loss", "33.3% packet loss" or "(0% loss)"
+	pingLoss = regexp.MustCompile(`([\d.]+)% (?:packet )?loss`)
+	// pingAverage matches the average of the round-trip summary, e.g.
+	// "rtt min/avg/max/mdev = 9.8/10.4/11.2/0.5 ms" or
+	// "round-trip min/avg/max = 9.8/10.4/11.2 ms"
+	pingAverage = regexp.MustCompile(`= [\d.]+/([\d.]+)/`)
+	// pingAverageWindows matches Windows' "Average = 10ms"
+	pingAverageWindows = regexp.MustCompile(`Average = (\d+)ms`)
+)
+
+// PingStat holds the latest latency measurement of a ping target
+type PingStat struct {
+	Host string
+	RTT  float64 // Average round-trip time in milliseconds; 0 without replies
+	Loss float64 // Percent of echo requests that got no reply
+}
+
+// PingMetrics holds the latency of every configured target, in config order
+type PingMetrics struct {
+	Targets    []PingStat
+	LastUpdate time.Time
+}
+
+// PingCollector measures round-trip time and packet loss to user-configured
+// hosts with the system ping, which works without root where raw ICMP
+// sockets wouldn't
+type PingCollector struct {
+	interval uint
+	targets  []string
+	mu       sync.RWMutex
+	lastData *PingMetrics
+}
+
+// NewPingCollector creates a new ping collector for the given hosts
+func NewPingCollector(interval uint, targets []string) *PingCollector {
+	return &PingCollector{
+		interval: interval,
+		targets:  targets,
+	}
+}
+
+// Name returns the collector name
+func (c *PingCollector) Name() string {
+	return "ping"
+}
+
+// Interval returns the update interval in seconds
+func (c *PingCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect pings every target concurrently. Unreachable or unknown hosts
+// count as full loss and are reported as partial failures.
+func (c *PingCollector) Collect(ctx context.Context) (interface{}, error) {
+	metrics := &PingMetrics{LastUpdate: time.Now()}
+	if len(c.targets) == 0 {
+		c.mu.Lock()
+		c.lastData = metrics
+		c.mu.Unlock()
+		return metrics, nil
+	}
+
+	path, err := exec.LookPath("ping")
+	if err != nil {
+		return nil, fmt.Errorf("ping not found: %w", err)
+	}
+
+	metrics.Targets = make([]PingStat, len(c.targets))
+	errs := make([]error, len(c.targets))
+	var wg sync.WaitGroup
+	for i, target := range c.targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			metrics.Targets[i], errs[i] = runPing(ctx, path, target)
+		}()
+	}
+	wg.Wait()
+
+	failed := make(map[string]error)
+	for i, err := range errs {
+		if err != nil {
+			failed["ping "+c.targets[i]] = err
+		}
+	}
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, data.NewPartialError(failed)
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *PingCollector) GetLastData() *PingMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// runPing sends pingCount echo requests to a host. ping exits non-zero when
+// no reply came back; that's still a valid measurement as long as it
+// printed its summary.
+func runPing(ctx context.Context, path, host string) (PingStat, error) {
+	stat := PingStat{Host: host, Loss: 100}
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, pingArgs(host)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return stat, fmt.Errorf("timed out after %s", pingTimeout)
+	}
+
+	loss, rtt, ok := parsePing(stdout.String())
+	if !ok {
+		if msg := firstLine(stderr.String()); msg != "" {
+			return stat, errors.New(msg)
+		}
+		if runErr != nil {
+			return stat, fmt.Errorf("ping failed: %w", runErr)
+		}
+		return stat, fmt.Errorf("unexpected ping output %q", firstLine(stdout.String()))
+	}
+	stat.Loss, stat.RTT = loss, rtt
+	return stat, nil
+}
+
+// pingArgs returns the arguments sending pingCount numeric-only echo
+// requests, waiting at most a second for each reply
+func pingArgs(host string) []string {
+	count := strconv.Itoa(pingCount)
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"-n", count, "-w", "1000", host}
+	case "darwin", "freebsd":
+		// BSD ping takes the reply wait in milliseconds
+		return []string{"-n", "-c", count, "-W", "1000", host}
+	}
+	return []string{"-n", "-c", count, "-W", "1", host}
+}
+
+// parsePing extracts the loss percentage and average round-trip time from
+// ping's summary. ok is false when there is no summary at all.
+func parsePing(output string) (loss, rtt float64, ok bool) {
+	m := pingLoss.FindStringSubmatch(output)
+	if m == nil {
+		return 0, 0, false
+	}
+	loss, _ = strconv.ParseFloat(m[1], 64)
+
+	if m := pingAverage.FindStringSubmatch(output); m != nil {
+		rtt, _ = strconv.ParseFloat(m[1], 64)
+	} else if m := pingAverageWindows.FindStringSubmatch(output); m != nil {
+		rtt, _ = strconv.ParseFloat(m[1], 64)
+	}
+	return loss, rtt, true
+}
@@ -0,0 +1,115 @@
+//go:build darwin
+
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// powermetricsTimeout caps a single powermetrics run, which itself samples
+// for powermetricsWindow
+const (
+	powermetricsTimeout = 3 * time.Second
+	powermetricsWindow  = 250 * time.Millisecond
+)
+
+// powermetricsLine matches a power reading such as "CPU Power: 425 mW",
+// "Combined Power (CPU + GPU + ANE): 435 mW" or, on Intel Macs,
+// "Intel energy model derived package power (CPUs+GT+SA): 1.63W"
+var powermetricsLine = regexp.MustCompile(`(?i)^(.*?) power(?: \([^)]*\))?: ([\d.]+) ?(mW|W)$`)
+
+// powermetricsDomains maps powermetrics' readings to power domains, in the
+// order they are reported
+var powermetricsDomains = []struct {
+	prefix string // Lowercased start of the reading's name
+	domain string
+}{
+	{"combined", "package"},
+	{"package", "package"},
+	{"intel energy model derived package", "package"},
+	{"cpu", "core"},
+	{"gpu", "gpu"},
+	{"ane", "ane"},
+	{"dram", "dram"},
+}
+
+// platformPower reads CPU package, CPU, GPU and Neural Engine power from
+// powermetrics, which only runs as root
+func platformPower(ctx context.Context) ([]PowerStat, error) {
+	path, err := exec.LookPath("powermetrics")
+	if err != nil {
+		return nil, fmt.Errorf("powermetrics not found: %w", err)
+	}
+	if os.Geteuid() != 0 {
+		return nil, fmt.Errorf("powermetrics needs root: %w", os.ErrPermission)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, powermetricsTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, "--samplers", "cpu_power,gpu_power",
+		"--sample-count", "1", "--sample-rate", strconv.Itoa(int(powermetricsWindow.Milliseconds())))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("powermetrics timed out after %s", powermetricsTimeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("powermetrics failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("powermetrics failed: %w", err)
+	}
+
+	return parsePowermetrics(stdout.String()), nil
+}
+
+// parsePowermetrics parses powermetrics' text output. GPU power is listed
+// under both samplers; the first reading of each domain wins.
+func parsePowermetrics(output string) []PowerStat {
+	watts := make(map[string]float64)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		m := powermetricsLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			continue
+		}
+		if m[3] == "mW" {
+			value /= 1000
+		}
+		name := strings.ToLower(m[1])
+		for _, d := range powermetricsDomains {
+			if name == d.prefix || strings.HasPrefix(name, d.prefix+" ") {
+				if _, ok := watts[d.domain]; !ok {
+					watts[d.domain] = value
+				}
+				break
+			}
+		}
+	}
+
+	var power []PowerStat
+	seen := make(map[string]bool)
+	for _, d := range powermetricsDomains {
+		if w, ok := watts[d.domain]; ok && !seen[d.domain] {
+			seen[d.domain] = true
+			power = append(power, PowerStat{Domain: d.domain, Watts: w})
+		}
+	}
+	return power
+}
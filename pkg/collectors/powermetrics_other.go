@@ -0,0 +1,10 @@
+//go:build !darwin
+
+package collectors
+
+import "context"
+
+// platformPower has nothing to add to RAPL outside macOS
+func platformPower(ctx context.Context) ([]PowerStat, error) {
+	return nil, nil
+}
@@ -0,0 +1,256 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// ProcessStat holds usage data for a single process
+type ProcessStat struct {
+	PID     int32
+	Name    string
+	User    string  // Owner's user name, empty if unreadable
+	Command string  // Full command line, empty if unreadable
+	CPU     float64 // Percent of one core since the previous sample
+	Memory  float64 // Resident memory as percent of total
+	RSS     uint64
+
+	// Bytes per second read from and written to storage since the previous
+	// sample; 0 for processes whose I/O counters can't be read
+	ReadPerSec  float64
+	WritePerSec float64
+}
+
+// IOPerSec returns the process's combined storage read and write rate
+func (s ProcessStat) IOPerSec() float64 {
+	return s.ReadPerSec + s.WritePerSec
+}
+
+// ProcessMetrics holds the busiest processes
+type ProcessMetrics struct {
+	Processes  []ProcessStat // Top processes by CPU, then memory and I/O heavy ones
+	Total      int           // Number of processes running
+	LastUpdate time.Time
+}
+
+// processIO holds a process's cumulative storage I/O byte counters
+type processIO struct {
+	read, write uint64
+}
+
+// ProcessCollector collects the top processes by CPU, memory and disk I/O
+type ProcessCollector struct {
+	interval uint
+	limit    int // Processes kept per ranking (CPU, memory and I/O)
+	mu       sync.RWMutex
+	lastData *ProcessMetrics
+
+	// CPU seconds and I/O counters per PID from the previous sample, for
+	// CPU percentages and I/O rates
+	lastTimes  map[int32]float64
+	lastIO     map[int32]processIO
+	lastSample time.Time
+}
+
+// NewProcessCollector creates a new process collector keeping the top limit
+// processes by CPU, the top limit by memory and the top limit by disk I/O
+func NewProcessCollector(interval uint, limit int) *ProcessCollector {
+	return &ProcessCollector{
+		interval:  interval,
+		limit:     limit,
+		lastTimes: make(map[int32]float64),
+		lastIO:    make(map[int32]processIO),
+	}
+}
+
+// Name returns the collector name
+func (c *ProcessCollector) Name() string {
+	return "processes"
+}
+
+// Interval returns the update interval in seconds
+func (c *ProcessCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers process metrics. Processes that exit or can't be read
+// during the scan are skipped; only failing to list processes is an error.
+func (c *ProcessCollector) Collect(ctx context.Context) (interface{}, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	var total uint64
+	if vmem, err := mem.VirtualMemoryWithContext(ctx); err == nil {
+		total = vmem.Total
+	}
+
+	now := time.Now()
+	c.mu.RLock()
+	elapsed := now.Sub(c.lastSample).Seconds()
+	lastTimes := c.lastTimes
+	lastIO := c.lastIO
+	first := c.lastSample.IsZero()
+	c.mu.RUnlock()
+
+	times := make(map[int32]float64, len(procs))
+	ios := make(map[int32]processIO, len(procs))
+	stats := make([]ProcessStat, 0, len(procs))
+	handles := make(map[int32]*process.Process, len(procs))
+	for _, p := range procs {
+		stat := ProcessStat{PID: p.Pid}
+
+		if cpuTimes, err := p.TimesWithContext(ctx); err == nil {
+			busy := cpuTimes.User + cpuTimes.System
+			times[p.Pid] = busy
+			if prev, ok := lastTimes[p.Pid]; ok && elapsed > 0 && busy >= prev {
+				stat.CPU = (busy - prev) / elapsed * 100
+			} else if first {
+				// No previous sample yet: use the lifetime average
+				stat.CPU, _ = p.CPUPercentWithContext(ctx)
+			}
+		}
+
+		if memInfo, err := p.MemoryInfoWithContext(ctx); err == nil {
+			stat.RSS = memInfo.RSS
+			if total > 0 {
+				stat.Memory = float64(memInfo.RSS) / float64(total) * 100
+			}
+		}
+
+		// Bytes that actually hit storage, as iotop shows; other users'
+		// processes need root
+		if counters, err := p.IOCountersWithContext(ctx); err == nil {
+			current := processIO{read: counters.ReadBytes, write: counters.WriteBytes}
+			ios[p.Pid] = current
+			if prev, ok := lastIO[p.Pid]; ok && elapsed > 0 {
+				if current.read >= prev.read {
+					stat.ReadPerSec = float64(current.read-prev.read) / elapsed
+				}
+				if current.write >= prev.write {
+					stat.WritePerSec = float64(current.write-prev.write) / elapsed
+				}
+			}
+		}
+
+		stats = append(stats, stat)
+		handles[p.Pid] = p
+	}
+
+	top := topProcesses(stats, c.limit)
+
+	// Names, owners and command lines only for the processes that are shown
+	for i := range top {
+		p := handles[top[i].PID]
+		if name, err := p.NameWithContext(ctx); err == nil {
+			top[i].Name = name
+		}
+		if user, err := p.UsernameWithContext(ctx); err == nil {
+			top[i].User = user
+		}
+		if cmdline, err := p.CmdlineWithContext(ctx); err == nil {
+			top[i].Command = cmdline
+		}
+	}
+
+	metrics := &ProcessMetrics{
+		Processes:  top,
+		Total:      len(procs),
+		LastUpdate: now,
+	}
+
+	c.mu.Lock()
+	c.lastTimes = times
+	c.lastIO = ios
+	c.lastSample = now
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, nil
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *ProcessCollector) GetLastData() *ProcessMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// topProcesses returns the top limit processes by CPU plus any of the top
+// limit by memory or by disk I/O not already included, ordered by CPU then
+// memory
+func topProcesses(stats []ProcessStat, limit int) []ProcessStat {
+	if limit <= 0 || len(stats) <= limit {
+		limit = len(stats)
+	}
+
+	byMemory := make([]ProcessStat, len(stats))
+	copy(byMemory, stats)
+	sort.SliceStable(byMemory, func(i, j int) bool {
+		return byMemory[i].Memory > byMemory[j].Memory
+	})
+	byIO := make([]ProcessStat, len(stats))
+	copy(byIO, stats)
+	sort.SliceStable(byIO, func(i, j int) bool {
+		return byIO[i].IOPerSec() > byIO[j].IOPerSec()
+	})
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].CPU != stats[j].CPU {
+			return stats[i].CPU > stats[j].CPU
+		}
+		return stats[i].Memory > stats[j].Memory
+	})
+
+	top := make([]ProcessStat, 0, 3*limit)
+	seen := make(map[int32]bool, 3*limit)
+	for _, stat := range stats[:limit] {
+		top = append(top, stat)
+		seen[stat.PID] = true
+	}
+	for _, stat := range byMemory[:limit] {
+		if !seen[stat.PID] {
+			top = append(top, stat)
+			seen[stat.PID] = true
+		}
+	}
+	for _, stat := range byIO[:limit] {
+		// Idle processes are already covered by the other rankings
+		if !seen[stat.PID] && stat.IOPerSec() > 0 {
+			top = append(top, stat)
+			seen[stat.PID] = true
+		}
+	}
+
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].CPU != top[j].CPU {
+			return top[i].CPU > top[j].CPU
+		}
+		return top[i].Memory > top[j].Memory
+	})
+	return top
+}
+
+// SignalProcess asks the process with the given PID to terminate (SIGTERM),
+// or kills it outright (SIGKILL) when force is set. Windows has no signals,
+// so both end the process there. The name guards against the PID having
+// been reused since the process list was collected.
+func SignalProcess(pid int32, name string, force bool) error {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+	if current, err := p.Name(); err == nil && current != name {
+		return fmt.Errorf("PID %d is now %s, not %s", pid, current, name)
+	}
+	if force {
+		return p.Kill()
+	}
+	return p.Terminate()
+}
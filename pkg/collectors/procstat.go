@@ -0,0 +1,90 @@
+package collectors
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// procStat holds the kernel's system-wide activity counters
+const procStat = "/proc/stat"
+
+// KernelActivity holds system-wide kernel activity rates
+type KernelActivity struct {
+	ContextSwitchesPerSec float64
+	InterruptsPerSec      float64
+	ForksPerSec           float64 // Processes and threads created
+}
+
+// kernelCounters holds the /proc/stat counters KernelActivity is derived from
+type kernelCounters struct {
+	ctxt      uint64
+	intr      uint64
+	processes uint64
+}
+
+// readKernelCounters reads the context switch, interrupt and fork counters.
+// It returns false on other platforms.
+func readKernelCounters() (kernelCounters, bool) {
+	file, err := os.Open(procStat)
+	if err != nil {
+		return kernelCounters{}, false
+	}
+	defer file.Close()
+	return parseKernelCounters(file)
+}
+
+// parseKernelCounters picks the counters out of /proc/stat, e.g.
+//
+//	intr 1462898527 9 0 0 ...
+//	ctxt 2845327331
+//	btime 1718026812
+//	processes 3624417
+//
+// The first intr field is the total over every interrupt line.
+func parseKernelCounters(r io.Reader) (kernelCounters, bool) {
+	var counters kernelCounters
+	found := 0
+
+	scanner := bufio.NewScanner(r)
+	// The intr line lists every interrupt line and can run past the
+	// scanner's default 64 KiB on large machines
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		var target *uint64
+		switch fields[0] {
+		case "ctxt":
+			target = &counters.ctxt
+		case "intr":
+			target = &counters.intr
+		case "processes":
+			target = &counters.processes
+		default:
+			continue
+		}
+		if value, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			*target = value
+			found++
+		}
+	}
+	return counters, found == 3
+}
+
+// activitySince turns two counter samples into per-second rates. Counters
+// that went back are treated as reset and yield false.
+func activitySince(prev, cur kernelCounters, elapsed float64) (KernelActivity, bool) {
+	if elapsed <= 0 || cur.ctxt < prev.ctxt || cur.intr < prev.intr || cur.processes < prev.processes {
+		return KernelActivity{}, false
+	}
+	return KernelActivity{
+		ContextSwitchesPerSec: float64(cur.ctxt-prev.ctxt) / elapsed,
+		InterruptsPerSec:      float64(cur.intr-prev.intr) / elapsed,
+		ForksPerSec:           float64(cur.processes-prev.processes) / elapsed,
+	}, true
+}
@@ -0,0 +1,106 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// powercapPath holds the RAPL (Running Average Power Limit) energy counters
+// of Intel and, since Linux 5.8, AMD CPUs
+const powercapPath = "/sys/class/powercap"
+
+// raplDomains is the order power domains are reported in
+var raplDomains = []string{"package", "core", "uncore", "dram", "psys"}
+
+// PowerStat is the average power draw of one RAPL domain since the previous
+// sample, summed over all CPU packages. On macOS it is powermetrics' reading,
+// which adds the "gpu" and "ane" (Neural Engine) domains.
+type PowerStat struct {
+	Domain string // "package", "core", "uncore", "dram", "psys", "gpu" or "ane"
+	Watts  float64
+}
+
+// raplZone is one powercap zone, e.g. intel-rapl:0 (package-0) or
+// intel-rapl:0:2 (dram)
+type raplZone struct {
+	path     string
+	domain   string
+	maxRange uint64 // Counter value at which energy_uj wraps to 0
+}
+
+// raplSample is a zone's energy counter at one point in time
+type raplSample struct {
+	uj uint64
+	at time.Time
+}
+
+// findRAPLZones lists the RAPL zones under /sys/class/powercap. The
+// intel-rapl-mmio zones duplicate the package counters and are skipped.
+func findRAPLZones() []raplZone {
+	paths, _ := filepath.Glob(filepath.Join(powercapPath, "intel-rapl:*"))
+	var zones []raplZone
+	for _, path := range paths {
+		name := readSysfsString(path + "/name")
+		if name == "" {
+			continue
+		}
+		// Packages are named package-0, package-1, ...
+		domain, _, _ := strings.Cut(name, "-")
+		maxRange, _ := strconv.ParseUint(readSysfsString(path+"/max_energy_range_uj"), 10, 64)
+		zones = append(zones, raplZone{path: path, domain: domain, maxRange: maxRange})
+	}
+	return zones
+}
+
+// readRAPLPower reads every zone's energy counter and returns the power
+// drawn per domain since the previous samples, along with the new samples.
+// energy_uj is only readable by root since Linux 5.10, so without it there
+// is no power data and the result is nil.
+func readRAPLPower(zones []raplZone, prev map[string]raplSample, now time.Time) ([]PowerStat, map[string]raplSample) {
+	samples := make(map[string]raplSample, len(zones))
+	watts := make(map[string]float64)
+	for _, zone := range zones {
+		uj, err := strconv.ParseUint(readSysfsString(zone.path+"/energy_uj"), 10, 64)
+		if err != nil {
+			continue
+		}
+		samples[zone.path] = raplSample{uj: uj, at: now}
+
+		last, ok := prev[zone.path]
+		if !ok {
+			continue
+		}
+		elapsed := now.Sub(last.at).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+		delta := uj - last.uj
+		if uj < last.uj {
+			if zone.maxRange == 0 {
+				continue
+			}
+			delta = zone.maxRange - last.uj + uj
+		}
+		watts[zone.domain] += float64(delta) / 1e6 / elapsed
+	}
+
+	var power []PowerStat
+	for _, domain := range raplDomains {
+		if w, ok := watts[domain]; ok {
+			power = append(power, PowerStat{Domain: domain, Watts: w})
+		}
+	}
+	return power, samples
+}
+
+// raplReadError returns why the first zone's energy counter can't be read
+func raplReadError(zones []raplZone) error {
+	if len(zones) == 0 {
+		return nil
+	}
+	_, err := os.ReadFile(zones[0].path + "/energy_uj")
+	return err
+}
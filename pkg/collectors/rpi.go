@@ -0,0 +1,192 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+const (
+	// deviceTreeModel names the board on ARM systems, e.g.
+	// "Raspberry Pi 4 Model B Rev 1.4"
+	deviceTreeModel = "/proc/device-tree/model"
+
+	// rpiThrottledPath exposes the firmware's throttled bitmask without
+	// vcgencmd on kernels with the raspberrypi firmware driver
+	rpiThrottledPath = "/sys/devices/platform/soc/soc:firmware/get_throttled"
+
+	// vcgencmdTimeout caps a single vcgencmd call
+	vcgencmdTimeout = 2 * time.Second
+)
+
+// PiMetrics holds Raspberry Pi SoC telemetry. Readings that aren't known
+// are zero.
+type PiMetrics struct {
+	Model        string  // Board model; empty when this isn't a Raspberry Pi
+	SoCTemp      float64 // °C
+	CoreVolts    float64 // Needs vcgencmd
+	ARMClock     uint64  // Hz
+	Throttled    uint32  // Firmware get_throttled bitmask
+	HasThrottled bool    // Throttled could be read
+	LastUpdate   time.Time
+}
+
+// PiCollector reads the SoC temperature, core voltage, ARM clock and the
+// firmware's under-voltage and throttling flags of a Raspberry Pi, from
+// vcgencmd when installed and from sysfs otherwise. Other machines get an
+// empty result.
+type PiCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *PiMetrics
+}
+
+// NewPiCollector creates a new Raspberry Pi collector
+func NewPiCollector(interval uint) *PiCollector {
+	return &PiCollector{
+		interval: interval,
+	}
+}
+
+// Name returns the collector name
+func (c *PiCollector) Name() string {
+	return "rpi"
+}
+
+// Interval returns the update interval in seconds
+func (c *PiCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers Raspberry Pi SoC telemetry
+func (c *PiCollector) Collect(ctx context.Context) (interface{}, error) {
+	metrics := &PiMetrics{LastUpdate: time.Now()}
+
+	// The device tree strings are NUL-terminated
+	model := strings.TrimRight(readSysfsString(deviceTreeModel), "\x00")
+	if !strings.HasPrefix(model, "Raspberry Pi") {
+		c.mu.Lock()
+		c.lastData = metrics
+		c.mu.Unlock()
+		return metrics, nil
+	}
+	metrics.Model = model
+
+	failed := make(map[string]error)
+	if path, err := exec.LookPath("vcgencmd"); err == nil {
+		if err := readVcgencmd(ctx, path, metrics); err != nil {
+			failed["vcgencmd"] = err
+		}
+	}
+
+	// Fall back to sysfs for whatever vcgencmd didn't provide
+	if metrics.SoCTemp == 0 {
+		if milli, err := strconv.ParseFloat(readSysfsString("/sys/class/thermal/thermal_zone0/temp"), 64); err == nil {
+			metrics.SoCTemp = milli / 1000
+		}
+	}
+	if metrics.ARMClock == 0 {
+		if khz, err := strconv.ParseUint(readSysfsString("/sys/devices/system/cpu/cpu0/cpufreq/scaling_cur_freq"), 10, 64); err == nil {
+			metrics.ARMClock = khz * 1000
+		}
+	}
+	if !metrics.HasThrottled {
+		if flags, err := parseThrottled(readSysfsString(rpiThrottledPath)); err == nil {
+			metrics.Throttled = flags
+			metrics.HasThrottled = true
+		}
+	}
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, data.NewPartialError(failed)
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *PiCollector) GetLastData() *PiMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// readVcgencmd fills in the readings vcgencmd reports, e.g. "temp=48.3'C",
+// "volt=0.8600V", "frequency(48)=1500345728" and "throttled=0x50005".
+// It stops at the first failure, usually no access to /dev/vchiq.
+func readVcgencmd(ctx context.Context, path string, metrics *PiMetrics) error {
+	temp, err := runVcgencmd(ctx, path, "measure_temp")
+	if err != nil {
+		return err
+	}
+	if celsius, err := strconv.ParseFloat(strings.TrimSuffix(temp, "'C"), 64); err == nil {
+		metrics.SoCTemp = celsius
+	}
+
+	volts, err := runVcgencmd(ctx, path, "measure_volts", "core")
+	if err != nil {
+		return err
+	}
+	if v, err := strconv.ParseFloat(strings.TrimSuffix(volts, "V"), 64); err == nil {
+		metrics.CoreVolts = v
+	}
+
+	clock, err := runVcgencmd(ctx, path, "measure_clock", "arm")
+	if err != nil {
+		return err
+	}
+	if hz, err := strconv.ParseUint(clock, 10, 64); err == nil {
+		metrics.ARMClock = hz
+	}
+
+	throttled, err := runVcgencmd(ctx, path, "get_throttled")
+	if err != nil {
+		return err
+	}
+	if flags, err := parseThrottled(throttled); err == nil {
+		metrics.Throttled = flags
+		metrics.HasThrottled = true
+	}
+	return nil
+}
+
+// runVcgencmd runs one vcgencmd command and returns the value after "="
+func runVcgencmd(ctx context.Context, path string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, vcgencmdTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("vcgencmd %s timed out after %s", args[0], vcgencmdTimeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return "", fmt.Errorf("vcgencmd %s failed: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("vcgencmd %s failed: %w", args[0], err)
+	}
+
+	_, value, ok := strings.Cut(firstLine(stdout.String()), "=")
+	if !ok {
+		return "", fmt.Errorf("unexpected vcgencmd %s output %q", args[0], firstLine(stdout.String()))
+	}
+	return value, nil
+}
+
+// parseThrottled parses the throttled bitmask, printed in hex with or
+// without a 0x prefix
+func parseThrottled(s string) (uint32, error) {
+	flags, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 32)
+	return uint32(flags), err
+}
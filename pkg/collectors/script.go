@@ -0,0 +1,195 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxScriptOutput caps how much script output is read and parsed
+const maxScriptOutput = 4096
+
+// ScriptConfig describes an external command that produces a single metric
+type ScriptConfig struct {
+	Name     string
+	Command  string
+	Interval uint // in seconds
+	Unit     string
+	Warning  float64
+	Critical float64
+}
+
+// ScriptMetrics holds the latest value reported by a script
+type ScriptMetrics struct {
+	Name       string
+	Value      float64
+	Unit       string
+	Warning    float64
+	Critical   float64
+	LastUpdate time.Time
+}
+
+// ScriptCollector runs an external command and parses its numeric output
+type ScriptCollector struct {
+	config   ScriptConfig
+	timeout  time.Duration
+	mu       sync.RWMutex
+	lastData *ScriptMetrics
+}
+
+// NewScriptCollector creates a new script collector
+func NewScriptCollector(config ScriptConfig) *ScriptCollector {
+	if config.Interval == 0 {
+		config.Interval = 10
+	}
+
+	// A run may never outlast its interval, and never more than 10 seconds
+	timeout := time.Duration(config.Interval) * time.Second
+	if timeout > 10*time.Second {
+		timeout = 10 * time.Second
+	}
+
+	return &ScriptCollector{
+		config:  config,
+		timeout: timeout,
+	}
+}
+
+// Name returns the collector name
+func (c *ScriptCollector) Name() string {
+	return ScriptCollectorName(c.config.Name)
+}
+
+// Interval returns the update interval in seconds
+func (c *ScriptCollector) Interval() uint {
+	return c.config.Interval
+}
+
+// Collect runs the command and parses its output
+func (c *ScriptCollector) Collect(ctx context.Context) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, c.config.Command)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &limitedBuffer{buf: &stdout, limit: maxScriptOutput}
+	cmd.Stderr = &limitedBuffer{buf: &stderr, limit: maxScriptOutput}
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("script %q timed out after %s", c.config.Name, c.timeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("script %q failed: %w: %s", c.config.Name, err, msg)
+		}
+		return nil, fmt.Errorf("script %q failed: %w", c.config.Name, err)
+	}
+
+	value, unit, err := parseScriptOutput(stdout.String())
+	if err != nil {
+		return nil, fmt.Errorf("script %q: %w", c.config.Name, err)
+	}
+	if unit == "" {
+		unit = c.config.Unit
+	}
+
+	metrics := &ScriptMetrics{
+		Name:       c.config.Name,
+		Value:      value,
+		Unit:       unit,
+		Warning:    c.config.Warning,
+		Critical:   c.config.Critical,
+		LastUpdate: time.Now(),
+	}
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, nil
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *ScriptCollector) GetLastData() *ScriptMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// ScriptCollectorName returns the aggregator key for a script metric
+func ScriptCollectorName(name string) string {
+	return "script:" + name
+}
+
+// shellCommand builds a command that runs through the platform shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return newCommand(ctx, "cmd", "/C", command)
+	}
+	return newCommand(ctx, "sh", "-c", command)
+}
+
+// parseScriptOutput accepts either a bare number or a JSON object of the
+// form {"value": 42.5, "unit": "ms"}; the unit is optional
+func parseScriptOutput(output string) (float64, string, error) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return 0, "", fmt.Errorf("no output")
+	}
+
+	if strings.HasPrefix(output, "{") {
+		var parsed struct {
+			Value *float64 `json:"value"`
+			Unit  string   `json:"unit"`
+		}
+		if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+			return 0, "", fmt.Errorf("invalid JSON output: %w", err)
+		}
+		if parsed.Value == nil {
+			return 0, "", fmt.Errorf("JSON output has no \"value\" field")
+		}
+		return *parsed.Value, parsed.Unit, nil
+	}
+
+	value, err := strconv.ParseFloat(firstLine(output), 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("output is not a number: %q", firstLine(output))
+	}
+	return value, "", nil
+}
+
+// firstLine returns the first non-empty trimmed line of s
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// limitedBuffer discards writes beyond its limit so a chatty script
+// can't grow memory without bound
+type limitedBuffer struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+// Write implements io.Writer
+func (l *limitedBuffer) Write(p []byte) (int, error) {
+	if room := l.limit - l.buf.Len(); room > 0 {
+		if len(p) > room {
+			l.buf.Write(p[:room])
+		} else {
+			l.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package collectors
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScriptCollectorTimeoutWithGrandchild(t *testing.T) {
+	c := NewScriptCollector(ScriptConfig{Name: "slow", Command: "sleep 60 | cat"})
+	c.timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := c.Collect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Collect error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Collect took %s after a %s timeout", elapsed, c.timeout)
+	}
+}
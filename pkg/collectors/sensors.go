@@ -2,6 +2,7 @@ package collectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ctcac00/metrics-tui/internal/data"
 	"github.com/shirou/gopsutil/v4/sensors"
 )
 
@@ -19,11 +21,14 @@ type FanStat struct {
 	RPM  uint64
 }
 
-// SensorMetrics holds sensor data (temperatures and fans)
+// SensorMetrics holds sensor data (temperatures, fans and, on servers
+// with IPMI, power supplies)
 type SensorMetrics struct {
-	Temperatures []sensors.TemperatureStat
-	Fans         []FanStat
-	LastUpdate   time.Time
+	Temperatures  []sensors.TemperatureStat
+	Fans          []FanStat
+	PowerSupplies []PSUStat
+	CoreTemps     map[int]float64 // Logical CPU -> temperature of its core (°C), where there are per-core sensors
+	LastUpdate    time.Time
 }
 
 // SensorsCollector collects sensor metrics
@@ -31,12 +36,22 @@ type SensorsCollector struct {
 	interval uint
 	mu       sync.RWMutex
 	lastData *SensorMetrics
+
+	// Guards smartCache separately, so a slow smartctl doesn't hold up
+	// GetLastData
+	smartMu    sync.Mutex
+	smartCache map[string]smartTempSample // Last SMART temperature per disk
+
+	// Guards ipmiCache the same way for slow BMCs
+	ipmiMu    sync.Mutex
+	ipmiCache ipmiSample
 }
 
 // NewSensorsCollector creates a new sensors collector
 func NewSensorsCollector(interval uint) *SensorsCollector {
 	return &SensorsCollector{
-		interval: interval,
+		interval:   interval,
+		smartCache: make(map[string]smartTempSample),
 	}
 }
 
@@ -52,32 +67,60 @@ func (c *SensorsCollector) Interval() uint {
 
 // Collect gathers sensor metrics
 func (c *SensorsCollector) Collect(ctx context.Context) (interface{}, error) {
-	temps, err := sensors.SensorsTemperatures()
+	// gopsutil reports unreadable sensors as warnings next to the ones it
+	// could read; only give up when nothing was read at all
+	failed := make(map[string]error)
+	temps, err := readTemperatures()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get temperature sensors: %w", err)
+		var warnings *sensors.Warnings
+		if !errors.As(err, &warnings) || len(temps) == 0 {
+			return nil, fmt.Errorf("failed to get temperature sensors: %w", err)
+		}
+		failed["temperature sensors"] = err
 	}
 
-	// Filter to only the most useful temperature sensors
-	filteredTemps := filterUsefulTemperatures(temps)
+	// Filter to only the most useful temperature sensors. NVMe, SATA and
+	// SAS drives are read separately so that each one can be told apart.
+	filteredTemps := platformTemperatures(temps)
+	filteredTemps = append(filteredTemps, nvmeTemperatures()...)
+	driveTemps, driveErrs := c.driveTemperatures(ctx)
+	filteredTemps = append(filteredTemps, driveTemps...)
+	for name, err := range driveErrs {
+		failed[name] = err
+	}
 
-	// Collect fan speeds from hwmon
-	fans, err := collectFanSpeeds()
+	// Collect fan speeds from hwmon, or the SMC on macOS
+	fans, err := platformFanSpeeds()
 	if err != nil {
-		// Don't fail entirely if fans can't be read, just log it
+		// Don't fail entirely if fans can't be read; a missing hwmon
+		// directory or SMC key just means there are no fans to report
 		fans = nil
+		if !errors.Is(err, os.ErrNotExist) {
+			failed["fans"] = err
+		}
+	}
+
+	// Chassis, PSU and fan bank sensors from the BMC on IPMI servers
+	ipmi := c.ipmiSensors(ctx)
+	if ipmi.err != nil {
+		failed["IPMI"] = ipmi.err
 	}
+	filteredTemps = append(filteredTemps, ipmi.temps...)
+	fans = append(fans, ipmi.fans...)
 
 	metrics := &SensorMetrics{
-		Temperatures: filteredTemps,
-		Fans:         fans,
-		LastUpdate:   time.Now(),
+		Temperatures:  filteredTemps,
+		Fans:          fans,
+		PowerSupplies: ipmi.psus,
+		CoreTemps:     platformCoreTemperatures(temps),
+		LastUpdate:    time.Now(),
 	}
 
 	c.mu.Lock()
 	c.lastData = metrics
 	c.mu.Unlock()
 
-	return metrics, nil
+	return metrics, data.NewPartialError(failed)
 }
 
 // filterUsefulTemperatures selects the most useful temperature sensors
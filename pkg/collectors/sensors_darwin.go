@@ -0,0 +1,119 @@
+//go:build darwin
+
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+// appleSensorGroups maps the name prefixes of Apple Silicon HID temperature
+// sensors to the sensor keys they're reported under. Each chip exposes a
+// few dozen sensors; every key shows the hottest of its group.
+var appleSensorGroups = []struct {
+	prefix string
+	key    string
+}{
+	{"pACC MTR Temp", "cpu_performance_cores"},
+	{"eACC MTR Temp", "cpu_efficiency_cores"},
+	{"PMU tdie", "cpu_die"},
+	{"PMU2 tdie", "cpu_die"},
+	{"GPU MTR Temp", "gpu"},
+	{"SOC MTR Temp", "soc"},
+	{"ANE MTR Temp", "ane"},
+	{"NAND", "ssd"},
+	{"gas gauge battery", "battery"},
+}
+
+// intelMacSensors names the SMC keys gopsutil reads on Intel Macs
+var intelMacSensors = []struct {
+	smcKey string
+	key    string
+}{
+	{"TC0D", "cpu_diode"},
+	{"TC0P", "cpu_proximity"},
+	{"TC0H", "cpu_heatsink"},
+	{"TG0D", "gpu_diode"},
+	{"TG0P", "gpu_proximity"},
+	{"TG0H", "gpu_heatsink"},
+	{"TM0P", "memory_proximity"},
+	{"TA0P", "ambient"},
+}
+
+// readTemperatures reads the Apple Silicon HID sensors or, on Intel Macs,
+// the SMC temperature keys through gopsutil
+func readTemperatures() ([]sensors.TemperatureStat, error) {
+	return sensors.SensorsTemperatures()
+}
+
+// platformTemperatures picks and names the useful sensors among the Apple
+// Silicon HID sensors or Intel Mac SMC keys that gopsutil returns
+func platformTemperatures(temps []sensors.TemperatureStat) []sensors.TemperatureStat {
+	hottest := make(map[string]float64)
+	for _, temp := range temps {
+		// Missing SMC keys read as 0 and idle HID sensors can report junk
+		if temp.Temperature <= 0 || temp.Temperature > 150 {
+			continue
+		}
+		for _, group := range appleSensorGroups {
+			if strings.HasPrefix(temp.SensorKey, group.prefix) {
+				hottest[group.key] = max(hottest[group.key], temp.Temperature)
+				break
+			}
+		}
+		for _, sensor := range intelMacSensors {
+			if temp.SensorKey == sensor.smcKey {
+				hottest[sensor.key] = temp.Temperature
+				break
+			}
+		}
+	}
+
+	var result []sensors.TemperatureStat
+	seen := make(map[string]bool)
+	add := func(key string) {
+		if temp, ok := hottest[key]; ok && !seen[key] {
+			seen[key] = true
+			result = append(result, sensors.TemperatureStat{SensorKey: key, Temperature: temp})
+		}
+	}
+	for _, group := range appleSensorGroups {
+		add(group.key)
+	}
+	for _, sensor := range intelMacSensors {
+		add(sensor.key)
+	}
+	return result
+}
+
+// platformCoreTemperatures reports none; Macs have no per-core sensors
+func platformCoreTemperatures(temps []sensors.TemperatureStat) map[int]float64 {
+	return nil
+}
+
+// platformFanSpeeds reads the fan speeds from the SMC. Macs without fans,
+// like the MacBook Air, have no FNum key and report no fans.
+func platformFanSpeeds() ([]FanStat, error) {
+	smc, err := openSMC()
+	if err != nil {
+		return nil, err
+	}
+	defer smc.close()
+
+	count, err := smc.readNumber("FNum")
+	if err != nil {
+		return nil, err
+	}
+
+	var fans []FanStat
+	for i := 0; i < int(count); i++ {
+		rpm, err := smc.readNumber(fmt.Sprintf("F%dAc", i))
+		if err != nil {
+			continue
+		}
+		fans = append(fans, FanStat{Name: fmt.Sprintf("Fan %d", i+1), RPM: uint64(max(rpm, 0))})
+	}
+	return fans, nil
+}
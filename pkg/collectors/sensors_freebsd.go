@@ -0,0 +1,108 @@
+//go:build freebsd
+
+package collectors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+// FreeBSD reports temperatures in tenths of a kelvin from 0°C = 2731
+// (TZ_ZEROC)
+const deciKelvinZero = 2731
+
+// readTemperatures reads the ACPI thermal zones (hw.acpi.thermal.tzN) and
+// the per-core sensors of coretemp(4) or amdtemp(4) (dev.cpu.N.temperature),
+// as gopsutil has no FreeBSD backend
+func readTemperatures() ([]sensors.TemperatureStat, error) {
+	var temps []sensors.TemperatureStat
+
+	for zone := 0; ; zone++ {
+		prefix := fmt.Sprintf("hw.acpi.thermal.tz%d.", zone)
+		temp, exists := sysctlCelsius(prefix + "temperature")
+		if !exists {
+			break
+		}
+		if temp == nil {
+			continue
+		}
+		critical, _ := sysctlCelsius(prefix + "_CRT")
+		high, _ := sysctlCelsius(prefix + "_HOT")
+		stat := sensors.TemperatureStat{
+			SensorKey:   fmt.Sprintf("acpitz_tz%d", zone),
+			Temperature: *temp,
+		}
+		if high != nil {
+			stat.High = *high
+		}
+		if critical != nil {
+			stat.Critical = *critical
+		}
+		temps = append(temps, stat)
+	}
+
+	// dev.cpu.N.temperature only exists with the coretemp or amdtemp module
+	// loaded; AMD reports one reading per package on every core's node
+	cpus, err := syscall.SysctlUint32("hw.ncpu")
+	if err != nil {
+		return temps, nil
+	}
+	for cpu := 0; cpu < int(cpus); cpu++ {
+		temp, exists := sysctlCelsius(fmt.Sprintf("dev.cpu.%d.temperature", cpu))
+		if !exists {
+			break
+		}
+		if temp != nil {
+			temps = append(temps, sensors.TemperatureStat{
+				SensorKey:   fmt.Sprintf("cpu_core_%d", cpu),
+				Temperature: *temp,
+			})
+		}
+	}
+	return temps, nil
+}
+
+// sysctlCelsius reads a temperature sysctl in tenths of a kelvin. exists
+// is false when there is no such sysctl; the temperature is nil when the
+// sensor has no valid reading, which FreeBSD reports as -1.
+func sysctlCelsius(name string) (temp *float64, exists bool) {
+	value, err := syscall.SysctlUint32(name)
+	if err != nil {
+		return nil, false
+	}
+	if int32(value) <= 0 {
+		return nil, true
+	}
+	celsius := float64(int32(value)-deciKelvinZero) / 10
+	return &celsius, true
+}
+
+// platformTemperatures picks the useful sensors
+func platformTemperatures(temps []sensors.TemperatureStat) []sensors.TemperatureStat {
+	return filterUsefulTemperatures(temps)
+}
+
+// platformCoreTemperatures maps logical CPUs to the dev.cpu.N readings,
+// which readTemperatures keyed cpu_core_N
+func platformCoreTemperatures(temps []sensors.TemperatureStat) map[int]float64 {
+	coreTemps := make(map[int]float64)
+	for _, temp := range temps {
+		cpu, err := strconv.Atoi(strings.TrimPrefix(temp.SensorKey, "cpu_core_"))
+		if err == nil && strings.HasPrefix(temp.SensorKey, "cpu_core_") {
+			coreTemps[cpu] = temp.Temperature
+		}
+	}
+	if len(coreTemps) == 0 {
+		return nil
+	}
+	return coreTemps
+}
+
+// platformFanSpeeds reports no fans; FreeBSD has no generic fan interface
+func platformFanSpeeds() ([]FanStat, error) {
+	return nil, nil
+}
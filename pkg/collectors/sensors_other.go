@@ -0,0 +1,27 @@
+//go:build !darwin && !freebsd
+
+package collectors
+
+import "github.com/shirou/gopsutil/v4/sensors"
+
+// readTemperatures reads every temperature sensor through gopsutil
+func readTemperatures() ([]sensors.TemperatureStat, error) {
+	return sensors.SensorsTemperatures()
+}
+
+// platformTemperatures picks the useful sensors among the ones gopsutil
+// returns from hwmon
+func platformTemperatures(temps []sensors.TemperatureStat) []sensors.TemperatureStat {
+	return filterUsefulTemperatures(temps)
+}
+
+// platformCoreTemperatures maps logical CPUs to their core's temperature
+// from the coretemp or k10temp hwmon sensors
+func platformCoreTemperatures(temps []sensors.TemperatureStat) map[int]float64 {
+	return hwmonCoreTemperatures()
+}
+
+// platformFanSpeeds reads fan speeds from hwmon
+func platformFanSpeeds() ([]FanStat, error) {
+	return collectFanSpeeds()
+}
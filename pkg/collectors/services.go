@@ -0,0 +1,241 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// busctlTimeout caps a single systemd D-Bus call through busctl
+const busctlTimeout = 3 * time.Second
+
+// ServiceStat holds the state and resource usage of a systemd unit
+type ServiceStat struct {
+	Name        string // Unit name, e.g. "nginx.service"
+	Description string
+	ActiveState string  // "active", "failed", "activating", ...
+	SubState    string  // "running", "exited", "dead", ...
+	CPU         float64 // Percent of one core since the previous sample
+	Memory      uint64  // Bytes charged to the unit's cgroup
+}
+
+// ServiceMetrics holds the failed systemd units and the running services
+type ServiceMetrics struct {
+	Services   []ServiceStat // Failed units first, then services by CPU
+	Failed     int           // Units of any type in the failed state
+	LastUpdate time.Time
+}
+
+// ServiceCollector lists systemd units through the systemd D-Bus API
+// (org.freedesktop.systemd1.Manager.ListUnits, called with busctl) and reads
+// the CPU and memory usage of system services from their cgroup v2
+// directories under system.slice. Failed units of every type are kept so
+// that e.g. a failed mount or timer still shows up.
+type ServiceCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *ServiceMetrics
+
+	// CPU time per unit from the previous sample
+	lastSamples map[string]serviceSample
+}
+
+// serviceSample is a unit's cumulative CPU time at one point in time
+type serviceSample struct {
+	usec uint64
+	at   time.Time
+}
+
+// NewServiceCollector creates a new systemd service collector
+func NewServiceCollector(interval uint) *ServiceCollector {
+	return &ServiceCollector{
+		interval:    interval,
+		lastSamples: make(map[string]serviceSample),
+	}
+}
+
+// Name returns the collector name
+func (c *ServiceCollector) Name() string {
+	return "services"
+}
+
+// Interval returns the update interval in seconds
+func (c *ServiceCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect gathers systemd unit states and per-service usage
+func (c *ServiceCollector) Collect(ctx context.Context) (interface{}, error) {
+	units, err := listSystemdUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	prev := c.lastSamples
+	c.mu.RUnlock()
+
+	now := time.Now()
+	cgroups := serviceCgroups()
+	samples := make(map[string]serviceSample)
+	metrics := &ServiceMetrics{LastUpdate: now}
+
+	for _, unit := range units {
+		failed := unit.ActiveState == "failed"
+		if failed {
+			metrics.Failed++
+		}
+		if !failed && (!strings.HasSuffix(unit.Name, ".service") || unit.ActiveState == "inactive") {
+			continue
+		}
+
+		if cgroup, ok := cgroups[unit.Name]; ok {
+			if usec, ok := readKeyedValue(cgroup+"/cpu.stat", "usage_usec"); ok {
+				sample := serviceSample{usec: usec, at: now}
+				if last, ok := prev[unit.Name]; ok && usec >= last.usec {
+					if elapsed := now.Sub(last.at).Microseconds(); elapsed > 0 {
+						unit.CPU = float64(usec-last.usec) / float64(elapsed) * 100
+					}
+				}
+				samples[unit.Name] = sample
+			}
+			if current, err := strconv.ParseUint(readSysfsString(cgroup+"/memory.current"), 10, 64); err == nil {
+				unit.Memory = current
+			}
+		}
+		metrics.Services = append(metrics.Services, unit)
+	}
+
+	sort.SliceStable(metrics.Services, func(i, j int) bool {
+		a, b := metrics.Services[i], metrics.Services[j]
+		if aFailed, bFailed := a.ActiveState == "failed", b.ActiveState == "failed"; aFailed != bFailed {
+			return aFailed
+		}
+		if a.CPU != b.CPU {
+			return a.CPU > b.CPU
+		}
+		return a.Name < b.Name
+	})
+
+	c.mu.Lock()
+	c.lastSamples = samples
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, nil
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *ServiceCollector) GetLastData() *ServiceMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// listSystemdUnits calls ListUnits on the systemd manager over the system
+// bus. busctl prints the reply as
+//
+//	{"type":"a(ssssssouso)","data":[[["nginx.service","A web server","loaded","active","running","","/org/...",0,"","/"],...]]}
+//
+// where each unit is name, description, load state, active state, sub
+// state, followed unit, object path and queued job.
+func listSystemdUnits(ctx context.Context) ([]ServiceStat, error) {
+	path, err := exec.LookPath("busctl")
+	if err != nil {
+		return nil, fmt.Errorf("busctl not found (needs systemd): %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, busctlTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, "--system", "--json=short", "call",
+		"org.freedesktop.systemd1", "/org/freedesktop/systemd1",
+		"org.freedesktop.systemd1.Manager", "ListUnits",
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("systemd ListUnits timed out after %s", busctlTimeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("systemd ListUnits failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("systemd ListUnits failed: %w", err)
+	}
+
+	return parseListUnits(stdout.Bytes())
+}
+
+// parseListUnits parses busctl's JSON reply to ListUnits
+func parseListUnits(output []byte) ([]ServiceStat, error) {
+	var reply struct {
+		Data [][][]any `json:"data"`
+	}
+	if err := json.Unmarshal(output, &reply); err != nil {
+		return nil, fmt.Errorf("failed to parse systemd units: %w", err)
+	}
+	if len(reply.Data) == 0 {
+		return nil, nil
+	}
+
+	units := make([]ServiceStat, 0, len(reply.Data[0]))
+	for _, fields := range reply.Data[0] {
+		if len(fields) < 5 {
+			continue
+		}
+		var strs [5]string
+		for i := range strs {
+			strs[i], _ = fields[i].(string)
+		}
+		// Missing units are only listed because another unit references them
+		if strs[0] == "" || strs[2] == "not-found" {
+			continue
+		}
+		units = append(units, ServiceStat{
+			Name:        strs[0],
+			Description: strs[1],
+			ActiveState: strs[3],
+			SubState:    strs[4],
+		})
+	}
+	return units, nil
+}
+
+// serviceCgroups maps the system services to their cgroup v2 directories,
+// e.g. "getty@tty1.service" to /sys/fs/cgroup/system.slice/system-getty.slice/getty@tty1.service
+func serviceCgroups() map[string]string {
+	cgroups := make(map[string]string)
+	var walk func(dir string)
+	walk = func(dir string) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			name := entry.Name()
+			switch {
+			case strings.HasSuffix(name, ".service"):
+				cgroups[name] = filepath.Join(dir, name)
+			case strings.HasSuffix(name, ".slice"):
+				walk(filepath.Join(dir, name))
+			}
+		}
+	}
+	walk(filepath.Join(cgroupRoot(), "system.slice"))
+	return cgroups
+}
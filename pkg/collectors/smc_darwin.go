@@ -0,0 +1,154 @@
+//go:build darwin
+
+package collectors
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// iokitPath is loaded at runtime through purego so the build needs no cgo
+const iokitPath = "/System/Library/Frameworks/IOKit.framework/IOKit"
+
+// AppleSMC user client selectors and results
+const (
+	smcHandleYPCEvent = 2
+	smcReadKey        = 5
+	smcGetKeyInfo     = 9
+	smcKeyNotFound    = 132
+)
+
+// smcKeyData mirrors the AppleSMC driver's SMCKeyData_t (80 bytes)
+type smcKeyData struct {
+	key  uint32
+	vers struct {
+		major, minor, build, reserved uint8
+		release                       uint16
+	}
+	pLimitData struct {
+		version, length                 uint16
+		cpuPLimit, gpuPLimit, memPLimit uint32
+	}
+	keyInfo struct {
+		dataSize, dataType uint32
+		dataAttributes     uint8
+	}
+	result, status, data8 uint8
+	data32                uint32
+	bytes                 [32]byte
+}
+
+// smcConn is an open connection to the System Management Controller, which
+// holds the fan speeds on both Intel and Apple Silicon Macs
+type smcConn struct {
+	lib          uintptr
+	conn         uint32
+	callStruct   func(connection, selector uint32, input unsafe.Pointer, inputSize uintptr, output unsafe.Pointer, outputSize *uintptr) int32
+	closeService func(connection uint32) int32
+}
+
+// openSMC connects to the AppleSMC service
+func openSMC() (*smcConn, error) {
+	lib, err := purego.Dlopen(iokitPath, purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IOKit: %w", err)
+	}
+
+	var (
+		serviceMatching    func(name string) uintptr
+		getMatchingService func(mainPort uint32, matching uintptr) uint32
+		serviceOpen        func(service, owningTask, connType uint32, connection *uint32) int32
+		objectRelease      func(object uint32) int32
+		machTaskSelf       func() uint32
+	)
+	purego.RegisterLibFunc(&serviceMatching, lib, "IOServiceMatching")
+	purego.RegisterLibFunc(&getMatchingService, lib, "IOServiceGetMatchingService")
+	purego.RegisterLibFunc(&serviceOpen, lib, "IOServiceOpen")
+	purego.RegisterLibFunc(&objectRelease, lib, "IOObjectRelease")
+	purego.RegisterLibFunc(&machTaskSelf, lib, "mach_task_self")
+
+	smc := &smcConn{lib: lib}
+	purego.RegisterLibFunc(&smc.callStruct, lib, "IOConnectCallStructMethod")
+	purego.RegisterLibFunc(&smc.closeService, lib, "IOServiceClose")
+
+	// IOServiceGetMatchingService consumes the matching dictionary
+	service := getMatchingService(0, serviceMatching("AppleSMC"))
+	if service == 0 {
+		purego.Dlclose(lib)
+		return nil, fmt.Errorf("AppleSMC service: %w", os.ErrNotExist)
+	}
+	defer objectRelease(service)
+
+	if result := serviceOpen(service, machTaskSelf(), 0, &smc.conn); result != 0 {
+		purego.Dlclose(lib)
+		return nil, fmt.Errorf("failed to open AppleSMC: IOServiceOpen returned %#x", uint32(result))
+	}
+	return smc, nil
+}
+
+// close disconnects from the SMC
+func (s *smcConn) close() {
+	s.closeService(s.conn)
+	purego.Dlclose(s.lib)
+}
+
+// call sends one request to the SMC user client
+func (s *smcConn) call(input *smcKeyData) (*smcKeyData, error) {
+	output := new(smcKeyData)
+	size := unsafe.Sizeof(*output)
+	result := s.callStruct(s.conn, smcHandleYPCEvent, unsafe.Pointer(input), unsafe.Sizeof(*input), unsafe.Pointer(output), &size)
+	runtime.KeepAlive(input)
+	if result != 0 {
+		return nil, fmt.Errorf("IOConnectCallStructMethod returned %#x", uint32(result))
+	}
+	return output, nil
+}
+
+// readNumber reads a numeric SMC key such as FNum or F0Ac. Keys the machine
+// doesn't have fail with os.ErrNotExist.
+func (s *smcConn) readNumber(key string) (float64, error) {
+	if len(key) != 4 {
+		return 0, fmt.Errorf("invalid SMC key %q", key)
+	}
+	input := &smcKeyData{key: binary.BigEndian.Uint32([]byte(key)), data8: smcGetKeyInfo}
+	info, err := s.call(input)
+	if err != nil {
+		return 0, fmt.Errorf("SMC key %s: %w", key, err)
+	}
+	if info.result == smcKeyNotFound {
+		return 0, fmt.Errorf("SMC key %s: %w", key, os.ErrNotExist)
+	}
+
+	input.keyInfo.dataSize = info.keyInfo.dataSize
+	input.data8 = smcReadKey
+	value, err := s.call(input)
+	if err != nil {
+		return 0, fmt.Errorf("SMC key %s: %w", key, err)
+	}
+	if value.result != 0 {
+		return 0, fmt.Errorf("SMC key %s: read failed with result %d", key, value.result)
+	}
+
+	var dataType [4]byte
+	binary.BigEndian.PutUint32(dataType[:], info.keyInfo.dataType)
+	data := value.bytes[:]
+	switch string(dataType[:]) {
+	case "ui8 ":
+		return float64(data[0]), nil
+	case "ui16":
+		return float64(binary.BigEndian.Uint16(data)), nil
+	case "flt ":
+		// Apple Silicon, native byte order
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data))), nil
+	case "fpe2":
+		// Intel, unsigned fixed point with 2 fraction bits
+		return float64(binary.BigEndian.Uint16(data)) / 4, nil
+	}
+	return 0, fmt.Errorf("SMC key %s: unsupported data type %q", key, dataType[:])
+}
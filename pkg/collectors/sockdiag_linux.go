@@ -0,0 +1,123 @@
+//go:build linux
+
+package collectors
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+)
+
+// inet_diag netlink protocol constants (linux/sock_diag.h, linux/inet_diag.h)
+const (
+	sockDiagByFamily = 20 // SOCK_DIAG_BY_FAMILY message type
+	inetDiagInfo     = 2  // INET_DIAG_INFO attribute, carrying struct tcp_info
+
+	inetDiagReqLen = 56 // sizeof(struct inet_diag_req_v2)
+	inetDiagMsgLen = 72 // sizeof(struct inet_diag_msg)
+
+	// Offsets of tcpi_bytes_acked and tcpi_bytes_received in struct
+	// tcp_info (kernel 4.1+); older kernels send a shorter struct
+	tcpInfoBytesAcked    = 120
+	tcpInfoBytesReceived = 128
+)
+
+// tcpSocketCounters dumps every TCP socket in the current network namespace
+// with its byte counters, keyed by socket inode. This is the kernel's
+// sock_diag interface that `ss -ti` uses; it needs no privileges and sees
+// the sockets of all users.
+func tcpSocketCounters() (map[uint64]socketCounters, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, syscall.NETLINK_INET_DIAG)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sock_diag socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	sockets := make(map[uint64]socketCounters)
+	for _, family := range []uint8{syscall.AF_INET, syscall.AF_INET6} {
+		if err := dumpTCPSockets(fd, family, sockets); err != nil {
+			return nil, err
+		}
+	}
+	return sockets, nil
+}
+
+// dumpTCPSockets requests a dump of one address family's TCP sockets and
+// reads the replies until the kernel signals the end of the dump
+func dumpTCPSockets(fd int, family uint8, sockets map[uint64]socketCounters) error {
+	req := make([]byte, syscall.NLMSG_HDRLEN+inetDiagReqLen)
+	binary.NativeEndian.PutUint32(req[0:], uint32(len(req)))
+	binary.NativeEndian.PutUint16(req[4:], sockDiagByFamily)
+	binary.NativeEndian.PutUint16(req[6:], syscall.NLM_F_REQUEST|syscall.NLM_F_DUMP)
+	binary.NativeEndian.PutUint32(req[8:], uint32(family))
+
+	// struct inet_diag_req_v2: family, protocol, extensions, pad, states
+	body := req[syscall.NLMSG_HDRLEN:]
+	body[0] = family
+	body[1] = syscall.IPPROTO_TCP
+	body[2] = 1 << (inetDiagInfo - 1)
+	binary.NativeEndian.PutUint32(body[4:], ^uint32(0))
+
+	if err := syscall.Sendto(fd, req, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return fmt.Errorf("failed to request TCP sockets: %w", err)
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return fmt.Errorf("failed to read TCP sockets: %w", err)
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return fmt.Errorf("failed to parse TCP sockets: %w", err)
+		}
+		for _, msg := range msgs {
+			switch msg.Header.Type {
+			case syscall.NLMSG_DONE:
+				return nil
+			case syscall.NLMSG_ERROR:
+				if len(msg.Data) >= 4 {
+					if errno := int32(binary.NativeEndian.Uint32(msg.Data)); errno < 0 {
+						return fmt.Errorf("failed to dump TCP sockets: %w", syscall.Errno(-errno))
+					}
+				}
+				return nil
+			case sockDiagByFamily:
+				if inode, counters, ok := parseInetDiagMsg(msg.Data); ok {
+					sockets[inode] = counters
+				}
+			}
+		}
+	}
+}
+
+// parseInetDiagMsg reads the inode of a struct inet_diag_msg and the byte
+// counters from its tcp_info attribute
+func parseInetDiagMsg(msg []byte) (uint64, socketCounters, bool) {
+	if len(msg) < inetDiagMsgLen {
+		return 0, socketCounters{}, false
+	}
+	inode := uint64(binary.NativeEndian.Uint32(msg[68:]))
+	if inode == 0 {
+		return 0, socketCounters{}, false
+	}
+
+	// Attributes follow, each a 4-byte length/type header and 4-byte aligned
+	attrs := msg[inetDiagMsgLen:]
+	for len(attrs) >= 4 {
+		length := int(binary.NativeEndian.Uint16(attrs))
+		kind := binary.NativeEndian.Uint16(attrs[2:])
+		if length < 4 || length > len(attrs) {
+			break
+		}
+		if info := attrs[4:length]; kind == inetDiagInfo && len(info) >= tcpInfoBytesReceived+8 {
+			return inode, socketCounters{
+				tx: binary.NativeEndian.Uint64(info[tcpInfoBytesAcked:]),
+				rx: binary.NativeEndian.Uint64(info[tcpInfoBytesReceived:]),
+			}, true
+		}
+		attrs = attrs[min((length+3)&^3, len(attrs)):]
+	}
+	return 0, socketCounters{}, false
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package collectors
+
+import "errors"
+
+// tcpSocketCounters needs Linux's sock_diag netlink interface
+func tcpSocketCounters() (map[uint64]socketCounters, error) {
+	return nil, errors.New("per-process network usage is only supported on Linux")
+}
@@ -0,0 +1,241 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// updatesTimeout caps a single package manager run; dnf loading a large
+// metadata cache can take a while
+const updatesTimeout = 60 * time.Second
+
+// UpdatesMetrics holds the number of packages waiting to be upgraded
+type UpdatesMetrics struct {
+	Manager    string // "apt", "dnf" or "pacman"; empty when none was found
+	Pending    int    // Packages with a newer version available
+	Security   int    // Of those, packages fixing security issues; -1 when the manager doesn't say
+	LastUpdate time.Time
+}
+
+// UpdatesCollector counts pending package updates from the package
+// manager's cached metadata. It never refreshes that metadata itself (no
+// network access or root needed), so the counts are as fresh as the
+// system's own apt/dnf timers or last `pacman -Sy`.
+type UpdatesCollector struct {
+	interval uint
+	mu       sync.RWMutex
+	lastData *UpdatesMetrics
+}
+
+// NewUpdatesCollector creates a new package updates collector
+func NewUpdatesCollector(interval uint) *UpdatesCollector {
+	return &UpdatesCollector{
+		interval: interval,
+	}
+}
+
+// Name returns the collector name
+func (c *UpdatesCollector) Name() string {
+	return "updates"
+}
+
+// Interval returns the update interval in seconds
+func (c *UpdatesCollector) Interval() uint {
+	return c.interval
+}
+
+// packageManagers lists the package managers in the order they are tried;
+// the first one installed is asked
+var packageManagers = []struct {
+	name  string
+	tool  string
+	query func(ctx context.Context, path string) (pending, security int, err error)
+}{
+	{"apt", "apt-get", queryApt},
+	{"dnf", "dnf", queryDnf},
+	{"pacman", "pacman", queryPacman},
+}
+
+// Collect asks the system's package manager for pending updates
+func (c *UpdatesCollector) Collect(ctx context.Context) (interface{}, error) {
+	metrics := &UpdatesMetrics{Security: -1}
+	for _, manager := range packageManagers {
+		path, err := exec.LookPath(manager.tool)
+		if err != nil {
+			continue
+		}
+		pending, security, err := manager.query(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", manager.name, err)
+		}
+		metrics.Manager = manager.name
+		metrics.Pending = pending
+		metrics.Security = security
+		break
+	}
+	metrics.LastUpdate = time.Now()
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, nil
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *UpdatesCollector) GetLastData() *UpdatesMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// runPackageManager runs a package manager command, returning its output
+// and exit code. A non-zero exit isn't an error by itself: dnf and pacman
+// use exit codes to say whether there are updates.
+func runPackageManager(ctx context.Context, path string, args ...string) ([]byte, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, updatesTimeout)
+	defer cancel()
+
+	cmd := newCommand(ctx, path, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, 0, fmt.Errorf("%s timed out after %s", filepath.Base(path), updatesTimeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if code := exitErr.ExitCode(); code > 0 {
+			if msg := firstLine(stderr.String()); msg != "" {
+				return stdout.Bytes(), code, fmt.Errorf("%w: %s", err, msg)
+			}
+			return stdout.Bytes(), code, err
+		}
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return stdout.Bytes(), 0, nil
+}
+
+// queryApt simulates a full upgrade against the cached package lists
+func queryApt(ctx context.Context, path string) (int, int, error) {
+	// Debug::NoLocking lets the simulation run without root
+	out, _, err := runPackageManager(ctx, path, "-s", "-q", "-o", "Debug::NoLocking=true", "dist-upgrade")
+	if err != nil {
+		return 0, 0, err
+	}
+	pending, security := parseAptSimulation(string(out))
+	return pending, security, nil
+}
+
+// parseAptSimulation counts the packages `apt-get -s dist-upgrade` would
+// install or upgrade, one "Inst" line each, e.g.
+//
+//	Inst openssl [3.0.2-0ubuntu1.14] (3.0.2-0ubuntu1.15 Ubuntu:22.04/jammy-updates, Ubuntu:22.04/jammy-security [amd64])
+//
+// A package whose new version comes from a -security suite is a security
+// update.
+func parseAptSimulation(output string) (pending, security int) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "Inst ") {
+			continue
+		}
+		pending++
+		if strings.Contains(line, "-security") {
+			security++
+		}
+	}
+	return pending, security
+}
+
+// queryDnf lists available updates and security advisories from the
+// metadata cache
+func queryDnf(ctx context.Context, path string) (int, int, error) {
+	// -C stays on the cache; exit code 100 means updates are available
+	out, code, err := runPackageManager(ctx, path, "-q", "-C", "check-update")
+	if err != nil && code != 100 {
+		return 0, 0, err
+	}
+	pending := parseDnfCheckUpdate(string(out))
+
+	security := -1
+	if pending == 0 {
+		security = 0
+	} else if out, _, err := runPackageManager(ctx, path, "-q", "-C", "updateinfo", "list", "--security"); err == nil {
+		security = parseDnfSecurity(string(out))
+	}
+	return pending, security, nil
+}
+
+// parseDnfCheckUpdate counts the packages in `dnf check-update` output,
+// one per line as name.arch, version and repository, e.g.
+//
+//	openssl-libs.x86_64     1:3.0.7-27.el9     baseos
+//
+// It stops at the "Obsoleting Packages" section, whose packages are
+// already listed above it.
+func parseDnfCheckUpdate(output string) int {
+	pending := 0
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Obsoleting") {
+			break
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 3 && strings.Contains(fields[0], ".") {
+			pending++
+		}
+	}
+	return pending
+}
+
+// parseDnfSecurity counts the distinct packages in `dnf updateinfo list
+// --security` output, one advisory and package per line, e.g.
+//
+//	RHSA-2024:1234 Important/Sec. openssl-libs-1:3.0.7-27.el9.x86_64
+func parseDnfSecurity(output string) int {
+	packages := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 {
+			packages[fields[2]] = true
+		}
+	}
+	return len(packages)
+}
+
+// queryPacman lists upgradable packages against the local sync database.
+// Arch publishes no security metadata with its packages.
+func queryPacman(ctx context.Context, path string) (int, int, error) {
+	out, code, err := runPackageManager(ctx, path, "-Qu")
+	// Exit code 1 with no output means nothing to upgrade
+	if code == 1 && len(bytes.TrimSpace(out)) == 0 {
+		return 0, -1, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	pending := 0
+	for _, line := range strings.Split(string(out), "\n") {
+		// name old -> new, possibly followed by [ignored]
+		if strings.Contains(line, " -> ") {
+			pending++
+		}
+	}
+	return pending, -1, nil
+}
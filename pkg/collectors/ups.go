@@ -0,0 +1,278 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+const (
+	// DefaultNUTAddress is where upsd listens unless configured otherwise
+	DefaultNUTAddress = "localhost:3493"
+
+	// nutTimeout caps a whole exchange with upsd
+	nutTimeout = 3 * time.Second
+)
+
+// UPSStat holds the state of a single UPS. Readings the driver doesn't
+// report are -1 (Runtime is 0 when unknown).
+type UPSStat struct {
+	Name        string // upsd's name for the UPS, e.g. "myups"
+	Description string
+	Model       string
+	Status      string  // Raw ups.status flags, e.g. "OL CHRG" or "OB DISCHRG LB"
+	OnBattery   bool    // Running from the battery (OB)
+	LowBattery  bool    // Battery is low; NUT starts shutting systems down (LB)
+	Charge      float64 // Battery charge in percent
+	Load        float64 // Output load in percent of capacity
+	Runtime     time.Duration
+}
+
+// UPSMetrics holds every UPS the NUT server knows about
+type UPSMetrics struct {
+	Server     string // Address of the NUT server asked
+	UPSes      []UPSStat
+	LastUpdate time.Time
+}
+
+// UPSCollector reads UPS state from a NUT (Network UPS Tools) server over
+// its text protocol. Without a configured address, no local server
+// listening is not an error; the metrics simply list no UPS.
+type UPSCollector struct {
+	interval   uint
+	address    string
+	configured bool // The address was set explicitly, so it must answer
+	mu         sync.RWMutex
+	lastData   *UPSMetrics
+}
+
+// NewUPSCollector creates a new UPS collector asking the upsd at address
+// (host:port); an empty address means DefaultNUTAddress
+func NewUPSCollector(interval uint, address string) *UPSCollector {
+	c := &UPSCollector{
+		interval:   interval,
+		address:    address,
+		configured: address != "",
+	}
+	if !c.configured {
+		c.address = DefaultNUTAddress
+	}
+	return c
+}
+
+// Name returns the collector name
+func (c *UPSCollector) Name() string {
+	return "ups"
+}
+
+// Interval returns the update interval in seconds
+func (c *UPSCollector) Interval() uint {
+	return c.interval
+}
+
+// Collect lists the UPSes upsd serves and reads each one's variables
+func (c *UPSCollector) Collect(ctx context.Context) (interface{}, error) {
+	metrics := &UPSMetrics{Server: c.address}
+	failed := make(map[string]error)
+
+	ups, err := queryNUT(ctx, c.address, failed)
+	var opErr *net.OpError
+	if err != nil && (c.configured || !errors.As(err, &opErr) || opErr.Op != "dial") {
+		return nil, fmt.Errorf("NUT server %s: %w", c.address, err)
+	}
+	metrics.UPSes = ups
+	metrics.LastUpdate = time.Now()
+
+	c.mu.Lock()
+	c.lastData = metrics
+	c.mu.Unlock()
+
+	return metrics, data.NewPartialError(failed)
+}
+
+// GetLastData returns the last collected data (thread-safe)
+func (c *UPSCollector) GetLastData() *UPSMetrics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastData
+}
+
+// queryNUT connects to upsd and reads every UPS it serves. A UPS whose
+// variables can't be read is recorded in failed and skipped.
+func queryNUT(ctx context.Context, address string, failed map[string]error) ([]UPSStat, error) {
+	ctx, cancel := context.WithTimeout(ctx, nutTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client := &nutClient{conn: conn, reader: bufio.NewReader(conn)}
+	defer client.send("LOGOUT")
+
+	list, err := client.list("UPS")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]UPSStat, 0, len(list))
+	for _, entry := range list {
+		// UPS <name> "<description>"
+		if len(entry) == 0 {
+			continue
+		}
+		vars, err := client.list("VAR", entry[0])
+		if err != nil {
+			failed[entry[0]] = err
+			continue
+		}
+		stat := parseUPSVars(entry[0], vars)
+		if len(entry) > 1 {
+			stat.Description = entry[1]
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// nutClient speaks the line-based upsd protocol over one connection
+type nutClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// send writes a single command line
+func (n *nutClient) send(command string) error {
+	_, err := n.conn.Write([]byte(command + "\n"))
+	return err
+}
+
+// list runs `LIST <kind> [args]` and returns the words after the kind and
+// args on every line between BEGIN and END, e.g. for LIST VAR myups the
+// line `VAR myups battery.charge "100"` yields [battery.charge 100]
+func (n *nutClient) list(kind string, args ...string) ([][]string, error) {
+	query := strings.Join(append([]string{kind}, args...), " ")
+	if err := n.send("LIST " + query); err != nil {
+		return nil, err
+	}
+
+	var entries [][]string
+	begun := false
+	for {
+		line, err := n.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		words := splitNUTLine(strings.TrimRight(line, "\r\n"))
+		if len(words) == 0 {
+			continue
+		}
+		switch {
+		case words[0] == "ERR":
+			return nil, fmt.Errorf("upsd: %s", strings.Join(words[1:], " "))
+		case words[0] == "BEGIN":
+			begun = true
+		case words[0] == "END":
+			return entries, nil
+		case begun && words[0] == kind && len(words) > len(args):
+			entries = append(entries, words[1+len(args):])
+		}
+	}
+}
+
+// splitNUTLine splits a upsd response line into words, unquoting
+// double-quoted words and their \" and \\ escapes
+func splitNUTLine(line string) []string {
+	var words []string
+	var word strings.Builder
+	inWord, quoted, escaped := false, false, false
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case r == ' ' && !quoted:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// parseUPSVars builds a UPSStat from a UPS's variables, each a
+// [name value] pair from LIST VAR
+func parseUPSVars(name string, vars [][]string) UPSStat {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		if len(v) >= 2 {
+			values[v[0]] = v[1]
+		}
+	}
+
+	stat := UPSStat{
+		Name:   name,
+		Status: values["ups.status"],
+		Charge: parseNUTValue(values["battery.charge"]),
+		Load:   parseNUTValue(values["ups.load"]),
+	}
+	for _, flag := range strings.Fields(stat.Status) {
+		switch flag {
+		case "OB":
+			stat.OnBattery = true
+		case "LB":
+			stat.LowBattery = true
+		}
+	}
+	if runtime := parseNUTValue(values["battery.runtime"]); runtime >= 0 {
+		stat.Runtime = time.Duration(runtime) * time.Second
+	}
+
+	manufacturer := values["device.mfr"]
+	model := values["device.model"]
+	if manufacturer == "" {
+		manufacturer = values["ups.mfr"]
+	}
+	if model == "" {
+		model = values["ups.model"]
+	}
+	stat.Model = strings.TrimSpace(manufacturer + " " + model)
+	return stat
+}
+
+// parseNUTValue parses a numeric UPS variable, returning -1 when it is
+// missing or not a number
+func parseNUTValue(value string) float64 {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || number < 0 {
+		return -1
+	}
+	return number
+}
@@ -0,0 +1,149 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// procNetWireless lists the signal of every wireless interface on Linux
+const procNetWireless = "/proc/net/wireless"
+
+// iwTimeout caps a single `iw dev <iface> link` run
+const iwTimeout = 2 * time.Second
+
+// wirelessLinkInterval is how often the SSID, bitrate and frequency are
+// re-read through iw; the signal itself comes from /proc on every collection
+const wirelessLinkInterval = 10 * time.Second
+
+// wirelessMaxQuality is the link quality cfg80211 drivers report at full
+// signal in /proc/net/wireless
+const wirelessMaxQuality = 70
+
+// WirelessStat holds the link state of a Wi-Fi interface. Readings that
+// aren't known are zero (Quality is -1).
+type WirelessStat struct {
+	SSID      string  // Empty when disconnected or iw isn't installed
+	Signal    float64 // dBm
+	Quality   float64 // Link quality percent
+	Bitrate   float64 // Transmit bitrate in Mbit/s
+	Frequency float64 // MHz
+	Channel   int
+}
+
+// wirelessLink is the part of a WirelessStat read through iw, and when
+type wirelessLink struct {
+	ssid      string
+	bitrate   float64
+	frequency float64
+	at        time.Time
+}
+
+// readProcWireless parses /proc/net/wireless, e.g.
+//
+//	Inter-| sta-|   Quality        |   Discarded packets               | Missed | WE
+//	 face | tus | link level noise |  nwid  crypt   frag  retry   misc | beacon | 22
+//	 wlan0: 0000   54.  -56.  -256        0      0      0      0     18        0
+//
+// into the quality and signal of each interface. It returns nil on other
+// platforms and when there are no wireless interfaces.
+func readProcWireless() map[string]WirelessStat {
+	file, err := os.Open(procNetWireless)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	stats := make(map[string]WirelessStat)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name, values, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(values)
+		if len(fields) < 3 {
+			continue
+		}
+		stat := WirelessStat{Quality: -1}
+		if link, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "."), 64); err == nil {
+			stat.Quality = min(link/wirelessMaxQuality*100, 100)
+		}
+		if level, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "."), 64); err == nil {
+			// Old drivers report the level as an unsigned byte
+			if level > 0 {
+				level -= 256
+			}
+			stat.Signal = level
+		}
+		stats[strings.TrimSpace(name)] = stat
+	}
+	return stats
+}
+
+// readIWLink reads the SSID, transmit bitrate and frequency of a wireless
+// interface from `iw dev <iface> link`, e.g.
+//
+//	Connected to 11:22:33:44:55:66 (on wlan0)
+//		SSID: MyNetwork
+//		freq: 5180
+//		signal: -52 dBm
+//		tx bitrate: 866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2
+//
+// The link is empty when iw is missing or the interface is disconnected.
+func readIWLink(ctx context.Context, iface string) wirelessLink {
+	link := wirelessLink{at: time.Now()}
+	path, err := exec.LookPath("iw")
+	if err != nil {
+		return link
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, iwTimeout)
+	defer cancel()
+	output, err := newCommand(ctx, path, "dev", iface, "link").Output()
+	if err != nil {
+		return link
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "SSID":
+			link.ssid = value
+		case "freq":
+			link.frequency, _ = strconv.ParseFloat(value, 64)
+		case "tx bitrate":
+			if fields := strings.Fields(value); len(fields) > 0 {
+				link.bitrate, _ = strconv.ParseFloat(fields[0], 64)
+			}
+		}
+	}
+	return link
+}
+
+// wifiChannel returns the channel number of a Wi-Fi frequency in MHz, or 0
+// outside the 2.4, 5 and 6 GHz bands
+func wifiChannel(frequency float64) int {
+	mhz := int(frequency)
+	switch {
+	case mhz == 2484:
+		return 14
+	case mhz >= 2412 && mhz <= 2472:
+		return (mhz - 2407) / 5
+	case mhz >= 5160 && mhz <= 5885:
+		return (mhz - 5000) / 5
+	case mhz >= 5955 && mhz <= 7115:
+		return (mhz - 5950) / 5
+	}
+	return 0
+}
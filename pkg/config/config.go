@@ -1,76 +1,338 @@
 package config
 
 import (
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Refresh   RefreshConfig
-	Display   DisplayConfig
-	Threshold ThresholdConfig
-	UI        UIConfig
-	Debug     bool
+	Refresh      RefreshConfig    `mapstructure:"refresh"`
+	Display      DisplayConfig    `mapstructure:"display"`
+	Threshold    ThresholdConfig  `mapstructure:"thresholds"`
+	UI           UIConfig         `mapstructure:"ui"`
+	Dashboard    DashboardConfig  `mapstructure:"dashboard"`
+	Process      ProcessConfig    `mapstructure:"process"`
+	Collectors   CollectorsConfig `mapstructure:"collectors"`
+	Network      NetworkConfig    `mapstructure:"network"`
+	CPU          CPUConfig        `mapstructure:"cpu"`
+	Disk         DiskConfig       `mapstructure:"disk"`
+	Memory       MemoryConfig     `mapstructure:"memory"`
+	Snapshot     SnapshotConfig   `mapstructure:"snapshot"`
+	UPS          UPSConfig        `mapstructure:"ups"`
+	Updates      UpdatesConfig    `mapstructure:"updates"`
+	LowPower     bool             `mapstructure:"low_power"` // Trade freshness for less CPU use (see ApplyLowPower)
+	HostView     bool             `mapstructure:"host_view"` // Host totals instead of the container's cgroup limits
+	Debug        bool             `mapstructure:"debug"`
+	DebugOverlay bool             `mapstructure:"debug_overlay"` // D toggles the collector health overlay
 }
 
 // RefreshConfig holds refresh interval settings
 type RefreshConfig struct {
-	Interval time.Duration
-	CPU      time.Duration
-	Memory   time.Duration
-	Disk     time.Duration
-	Network  time.Duration
-	Sensors  time.Duration
-	Host     time.Duration
+	Interval    time.Duration `mapstructure:"interval"`
+	CPU         time.Duration `mapstructure:"cpu"`
+	Memory      time.Duration `mapstructure:"memory"`
+	Disk        time.Duration `mapstructure:"disk"`
+	Network     time.Duration `mapstructure:"network"`
+	Sensors     time.Duration `mapstructure:"sensors"`
+	Host        time.Duration `mapstructure:"host"`
+	Processes   time.Duration `mapstructure:"processes"`
+	GPU         time.Duration `mapstructure:"gpu"`
+	Containers  time.Duration `mapstructure:"containers"`
+	Bandwidth   time.Duration `mapstructure:"bandwidth"`
+	Connections time.Duration `mapstructure:"connections"`
+	Services    time.Duration `mapstructure:"services"`
+	Kubernetes  time.Duration `mapstructure:"kubernetes"`
+	VMs         time.Duration `mapstructure:"vms"`
+	Pi          time.Duration `mapstructure:"rpi"`
+	Ping        time.Duration `mapstructure:"ping"`
+	Clock       time.Duration `mapstructure:"clock"`
+	Conntrack   time.Duration `mapstructure:"conntrack"`
+	UPS         time.Duration `mapstructure:"ups"`
+	Updates     time.Duration `mapstructure:"updates"`
+	Certs       time.Duration `mapstructure:"certs"`
 }
 
 // DisplayConfig holds display settings
 type DisplayConfig struct {
-	Theme          string
-	ShowGraphs     bool
-	ShowPercentages bool
-	Precision      int
-	Units          string
+	Theme           string       `mapstructure:"theme"`
+	ShowGraphs      bool         `mapstructure:"show_graphs"`
+	ShowPercentages bool         `mapstructure:"show_percentages"`
+	ShowAbsolute    bool         `mapstructure:"show_absolute"` // Sizes next to percentages (memory, swap, disk)
+	Precision       int          `mapstructure:"precision"`
+	Units           string       `mapstructure:"units"`
+	CPUMode         string       `mapstructure:"cpu_mode"` // percent or cores
+	CPUBreakdown    bool         `mapstructure:"cpu_breakdown"`
+	Timezone        string       `mapstructure:"timezone"` // IANA name, "UTC", or empty for local
+	Colors          ColorsConfig `mapstructure:"colors"`
+	BusyWeights     BusyWeights  `mapstructure:"busy_weights"`
+	ShowTrends      bool         `mapstructure:"show_trends"` // ▲/▼ arrows next to CPU, memory, and network
+}
+
+// BusyWeights weights the inputs of the header's composite "busy" sparkline;
+// see data.SystemBusy for the formula
+type BusyWeights struct {
+	CPU    float64 `mapstructure:"cpu"`
+	IOWait float64 `mapstructure:"iowait"`
+	Load   float64 `mapstructure:"load"`
+}
+
+// ColorsConfig overrides theme colors with hex values like "#50fa7b";
+// empty entries keep the theme's color
+type ColorsConfig struct {
+	Normal   string `mapstructure:"normal"`
+	Warning  string `mapstructure:"warning"`
+	Critical string `mapstructure:"critical"`
+	Label    string `mapstructure:"label"`
+	Muted    string `mapstructure:"muted"`
+	Title    string `mapstructure:"title"`
+	Accent   string `mapstructure:"accent"`
+	Text     string `mapstructure:"text"`
+	Border   string `mapstructure:"border"`
+}
+
+// hexColor matches #rgb and #rrggbb color strings
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Location returns the configured display timezone, falling back to local time
+func (d DisplayConfig) Location() *time.Location {
+	if d.Timezone == "" || d.Timezone == "Local" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
 }
 
 // ThresholdConfig holds alert threshold settings
 type ThresholdConfig struct {
-	CPUWarning  float64
-	CPUCritical float64
-	MemWarning  float64
-	MemCritical float64
-	TempWarning float64
-	TempCritical float64
+	CPUWarning       float64 `mapstructure:"cpu_warning"`
+	CPUCritical      float64 `mapstructure:"cpu_critical"`
+	MemWarning       float64 `mapstructure:"memory_warning"`
+	MemCritical      float64 `mapstructure:"memory_critical"`
+	TempWarning      float64 `mapstructure:"temp_warning"`
+	TempCritical     float64 `mapstructure:"temp_critical"`
+	PressureWarning  float64 `mapstructure:"pressure_warning"` // Memory pressure score, see data.MemoryPressure
+	PressureCritical float64 `mapstructure:"pressure_critical"`
+	InodeWarning     float64 `mapstructure:"inode_warning"` // Percent of a filesystem's inodes in use
+	InodeCritical    float64 `mapstructure:"inode_critical"`
+	ClockOffsetWarning  float64 `mapstructure:"clock_offset_warning"` // Clock drift from NTP in milliseconds, either direction
+	ClockOffsetCritical float64 `mapstructure:"clock_offset_critical"`
+	ConntrackWarning    float64 `mapstructure:"conntrack_warning"` // Percent of the conntrack table in use
+	ConntrackCritical   float64 `mapstructure:"conntrack_critical"`
+	CertExpiryWarning   float64 `mapstructure:"cert_expiry_warning"` // Days left before a TLS certificate expires
+	CertExpiryCritical  float64 `mapstructure:"cert_expiry_critical"`
 }
 
 // UIConfig holds UI-specific settings
 type UIConfig struct {
-	PageSize      int
-	ShowLoadAverage bool
-	ShowUptime      bool
-	ShowHostname    bool
+	PageSize           int    `mapstructure:"page_size"`
+	ShowLoadAverage    bool   `mapstructure:"show_load_average"`
+	ShowUptime         bool   `mapstructure:"show_uptime"`
+	ShowHostname       bool   `mapstructure:"show_hostname"`
+	Mode               string `mapstructure:"mode"`                  // dashboard, top, or compact
+	AlertBarMaxItems   int    `mapstructure:"alert_bar_max_items"`   // 0 = as many as fit
+	NavStyle           string `mapstructure:"nav_style"`             // sidebar or topbar
+	ConfirmQuit        bool   `mapstructure:"confirm_quit"`          // Require q twice to quit
+	RefreshOnTabSwitch bool   `mapstructure:"refresh_on_tab_switch"` // Collect the new tab's metrics immediately
+	Clipboard          bool   `mapstructure:"clipboard"`             // y copies a text summary to the clipboard
+	Mouse              bool   `mapstructure:"mouse"`                 // Click tabs and alerts, scroll with the wheel
+	PauseCollection    bool   `mapstructure:"pause_collection"`      // p also stops collection, not just screen updates
+}
+
+// DashboardConfig holds dashboard view settings
+type DashboardConfig struct {
+	Layout  [][]string     `mapstructure:"layout"`  // Columns left to right, each a list of panels top to bottom
+	Widths  []int          `mapstructure:"widths"`  // Relative column widths, one per column; empty for equal columns
+	Heights map[string]int `mapstructure:"heights"` // Relative panel heights within a stacked column; empty sizes panels to content
+}
+
+// DashboardPanels are the panel names a dashboard layout can use
+var DashboardPanels = []string{"cpu", "memory", "network", "temperature"}
+
+// DefaultDashboardLayout is CPU | Temperature | Memory over Network
+func DefaultDashboardLayout() [][]string {
+	return [][]string{{"cpu"}, {"temperature"}, {"memory", "network"}}
+}
+
+// validDashboardLayout reports whether a layout has 2-4 non-empty columns
+// and names each known panel at most once
+func validDashboardLayout(layout [][]string) bool {
+	if len(layout) < 2 || len(layout) > 4 {
+		return false
+	}
+	seen := make(map[string]bool)
+	for _, column := range layout {
+		if len(column) == 0 {
+			return false
+		}
+		for _, panel := range column {
+			if !slices.Contains(DashboardPanels, panel) || seen[panel] {
+				return false
+			}
+			seen[panel] = true
+		}
+	}
+	return true
+}
+
+// validDashboardWidths reports whether widths gives a positive relative
+// width to each of the given number of columns
+func validDashboardWidths(widths []int, columns int) bool {
+	if len(widths) != columns {
+		return false
+	}
+	for _, w := range widths {
+		if w <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// validDashboardHeights reports whether heights only names known panels,
+// each with a positive relative height
+func validDashboardHeights(heights map[string]int) bool {
+	for panel, h := range heights {
+		if !slices.Contains(DashboardPanels, panel) || h <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ProcessConfig holds process table settings
+type ProcessConfig struct {
+	CPUWarning   float64 `mapstructure:"cpu_warning"`
+	CPUCritical  float64 `mapstructure:"cpu_critical"`
+	MemWarning   float64 `mapstructure:"mem_warning"`
+	MemCritical  float64 `mapstructure:"mem_critical"`
+	NormalizeCPU bool    `mapstructure:"normalize_cpu"` // CPU% relative to all cores instead of one
+	Limit        int     `mapstructure:"limit"`         // Top processes kept by CPU, by memory and by disk I/O
+	Sort         string  `mapstructure:"sort"`          // Initial process table order: cpu, memory, io or gpu
+}
+
+// CollectorsConfig holds collector-level settings
+type CollectorsConfig struct {
+	Disabled []string       `mapstructure:"disabled"` // Collector names to turn off (cpu, memory, disk, network, sensors, host)
+	Jitter   float64        `mapstructure:"jitter"`   // Random timing spread per collector (0.1 = ±10%, 0 = off)
+	Scripts  []ScriptConfig `mapstructure:"scripts"`  // External commands reporting custom metrics
+}
+
+// NetworkConfig holds network panel settings
+type NetworkConfig struct {
+	ExcludeLoopback bool              `mapstructure:"exclude_loopback"` // Hide lo and other loopback interfaces
+	Aliases         map[string]string `mapstructure:"aliases"`          // Display names keyed by interface name
+	MinLinkSpeed    int               `mapstructure:"min_link_speed"`   // Mb/s below which a wired link is flagged (0 = off)
+	PingTargets     []string          `mapstructure:"ping_targets"`     // Hosts pinged for RTT and loss, e.g. the gateway or 8.8.8.8
+	TLSEndpoints    []string          `mapstructure:"tls_endpoints"`    // host:port endpoints whose certificate expiry is watched
+}
+
+// CPUConfig holds CPU collection settings
+type CPUConfig struct {
+	Logical   bool `mapstructure:"logical"`    // Load percent relative to logical cores; false = physical
+	Power     bool `mapstructure:"power"`      // Read RAPL energy counters (powermetrics on macOS) for package/core/DRAM watts
+	CoreTemps bool `mapstructure:"core_temps"` // Show each core's temperature on its usage row
+}
+
+// MemoryConfig holds memory collection settings
+type MemoryConfig struct {
+	UsedBasis string         `mapstructure:"used_basis"` // gopsutil or available (used = total - available)
+	Pressure  PressureConfig `mapstructure:"pressure"`
+}
+
+// PressureConfig weights the inputs of the memory pressure score; see
+// data.MemoryPressure for the formula
+type PressureConfig struct {
+	Available   float64 `mapstructure:"available"`     // Weight of the share of memory not available
+	Swap        float64 `mapstructure:"swap"`          // Weight of the swap-in/out rate
+	SwapFullMiB float64 `mapstructure:"swap_full_mib"` // Swap MiB/s that counts as fully saturated
+}
+
+// DiskConfig holds disk panel settings
+type DiskConfig struct {
+	ShowDeviceInfo  bool              `mapstructure:"show_device_info"` // Model/serial of each partition's disk (Linux sysfs)
+	ShowTemperature bool              `mapstructure:"show_temperature"` // Drive temperature from hwmon (nvme, drivetemp)
+	ShowHealth      bool              `mapstructure:"show_health"`      // NVMe wear, spare and media errors via nvme-cli
+	Labels          map[string]string `mapstructure:"labels"`           // Display labels keyed by mountpoint
+}
+
+// SnapshotConfig holds snapshot and export settings
+type SnapshotConfig struct {
+	Anonymize bool `mapstructure:"anonymize"` // Redact hostname, addresses, and serials for sharing
+}
+
+// UPSConfig holds UPS monitoring settings
+type UPSConfig struct {
+	Address string `mapstructure:"address"` // NUT server host:port; empty asks localhost:3493 if it's running
+}
+
+// UpdatesConfig holds pending package update settings
+type UpdatesConfig struct {
+	Enabled bool `mapstructure:"enabled"` // Ask apt, dnf or pacman for pending updates (off by default)
+}
+
+// ScriptConfig defines an external command whose output is shown as a metric
+type ScriptConfig struct {
+	Name     string        `mapstructure:"name"`
+	Command  string        `mapstructure:"command"`
+	Interval time.Duration `mapstructure:"interval"`
+	Unit     string        `mapstructure:"unit"`
+	Warning  float64       `mapstructure:"warning"`
+	Critical float64       `mapstructure:"critical"`
 }
 
 // DefaultConfig returns default configuration
 func DefaultConfig() *Config {
 	return &Config{
 		Refresh: RefreshConfig{
-			Interval: 2 * time.Second,
-			CPU:      1 * time.Second,
-			Memory:   2 * time.Second,
-			Disk:     5 * time.Second,
-			Network:  2 * time.Second,
-			Sensors:  5 * time.Second,
-			Host:     5 * time.Second,
+			Interval:    2 * time.Second,
+			CPU:         1 * time.Second,
+			Memory:      2 * time.Second,
+			Disk:        5 * time.Second,
+			Network:     2 * time.Second,
+			Sensors:     5 * time.Second,
+			Host:        5 * time.Second,
+			Processes:   3 * time.Second,
+			GPU:         2 * time.Second,
+			Containers:  5 * time.Second,
+			Bandwidth:   3 * time.Second,
+			Connections: 5 * time.Second,
+			Services:    5 * time.Second,
+			Kubernetes:  10 * time.Second,
+			VMs:         5 * time.Second,
+			Pi:          5 * time.Second,
+			Ping:        10 * time.Second,
+			Clock:       30 * time.Second,
+			Conntrack:   5 * time.Second,
+			UPS:         10 * time.Second,
+			Updates:     time.Hour,
+			Certs:       time.Hour,
 		},
 		Display: DisplayConfig{
 			Theme:           "auto",
 			ShowGraphs:      true,
 			ShowPercentages: true,
+			ShowAbsolute:    true,
 			Precision:       1,
 			Units:           "auto",
+			CPUMode:         "percent",
+			CPUBreakdown:    true,
+			ShowTrends:      true,
+			BusyWeights: BusyWeights{
+				CPU:    0.5,
+				IOWait: 0.2,
+				Load:   0.3,
+			},
 		},
 		Threshold: ThresholdConfig{
 			CPUWarning:    70.0,
@@ -79,14 +341,56 @@ func DefaultConfig() *Config {
 			MemCritical:   95.0,
 			TempWarning:   70.0,
 			TempCritical:  85.0,
+			PressureWarning:  60.0,
+			PressureCritical: 85.0,
+			InodeWarning:     80.0,
+			InodeCritical:    95.0,
+			ClockOffsetWarning:  100.0,
+			ClockOffsetCritical: 1000.0,
+			ConntrackWarning:    80.0,
+			ConntrackCritical:   95.0,
+			CertExpiryWarning:   30.0,
+			CertExpiryCritical:  7.0,
 		},
 		UI: UIConfig{
 			PageSize:        50,
 			ShowLoadAverage: true,
 			ShowUptime:      true,
 			ShowHostname:    true,
+			Mode:            "dashboard",
+			NavStyle:        "sidebar",
+			Mouse:           true,
+		},
+		Dashboard: DashboardConfig{
+			Layout: DefaultDashboardLayout(),
+		},
+		Process: ProcessConfig{
+			CPUWarning:  20,
+			CPUCritical: 50,
+			MemWarning:  20,
+			MemCritical: 50,
+			Limit:       20,
+			Sort:        "cpu",
+		},
+		Network: NetworkConfig{
+			ExcludeLoopback: true,
+			MinLinkSpeed:    1000,
+		},
+		CPU: CPUConfig{
+			Logical:   true,
+			Power:     true,
+			CoreTemps: true,
 		},
-		Debug: false,
+		Memory: MemoryConfig{
+			UsedBasis: "gopsutil",
+			Pressure: PressureConfig{
+				Available:   0.6,
+				Swap:        0.4,
+				SwapFullMiB: 10,
+			},
+		},
+		Debug:        false,
+		DebugOverlay: false,
 	}
 }
 
@@ -102,12 +406,44 @@ func Load() (*Config, error) {
 	viper.SetDefault("refresh.network", cfg.Refresh.Network)
 	viper.SetDefault("refresh.sensors", cfg.Refresh.Sensors)
 	viper.SetDefault("refresh.host", cfg.Refresh.Host)
+	viper.SetDefault("refresh.processes", cfg.Refresh.Processes)
+	viper.SetDefault("refresh.gpu", cfg.Refresh.GPU)
+	viper.SetDefault("refresh.containers", cfg.Refresh.Containers)
+	viper.SetDefault("refresh.bandwidth", cfg.Refresh.Bandwidth)
+	viper.SetDefault("refresh.connections", cfg.Refresh.Connections)
+	viper.SetDefault("refresh.services", cfg.Refresh.Services)
+	viper.SetDefault("refresh.kubernetes", cfg.Refresh.Kubernetes)
+	viper.SetDefault("refresh.vms", cfg.Refresh.VMs)
+	viper.SetDefault("refresh.rpi", cfg.Refresh.Pi)
+	viper.SetDefault("refresh.ping", cfg.Refresh.Ping)
+	viper.SetDefault("refresh.clock", cfg.Refresh.Clock)
+	viper.SetDefault("refresh.conntrack", cfg.Refresh.Conntrack)
+	viper.SetDefault("refresh.ups", cfg.Refresh.UPS)
+	viper.SetDefault("refresh.updates", cfg.Refresh.Updates)
+	viper.SetDefault("refresh.certs", cfg.Refresh.Certs)
 
 	viper.SetDefault("display.theme", cfg.Display.Theme)
 	viper.SetDefault("display.show_graphs", cfg.Display.ShowGraphs)
 	viper.SetDefault("display.show_percentages", cfg.Display.ShowPercentages)
+	viper.SetDefault("display.show_absolute", cfg.Display.ShowAbsolute)
 	viper.SetDefault("display.precision", cfg.Display.Precision)
 	viper.SetDefault("display.units", cfg.Display.Units)
+	viper.SetDefault("display.cpu_mode", cfg.Display.CPUMode)
+	viper.SetDefault("display.cpu_breakdown", cfg.Display.CPUBreakdown)
+	viper.SetDefault("display.timezone", cfg.Display.Timezone)
+	viper.SetDefault("display.colors.normal", cfg.Display.Colors.Normal)
+	viper.SetDefault("display.colors.warning", cfg.Display.Colors.Warning)
+	viper.SetDefault("display.colors.critical", cfg.Display.Colors.Critical)
+	viper.SetDefault("display.colors.label", cfg.Display.Colors.Label)
+	viper.SetDefault("display.colors.muted", cfg.Display.Colors.Muted)
+	viper.SetDefault("display.colors.title", cfg.Display.Colors.Title)
+	viper.SetDefault("display.colors.accent", cfg.Display.Colors.Accent)
+	viper.SetDefault("display.colors.text", cfg.Display.Colors.Text)
+	viper.SetDefault("display.colors.border", cfg.Display.Colors.Border)
+	viper.SetDefault("display.show_trends", cfg.Display.ShowTrends)
+	viper.SetDefault("display.busy_weights.cpu", cfg.Display.BusyWeights.CPU)
+	viper.SetDefault("display.busy_weights.iowait", cfg.Display.BusyWeights.IOWait)
+	viper.SetDefault("display.busy_weights.load", cfg.Display.BusyWeights.Load)
 
 	viper.SetDefault("thresholds.cpu_warning", cfg.Threshold.CPUWarning)
 	viper.SetDefault("thresholds.cpu_critical", cfg.Threshold.CPUCritical)
@@ -115,13 +451,72 @@ func Load() (*Config, error) {
 	viper.SetDefault("thresholds.memory_critical", cfg.Threshold.MemCritical)
 	viper.SetDefault("thresholds.temp_warning", cfg.Threshold.TempWarning)
 	viper.SetDefault("thresholds.temp_critical", cfg.Threshold.TempCritical)
+	viper.SetDefault("thresholds.pressure_warning", cfg.Threshold.PressureWarning)
+	viper.SetDefault("thresholds.pressure_critical", cfg.Threshold.PressureCritical)
+	viper.SetDefault("thresholds.inode_warning", cfg.Threshold.InodeWarning)
+	viper.SetDefault("thresholds.inode_critical", cfg.Threshold.InodeCritical)
+	viper.SetDefault("thresholds.clock_offset_warning", cfg.Threshold.ClockOffsetWarning)
+	viper.SetDefault("thresholds.clock_offset_critical", cfg.Threshold.ClockOffsetCritical)
+	viper.SetDefault("thresholds.conntrack_warning", cfg.Threshold.ConntrackWarning)
+	viper.SetDefault("thresholds.conntrack_critical", cfg.Threshold.ConntrackCritical)
+	viper.SetDefault("thresholds.cert_expiry_warning", cfg.Threshold.CertExpiryWarning)
+	viper.SetDefault("thresholds.cert_expiry_critical", cfg.Threshold.CertExpiryCritical)
 
 	viper.SetDefault("ui.page_size", cfg.UI.PageSize)
 	viper.SetDefault("ui.show_load_average", cfg.UI.ShowLoadAverage)
 	viper.SetDefault("ui.show_uptime", cfg.UI.ShowUptime)
 	viper.SetDefault("ui.show_hostname", cfg.UI.ShowHostname)
+	viper.SetDefault("ui.mode", cfg.UI.Mode)
+	viper.SetDefault("ui.nav_style", cfg.UI.NavStyle)
+	viper.SetDefault("ui.alert_bar_max_items", cfg.UI.AlertBarMaxItems)
+	viper.SetDefault("ui.confirm_quit", cfg.UI.ConfirmQuit)
+	viper.SetDefault("ui.refresh_on_tab_switch", cfg.UI.RefreshOnTabSwitch)
+	viper.SetDefault("ui.clipboard", cfg.UI.Clipboard)
+	viper.SetDefault("ui.mouse", cfg.UI.Mouse)
+	viper.SetDefault("ui.pause_collection", cfg.UI.PauseCollection)
+	viper.SetDefault("dashboard.layout", cfg.Dashboard.Layout)
+	viper.SetDefault("dashboard.widths", cfg.Dashboard.Widths)
+	viper.SetDefault("dashboard.heights", cfg.Dashboard.Heights)
+	viper.SetDefault("process.cpu_warning", cfg.Process.CPUWarning)
+	viper.SetDefault("process.cpu_critical", cfg.Process.CPUCritical)
+	viper.SetDefault("process.mem_warning", cfg.Process.MemWarning)
+	viper.SetDefault("process.mem_critical", cfg.Process.MemCritical)
+	viper.SetDefault("process.normalize_cpu", cfg.Process.NormalizeCPU)
+	viper.SetDefault("process.limit", cfg.Process.Limit)
+	viper.SetDefault("process.sort", cfg.Process.Sort)
+
+	viper.SetDefault("collectors.disabled", cfg.Collectors.Disabled)
+	viper.SetDefault("collectors.jitter", cfg.Collectors.Jitter)
+	viper.SetDefault("collectors.scripts", []ScriptConfig{})
+
+	viper.SetDefault("network.exclude_loopback", cfg.Network.ExcludeLoopback)
+	viper.SetDefault("network.aliases", map[string]string{})
+	viper.SetDefault("network.min_link_speed", cfg.Network.MinLinkSpeed)
+	viper.SetDefault("network.ping_targets", []string{})
+	viper.SetDefault("network.tls_endpoints", []string{})
+
+	viper.SetDefault("cpu.logical", cfg.CPU.Logical)
+	viper.SetDefault("cpu.power", cfg.CPU.Power)
+	viper.SetDefault("cpu.core_temps", cfg.CPU.CoreTemps)
+
+	viper.SetDefault("disk.show_device_info", cfg.Disk.ShowDeviceInfo)
+	viper.SetDefault("disk.labels", map[string]string{})
+	viper.SetDefault("disk.show_temperature", cfg.Disk.ShowTemperature)
+	viper.SetDefault("disk.show_health", cfg.Disk.ShowHealth)
 
+	viper.SetDefault("memory.used_basis", cfg.Memory.UsedBasis)
+	viper.SetDefault("memory.pressure.available", cfg.Memory.Pressure.Available)
+	viper.SetDefault("memory.pressure.swap", cfg.Memory.Pressure.Swap)
+	viper.SetDefault("memory.pressure.swap_full_mib", cfg.Memory.Pressure.SwapFullMiB)
+
+	viper.SetDefault("snapshot.anonymize", cfg.Snapshot.Anonymize)
+	viper.SetDefault("ups.address", cfg.UPS.Address)
+	viper.SetDefault("updates.enabled", cfg.Updates.Enabled)
+
+	viper.SetDefault("low_power", cfg.LowPower)
+	viper.SetDefault("host_view", cfg.HostView)
 	viper.SetDefault("debug", cfg.Debug)
+	viper.SetDefault("debug_overlay", cfg.DebugOverlay)
 
 	// Read config file if it exists
 	viper.SetConfigName("config")
@@ -140,11 +535,19 @@ func Load() (*Config, error) {
 		}
 	}
 
-	// Unmarshal config
-	if err := viper.Unmarshal(cfg); err != nil {
+	// Unmarshal config; bare numbers in duration fields mean seconds
+	if err := viper.Unmarshal(cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		secondsToDurationHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToWeakSliceHookFunc(","),
+	))); err != nil {
 		return nil, err
 	}
 
+	if cfg.LowPower {
+		cfg.ApplyLowPower()
+	}
+
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -153,6 +556,33 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// secondsToDurationHook decodes plain numbers ("refresh.cpu: 1", or "1" from
+// an environment variable) into durations as seconds. Without it they would
+// be taken as nanoseconds and floored to the minimum interval. Values that
+// already are durations, such as the defaults, pass through unchanged.
+func secondsToDurationHook(from, to reflect.Type, value any) (any, error) {
+	durationType := reflect.TypeOf(time.Duration(0))
+	if to != durationType || from == durationType {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case uint64:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case string:
+		if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(seconds * float64(time.Second)), nil
+		}
+	}
+	return value, nil
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	// Validate refresh intervals (minimum 100ms)
@@ -178,6 +608,51 @@ func (c *Config) Validate() error {
 	if c.Refresh.Host < minInterval {
 		c.Refresh.Host = minInterval
 	}
+	if c.Refresh.Processes < minInterval {
+		c.Refresh.Processes = minInterval
+	}
+	if c.Refresh.GPU < minInterval {
+		c.Refresh.GPU = minInterval
+	}
+	if c.Refresh.Containers < minInterval {
+		c.Refresh.Containers = minInterval
+	}
+	if c.Refresh.Bandwidth < minInterval {
+		c.Refresh.Bandwidth = minInterval
+	}
+	if c.Refresh.Connections < minInterval {
+		c.Refresh.Connections = minInterval
+	}
+	if c.Refresh.Services < minInterval {
+		c.Refresh.Services = minInterval
+	}
+	if c.Refresh.Kubernetes < minInterval {
+		c.Refresh.Kubernetes = minInterval
+	}
+	if c.Refresh.VMs < minInterval {
+		c.Refresh.VMs = minInterval
+	}
+	if c.Refresh.Pi < minInterval {
+		c.Refresh.Pi = minInterval
+	}
+	if c.Refresh.Ping < minInterval {
+		c.Refresh.Ping = minInterval
+	}
+	if c.Refresh.Clock < minInterval {
+		c.Refresh.Clock = minInterval
+	}
+	if c.Refresh.Conntrack < minInterval {
+		c.Refresh.Conntrack = minInterval
+	}
+	if c.Refresh.UPS < minInterval {
+		c.Refresh.UPS = minInterval
+	}
+	if c.Refresh.Updates < minInterval {
+		c.Refresh.Updates = minInterval
+	}
+	if c.Refresh.Certs < minInterval {
+		c.Refresh.Certs = minInterval
+	}
 
 	// Validate display precision (0-3 decimal places)
 	if c.Display.Precision < 0 {
@@ -192,10 +667,89 @@ func (c *Config) Validate() error {
 		c.Display.Theme = "auto"
 	}
 
+	// Validate value display; hiding both would leave usage blank
+	if !c.Display.ShowPercentages && !c.Display.ShowAbsolute {
+		c.Display.ShowPercentages = true
+		c.Display.ShowAbsolute = true
+	}
+
+	// Validate CPU display mode
+	if c.Display.CPUMode != "percent" && c.Display.CPUMode != "cores" {
+		c.Display.CPUMode = "percent"
+	}
+
+	// Validate timezone; unknown names fall back to local time
+	if c.Display.Timezone != "" {
+		if _, err := time.LoadLocation(c.Display.Timezone); err != nil {
+			c.Display.Timezone = ""
+		}
+	}
+
+	// Validate color overrides; invalid hex values fall back to the theme
+	for _, color := range []*string{
+		&c.Display.Colors.Normal, &c.Display.Colors.Warning, &c.Display.Colors.Critical,
+		&c.Display.Colors.Label, &c.Display.Colors.Muted, &c.Display.Colors.Title,
+		&c.Display.Colors.Accent, &c.Display.Colors.Text, &c.Display.Colors.Border,
+	} {
+		if *color != "" && !hexColor.MatchString(*color) {
+			*color = ""
+		}
+	}
+
+	// Validate busy weights; negatives count as 0, all zero restores defaults
+	for _, weight := range []*float64{
+		&c.Display.BusyWeights.CPU, &c.Display.BusyWeights.IOWait, &c.Display.BusyWeights.Load,
+	} {
+		if *weight < 0 {
+			*weight = 0
+		}
+	}
+	if c.Display.BusyWeights == (BusyWeights{}) {
+		c.Display.BusyWeights = DefaultConfig().Display.BusyWeights
+	}
+
+	// Validate memory pressure weights the same way; the saturation rate
+	// must be positive for swap to count
+	for _, weight := range []*float64{
+		&c.Memory.Pressure.Available, &c.Memory.Pressure.Swap,
+	} {
+		if *weight < 0 {
+			*weight = 0
+		}
+	}
+	if c.Memory.Pressure.SwapFullMiB <= 0 {
+		c.Memory.Pressure.SwapFullMiB = DefaultConfig().Memory.Pressure.SwapFullMiB
+	}
+	if c.Memory.Pressure.Available == 0 && c.Memory.Pressure.Swap == 0 {
+		c.Memory.Pressure = DefaultConfig().Memory.Pressure
+	}
+
 	// Validate thresholds (0-100 range)
 	validateThreshold(&c.Threshold.CPUWarning, &c.Threshold.CPUCritical)
 	validateThreshold(&c.Threshold.MemWarning, &c.Threshold.MemCritical)
 	validateThreshold(&c.Threshold.TempWarning, &c.Threshold.TempCritical)
+	validateThreshold(&c.Threshold.PressureWarning, &c.Threshold.PressureCritical)
+	validateThreshold(&c.Threshold.InodeWarning, &c.Threshold.InodeCritical)
+	validateThreshold(&c.Threshold.ConntrackWarning, &c.Threshold.ConntrackCritical)
+	validateThreshold(&c.Process.CPUWarning, &c.Process.CPUCritical)
+	validateThreshold(&c.Process.MemWarning, &c.Process.MemCritical)
+
+	// Validate clock offset thresholds (milliseconds, not a percentage)
+	if c.Threshold.ClockOffsetCritical <= 0 {
+		c.Threshold.ClockOffsetCritical = DefaultConfig().Threshold.ClockOffsetCritical
+	}
+	if c.Threshold.ClockOffsetWarning < 0 || c.Threshold.ClockOffsetWarning >= c.Threshold.ClockOffsetCritical {
+		c.Threshold.ClockOffsetWarning = c.Threshold.ClockOffsetCritical / 2
+	}
+
+	// Validate certificate expiry thresholds (days left, so warning is the
+	// larger of the two)
+	if c.Threshold.CertExpiryCritical <= 0 {
+		c.Threshold.CertExpiryCritical = DefaultConfig().Threshold.CertExpiryCritical
+	}
+	if c.Threshold.CertExpiryWarning <= c.Threshold.CertExpiryCritical {
+		c.Threshold.CertExpiryWarning = c.Threshold.CertExpiryCritical * 4
+	}
 
 	// Validate page size (10-200)
 	if c.UI.PageSize < 10 {
@@ -205,9 +759,152 @@ func (c *Config) Validate() error {
 		c.UI.PageSize = 200
 	}
 
+	// Validate alert bar item cap (0 = fit to width)
+	if c.UI.AlertBarMaxItems < 0 {
+		c.UI.AlertBarMaxItems = 0
+	}
+
+	// Validate minimum link speed (0 = no warning)
+	if c.Network.MinLinkSpeed < 0 {
+		c.Network.MinLinkSpeed = 0
+	}
+
+	// Validate collector jitter (0-50%)
+	if c.Collectors.Jitter < 0 {
+		c.Collectors.Jitter = 0
+	}
+	if c.Collectors.Jitter > 0.5 {
+		c.Collectors.Jitter = 0.5
+	}
+
+	// Validate script collectors: drop incomplete entries, default the interval
+	scripts := c.Collectors.Scripts[:0]
+	for _, script := range c.Collectors.Scripts {
+		if script.Name == "" || script.Command == "" {
+			continue
+		}
+		if script.Interval <= 0 {
+			script.Interval = 10 * time.Second
+		}
+		if script.Interval < time.Second {
+			script.Interval = time.Second
+		}
+		scripts = append(scripts, script)
+	}
+	c.Collectors.Scripts = scripts
+
+	// Validate process limit (1-200)
+	if c.Process.Limit < 1 {
+		c.Process.Limit = 20
+	}
+	if c.Process.Limit > 200 {
+		c.Process.Limit = 200
+	}
+
+	// Validate process sort order
+	if !slices.Contains([]string{"cpu", "memory", "io", "gpu"}, c.Process.Sort) {
+		c.Process.Sort = "cpu"
+	}
+
+	// Validate UI mode
+	if c.UI.Mode != "dashboard" && c.UI.Mode != "top" && c.UI.Mode != "compact" {
+		c.UI.Mode = "dashboard"
+	}
+
+	// Validate dashboard layout, falling back to the default columns
+	for _, column := range c.Dashboard.Layout {
+		for i, panel := range column {
+			column[i] = strings.ToLower(strings.TrimSpace(panel))
+		}
+	}
+	if !validDashboardLayout(c.Dashboard.Layout) {
+		c.Dashboard.Layout = DefaultDashboardLayout()
+	}
+	if !validDashboardWidths(c.Dashboard.Widths, len(c.Dashboard.Layout)) {
+		c.Dashboard.Widths = nil
+	}
+	if !validDashboardHeights(c.Dashboard.Heights) {
+		c.Dashboard.Heights = nil
+	}
+
+	// Validate memory used basis
+	if c.Memory.UsedBasis != "gopsutil" && c.Memory.UsedBasis != "available" {
+		c.Memory.UsedBasis = "gopsutil"
+	}
+
+	// Validate navigation style
+	if c.UI.NavStyle != "sidebar" && c.UI.NavStyle != "topbar" {
+		c.UI.NavStyle = "sidebar"
+	}
+
 	return nil
 }
 
+// lowPowerIntervals are the shortest refresh intervals used in low power mode
+var lowPowerIntervals = RefreshConfig{
+	Interval:    5 * time.Second,
+	CPU:         5 * time.Second,
+	Memory:      5 * time.Second,
+	Disk:        15 * time.Second,
+	Network:     5 * time.Second,
+	Sensors:     15 * time.Second,
+	Host:        30 * time.Second,
+	Processes:   10 * time.Second,
+	GPU:         10 * time.Second,
+	Containers:  15 * time.Second,
+	Bandwidth:   10 * time.Second,
+	Connections: 15 * time.Second,
+	Services:    30 * time.Second,
+	Kubernetes:  60 * time.Second,
+	VMs:         30 * time.Second,
+	Pi:          30 * time.Second,
+	Ping:        60 * time.Second,
+	Clock:       120 * time.Second,
+	Conntrack:   30 * time.Second,
+	UPS:         60 * time.Second,
+	Updates:     6 * time.Hour,
+	Certs:       6 * time.Hour,
+}
+
+// ApplyLowPower adjusts the configuration for battery-sensitive use: every
+// refresh interval is raised to at least its low power floor, which also
+// slows UI redraws, and sparklines are turned off
+func (c *Config) ApplyLowPower() {
+	c.LowPower = true
+	for _, pair := range []struct {
+		interval *time.Duration
+		floor    time.Duration
+	}{
+		{&c.Refresh.Interval, lowPowerIntervals.Interval},
+		{&c.Refresh.CPU, lowPowerIntervals.CPU},
+		{&c.Refresh.Memory, lowPowerIntervals.Memory},
+		{&c.Refresh.Disk, lowPowerIntervals.Disk},
+		{&c.Refresh.Network, lowPowerIntervals.Network},
+		{&c.Refresh.Sensors, lowPowerIntervals.Sensors},
+		{&c.Refresh.Host, lowPowerIntervals.Host},
+		{&c.Refresh.Processes, lowPowerIntervals.Processes},
+		{&c.Refresh.GPU, lowPowerIntervals.GPU},
+		{&c.Refresh.Containers, lowPowerIntervals.Containers},
+		{&c.Refresh.Bandwidth, lowPowerIntervals.Bandwidth},
+		{&c.Refresh.Connections, lowPowerIntervals.Connections},
+		{&c.Refresh.Services, lowPowerIntervals.Services},
+		{&c.Refresh.Kubernetes, lowPowerIntervals.Kubernetes},
+		{&c.Refresh.VMs, lowPowerIntervals.VMs},
+		{&c.Refresh.Pi, lowPowerIntervals.Pi},
+		{&c.Refresh.Ping, lowPowerIntervals.Ping},
+		{&c.Refresh.Clock, lowPowerIntervals.Clock},
+		{&c.Refresh.Conntrack, lowPowerIntervals.Conntrack},
+		{&c.Refresh.UPS, lowPowerIntervals.UPS},
+		{&c.Refresh.Updates, lowPowerIntervals.Updates},
+		{&c.Refresh.Certs, lowPowerIntervals.Certs},
+	} {
+		if *pair.interval < pair.floor {
+			*pair.interval = pair.floor
+		}
+	}
+	c.Display.ShowGraphs = false
+}
+
 // validateThreshold ensures warning < critical and both are in range 0-100
 func validateThreshold(warning, critical *float64) {
 	if *warning < 0 {
@@ -233,11 +930,26 @@ func validateThreshold(warning, critical *float64) {
 // GetIntervalMap returns a map of collector intervals
 func (c *Config) GetIntervalMap() map[string]uint {
 	return map[string]uint{
-		"cpu":     uint(c.Refresh.CPU.Seconds()),
-		"memory":  uint(c.Refresh.Memory.Seconds()),
-		"disk":    uint(c.Refresh.Disk.Seconds()),
-		"network": uint(c.Refresh.Network.Seconds()),
-		"sensors": uint(c.Refresh.Sensors.Seconds()),
-		"host":    uint(c.Refresh.Host.Seconds()),
+		"cpu":         uint(c.Refresh.CPU.Seconds()),
+		"memory":      uint(c.Refresh.Memory.Seconds()),
+		"disk":        uint(c.Refresh.Disk.Seconds()),
+		"network":     uint(c.Refresh.Network.Seconds()),
+		"sensors":     uint(c.Refresh.Sensors.Seconds()),
+		"host":        uint(c.Refresh.Host.Seconds()),
+		"processes":   uint(c.Refresh.Processes.Seconds()),
+		"gpu":         uint(c.Refresh.GPU.Seconds()),
+		"containers":  uint(c.Refresh.Containers.Seconds()),
+		"bandwidth":   uint(c.Refresh.Bandwidth.Seconds()),
+		"connections": uint(c.Refresh.Connections.Seconds()),
+		"services":    uint(c.Refresh.Services.Seconds()),
+		"kubernetes":  uint(c.Refresh.Kubernetes.Seconds()),
+		"vms":         uint(c.Refresh.VMs.Seconds()),
+		"rpi":         uint(c.Refresh.Pi.Seconds()),
+		"ping":        uint(c.Refresh.Ping.Seconds()),
+		"clock":       uint(c.Refresh.Clock.Seconds()),
+		"conntrack":   uint(c.Refresh.Conntrack.Seconds()),
+		"ups":         uint(c.Refresh.UPS.Seconds()),
+		"updates":     uint(c.Refresh.Updates.Seconds()),
+		"certs":       uint(c.Refresh.Certs.Seconds()),
 	}
 }
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadRefreshSeconds(t *testing.T) {
+	tests := []struct {
+		value any
+		want  time.Duration
+	}{
+		{"1", time.Second},
+		{1, time.Second},
+		{"1.5", 1500 * time.Millisecond},
+		{"1s", time.Second},
+		{"500ms", 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Setenv("HOME", t.TempDir()) // Keep a real config file out of the way
+		viper.Reset()
+		t.Cleanup(viper.Reset)
+		viper.Set("refresh.cpu", tt.value)
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load with refresh.cpu %#v: %v", tt.value, err)
+		}
+		if cfg.Refresh.CPU != tt.want {
+			t.Errorf("refresh.cpu %#v: Refresh.CPU = %s, want %s", tt.value, cfg.Refresh.CPU, tt.want)
+		}
+	}
+}
+
+func TestLoadRefreshFromFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	dir := filepath.Join(home, ".config", "metrics-tui")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := "refresh:\n  cpu: 3s\n  vms: 20s\n  rpi: 42s\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Refresh.CPU != 3*time.Second {
+		t.Errorf("Refresh.CPU = %s, want 3s", cfg.Refresh.CPU)
+	}
+	if cfg.Refresh.VMs != 20*time.Second {
+		t.Errorf("Refresh.VMs = %s, want 20s", cfg.Refresh.VMs)
+	}
+	if cfg.Refresh.Pi != 42*time.Second {
+		t.Errorf("Refresh.Pi = %s, want 42s", cfg.Refresh.Pi)
+	}
+}
+
+func TestLoadDashboardHeights(t *testing.T) {
+	tests := []struct {
+		heights map[string]int
+		want    map[string]int
+	}{
+		{map[string]int{"memory": 2}, map[string]int{"memory": 2}},
+		{map[string]int{"memory": 2, "disk": 1}, nil},
+		{map[string]int{"network": 0}, nil},
+	}
+	for _, tt := range tests {
+		t.Setenv("HOME", t.TempDir())
+		viper.Reset()
+		t.Cleanup(viper.Reset)
+		viper.Set("dashboard.heights", tt.heights)
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load with dashboard.heights %v: %v", tt.heights, err)
+		}
+		if !maps.Equal(cfg.Dashboard.Heights, tt.want) {
+			t.Errorf("dashboard.heights %v: Heights = %v, want %v", tt.heights, cfg.Dashboard.Heights, tt.want)
+		}
+	}
+}
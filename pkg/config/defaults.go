@@ -18,14 +18,40 @@ refresh:
   network: 2s       # Network metrics update interval
   sensors: 5s       # Temperature sensors update interval
   host: 5s          # Host info update interval
+  processes: 3s     # Process list update interval (top mode)
+  gpu: 2s           # GPU metrics update interval
+  containers: 5s    # Container metrics update interval
+  bandwidth: 3s     # Per-process network bandwidth update interval
+  connections: 5s   # TCP/UDP connection table and listening ports update interval
+  services: 5s      # systemd service states and usage update interval
+  kubernetes: 10s   # Kubernetes pods on this node update interval
+  vms: 5s           # libvirt virtual machine update interval
+  rpi: 5s           # Raspberry Pi SoC and throttling update interval
+  ping: 10s         # Ping latency and loss update interval
+  clock: 30s        # NTP sync state and clock offset update interval
+  conntrack: 5s     # Connection tracking table usage update interval
+  ups: 10s          # UPS battery and power state update interval (NUT)
+  updates: 1h       # Pending package updates check interval
+  certs: 1h         # TLS certificate expiry check interval
 
 # Display settings
 display:
   theme: auto              # Theme: auto, dark, light
   show_graphs: true         # Enable sparkline graphs
   show_percentages: true    # Show percentage values
+  show_absolute: true       # Show sizes (memory, swap, disk); false = percent only
   precision: 1              # Decimal places (0-3)
   units: auto               # Unit system: auto, binary, decimal
+  cpu_mode: percent         # Total CPU as percent or busy cores
+  cpu_breakdown: true       # Stacked user/system/iowait CPU bar
+  timezone: ""              # IANA timezone or UTC; empty = local
+  show_trends: true         # Rising/falling arrows for CPU, memory, network
+  busy_weights:             # Header "busy" sparkline blend of cpu%, iowait%
+    cpu: 0.5                # and load1/cores (capped at 100%)
+    iowait: 0.2
+    load: 0.3
+  colors: {}                # Hex overrides: normal, warning, critical, label,
+                            # muted, title, accent, text, border
 
 # Alert thresholds (percentage or temperature)
 thresholds:
@@ -35,6 +61,16 @@ thresholds:
   memory_critical: 95       # Memory usage critical level (%)
   temp_warning: 70          # Temperature warning level (°C)
   temp_critical: 85         # Temperature critical level (°C)
+  pressure_warning: 60      # Memory pressure warning level (score 0-100)
+  pressure_critical: 85     # Memory pressure critical level (score 0-100)
+  inode_warning: 80         # Filesystem inode usage warning level (%)
+  inode_critical: 95        # Filesystem inode usage critical level (%)
+  clock_offset_warning: 100   # Clock offset from NTP warning level (ms)
+  clock_offset_critical: 1000 # Clock offset from NTP critical level (ms)
+  conntrack_warning: 80     # Conntrack table usage warning level (%)
+  conntrack_critical: 95    # Conntrack table usage critical level (%)
+  cert_expiry_warning: 30   # TLS certificate expiry warning level (days left)
+  cert_expiry_critical: 7   # TLS certificate expiry critical level (days left)
 
 # UI-specific settings
 ui:
@@ -42,10 +78,99 @@ ui:
   show_load_average: true   # Show load average in header
   show_uptime: true         # Show system uptime in header
   show_hostname: true       # Show hostname in header
+  mode: dashboard           # Layout: dashboard, top, compact
+  nav_style: sidebar        # Tab navigation: sidebar, topbar
+  alert_bar_max_items: 0    # Max alerts in the alert bar (0 = fit width)
+  confirm_quit: false       # Press q twice within 2s to quit (Ctrl+C always quits)
+  refresh_on_tab_switch: false # Collect a tab's metrics as soon as it is selected
+  clipboard: false          # y copies a metrics summary to the clipboard
+  mouse: true               # Click tabs and alerts, scroll with the wheel
+  pause_collection: false   # p also stops collection, not just screen updates
+
+# Dashboard view
+dashboard:
+  layout:                   # 2-4 columns of panels: cpu, memory, network, temperature
+    - [cpu]
+    - [temperature]
+    - [memory, network]
+  widths: []                # Relative column widths, e.g. [2, 1, 1]; empty for equal
+  heights: {}               # Relative heights of stacked panels, e.g. {memory: 2}; empty fits content
+
+# Process table coloring (top mode)
+process:
+  cpu_warning: 20           # Process CPU warning level (%)
+  cpu_critical: 50          # Process CPU critical level (%)
+  mem_warning: 20           # Process memory warning level (%)
+  mem_critical: 50          # Process memory critical level (%)
+  normalize_cpu: false      # CPU% relative to all cores instead of one
+  limit: 20                 # Top processes kept by CPU, by memory and by disk I/O (1-200)
+  sort: cpu                 # Initial process order: cpu, memory, io or gpu
+
+# Collector settings
+collectors:
+  disabled: []              # Collectors to turn off (cpu, memory, disk, network, sensors, host, processes, gpu, containers, bandwidth, connections, services, kubernetes, vms, rpi, ping, clock, conntrack, ups, certs)
+  jitter: 0                 # Timing spread per collector (0.1 = ±10%, 0 = off)
+  scripts:                  # External commands printing a number or {"value": n}
+    - name: queue_depth
+      command: "redis-cli llen jobs"
+      interval: 10s
+      unit: count
+      warning: 100
+      critical: 500
+
+# Network settings
+network:
+  exclude_loopback: true    # Hide the loopback interface
+  aliases: {}               # Display names, e.g. enp0s31f6: Ethernet
+  min_link_speed: 1000      # Flag wired links slower than this (Mb/s, 0 = off)
+  ping_targets: []          # Hosts to ping for RTT and loss, e.g. [192.168.1.1, 8.8.8.8]
+  tls_endpoints: []         # host:port endpoints whose certificate expiry is checked
+
+# CPU settings
+cpu:
+  logical: true             # Load percent per logical core; false = physical
+  power: true               # Package/core/DRAM watts from RAPL or powermetrics (needs root)
+  core_temps: true          # Core temperatures on the CPU core rows
+
+# Memory settings
+memory:
+  used_basis: gopsutil      # gopsutil, or available (used = total - available, like free)
+  pressure:                 # Pressure score blend of unavailable memory and
+    available: 0.6          # swap-in/out rate (saturated at swap_full_mib MiB/s)
+    swap: 0.4
+    swap_full_mib: 10
+
+# Disk settings
+disk:
+  show_device_info: false   # Show disk model/serial under each partition (Linux)
+  show_temperature: false   # Show drive temperature next to usage (Linux hwmon)
+  show_health: false        # Show NVMe wear, spare and media errors (nvme-cli, usually root)
+  labels: {}                # Display labels, e.g. /mnt/data/backups: Backups
+
+# Snapshot and export settings (s, e)
+snapshot:
+  anonymize: false          # Hash hostname, mask IPs, drop MACs/serials (also --anonymize)
+
+# UPS monitoring through NUT
+ups:
+  address: ""               # upsd host:port; empty uses localhost:3493 if running
+
+# Pending package updates (apt, dnf or pacman)
+updates:
+  enabled: false            # Count pending and security updates from cached metadata
+
+# Low power profile (also --low-power): longer refresh intervals, no sparklines
+low_power: false
+
+# Host CPU and memory totals instead of cgroup limits (also --host-view)
+host_view: false
 
 # Debug mode
 debug: false
 
+# Collector health overlay in the TUI (D)
+debug_overlay: false
+
 # Environment variables:
 # All config values can be overridden via environment variables with MONITOR_ prefix
 # Examples:
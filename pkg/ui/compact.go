@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/config"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// compactSparkWidth is the widest sparkline a compact line uses
+const compactSparkWidth = 12
+
+// CompactView renders one line per key metric (label, tiny sparkline,
+// value), small enough to embed in a tmux pane or status area
+type CompactView struct {
+	label    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	warning  lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	history  *data.HistoryData
+
+	cpuWarning   float64
+	cpuCritical  float64
+	memWarning   float64
+	memCritical  float64
+	tempWarning  float64
+	tempCritical float64
+
+	sparkline *components.SparkLine
+}
+
+// NewCompactView creates a new compact view
+func NewCompactView() *CompactView {
+	c := &CompactView{
+		sparkline:    components.NewSparkLine(),
+		cpuWarning:   70,
+		cpuCritical:  90,
+		memWarning:   80,
+		memCritical:  95,
+		tempWarning:  70,
+		tempCritical: 85,
+	}
+	c.SetTheme(components.DarkTheme())
+	return c
+}
+
+// SetTheme rebuilds the view styles from the given theme
+func (c *CompactView) SetTheme(theme *components.Theme) {
+	c.label = lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
+	c.value = lipgloss.NewStyle().Foreground(theme.Foreground)
+	c.muted = lipgloss.NewStyle().Foreground(theme.Comment)
+	c.warning = lipgloss.NewStyle().Foreground(theme.Orange)
+	c.critical = lipgloss.NewStyle().Foreground(theme.Red).Bold(true)
+	c.sparkline.SetTheme(theme)
+}
+
+// SetWidth sets the view width; every line fits within it
+func (c *CompactView) SetWidth(w int) {
+	c.width = w
+}
+
+// SetThresholds sets the warning/critical levels used to color values
+func (c *CompactView) SetThresholds(t config.ThresholdConfig) {
+	c.cpuWarning, c.cpuCritical = t.CPUWarning, t.CPUCritical
+	c.memWarning, c.memCritical = t.MemWarning, t.MemCritical
+	c.tempWarning, c.tempCritical = t.TempWarning, t.TempCritical
+}
+
+// SetHistory sets the history the sparklines are drawn from; nil hides them
+func (c *CompactView) SetHistory(history *data.HistoryData) {
+	c.history = history
+}
+
+// Render returns the rendered compact view
+func (c *CompactView) Render(systemData *data.SystemData) string {
+	if systemData == nil {
+		return c.muted.Render("Loading...")
+	}
+
+	var history data.HistoryData
+	if c.history != nil {
+		history = *c.history
+	}
+
+	var lines []string
+
+	if cpu := systemData.CPU; cpu != nil {
+		value := c.levelStyle(cpu.Total, c.cpuWarning, c.cpuCritical).Render(fmt.Sprintf("%3.0f%%", cpu.Total))
+		lines = append(lines, c.renderLine("CPU", history.CPU, value))
+	} else {
+		lines = append(lines, c.renderLine("CPU", nil, c.muted.Render("...")))
+	}
+
+	if mem := systemData.Memory; mem != nil {
+		value := c.levelStyle(mem.UsedPercent, c.memWarning, c.memCritical).Render(fmt.Sprintf("%3.0f%%", mem.UsedPercent))
+		lines = append(lines, c.renderLine("MEM", history.Memory, value))
+	} else {
+		lines = append(lines, c.renderLine("MEM", nil, c.muted.Render("...")))
+	}
+
+	if systemData.Network != nil {
+		value := c.muted.Render("...")
+		if rx, tx := history.Network.Rx, history.Network.Tx; len(rx) > 0 && len(tx) > 0 {
+			value = c.value.Render(fmt.Sprintf("↓%s ↑%s", compactRate(rx[len(rx)-1]), compactRate(tx[len(tx)-1])))
+		}
+		lines = append(lines, c.renderLine("NET", history.Network.Rx, value))
+	}
+
+	if sensors := systemData.Sensors; sensors != nil && len(sensors.Temperatures) > 0 {
+		maxTemp := 0.0
+		for _, temp := range sensors.Temperatures {
+			if temp.Temperature > maxTemp {
+				maxTemp = temp.Temperature
+			}
+		}
+		value := c.levelStyle(maxTemp, c.tempWarning, c.tempCritical).Render(fmt.Sprintf("%3.0f°C", maxTemp))
+		lines = append(lines, c.renderLine("TMP", nil, value))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderLine renders "LABEL sparkline value", shrinking or dropping the
+// sparkline so the line fits the view width
+func (c *CompactView) renderLine(label string, history []float64, value string) string {
+	line := c.label.Render(label) + " "
+
+	sparkWidth := min(compactSparkWidth, c.width-lipgloss.Width(line)-lipgloss.Width(value)-1)
+	if len(history) > 0 && sparkWidth >= 3 {
+		c.sparkline.SetWidth(sparkWidth)
+		c.sparkline.SetData(history)
+		c.sparkline.SetStyle(c.muted)
+		line += c.sparkline.Render() + " "
+	}
+
+	line += value
+	if c.width > 0 && lipgloss.Width(line) > c.width {
+		line = lipgloss.NewStyle().MaxWidth(c.width).Render(line)
+	}
+	return line
+}
+
+// levelStyle picks the value style for a warning/critical level
+func (c *CompactView) levelStyle(value, warning, critical float64) lipgloss.Style {
+	if value >= critical {
+		return c.critical
+	}
+	if value >= warning {
+		return c.warning
+	}
+	return c.value
+}
+
+// compactRate formats a byte rate in as few characters as possible,
+// e.g. "512B", "1.2K", "34M"
+func compactRate(bytesPerSec float64) string {
+	const unit = 1024
+	if bytesPerSec < unit {
+		return fmt.Sprintf("%.0fB", bytesPerSec)
+	}
+	value := bytesPerSec / unit
+	suffix := 'K'
+	for _, next := range "MGT" {
+		if value < unit {
+			break
+		}
+		value /= unit
+		suffix = next
+	}
+	if value < 10 {
+		return fmt.Sprintf("%.1f%c", value, suffix)
+	}
+	return fmt.Sprintf("%.0f%c", value, suffix)
+}
@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,13 +21,14 @@ const (
 
 // Alert represents a single alert
 type Alert struct {
-	Severity    AlertSeverity
-	Message     string
-	Timestamp   time.Time
-	TriggerTime time.Time
-	Value       float64
-	Threshold   float64
-	Metric      string
+	Severity     AlertSeverity
+	Message      string
+	Timestamp    time.Time
+	TriggerTime  time.Time
+	Value        float64
+	Threshold    float64
+	Metric       string
+	Acknowledged bool // Hidden from the alert bar until it clears or changes severity
 }
 
 // AlertManager manages active alerts
@@ -37,6 +39,7 @@ type AlertManager struct {
 	history      []Alert
 	maxHistory   int
 	enabled      bool
+	location     *time.Location
 }
 
 // ThresholdConfig defines alert thresholds
@@ -53,6 +56,7 @@ func NewAlertManager() *AlertManager {
 		history:    make([]Alert, 0, 100),
 		maxHistory: 100,
 		enabled:    true,
+		location:   time.Local,
 	}
 }
 
@@ -66,6 +70,13 @@ func (a *AlertManager) SetThreshold(metric string, warning, critical float64) {
 	}
 }
 
+// SetLocation sets the timezone alerts are timestamped in
+func (a *AlertManager) SetLocation(loc *time.Location) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.location = loc
+}
+
 // SetEnabled enables or disables alerting
 func (a *AlertManager) SetEnabled(enabled bool) {
 	a.mu.Lock()
@@ -102,11 +113,12 @@ func (a *AlertManager) CheckValue(metric string, value float64) {
 	if alertMsg != "" {
 		// Check if we already have an alert for this metric
 		if existing, ok := a.alerts[key]; !ok || existing.Severity != severity {
+			now := time.Now().In(a.location)
 			alert := &Alert{
 				Severity:    severity,
 				Message:     alertMsg,
-				Timestamp:   time.Now(),
-				TriggerTime: time.Now(),
+				Timestamp:   now,
+				TriggerTime: now,
 				Value:       value,
 				Threshold:   threshold.Warning,
 				Metric:      metric,
@@ -127,7 +139,54 @@ func (a *AlertManager) CheckValue(metric string, value float64) {
 	}
 }
 
-// GetActiveAlerts returns all active alerts
+// SetStateAlert raises an alert for a condition that has no numeric
+// threshold, such as a degraded RAID array. An empty message clears it.
+func (a *AlertManager) SetStateAlert(metric string, severity AlertSeverity, message string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if message == "" {
+		delete(a.alerts, metric)
+		return
+	}
+	if !a.enabled {
+		return
+	}
+	if existing, ok := a.alerts[metric]; ok && existing.Severity == severity {
+		// Keep the trigger time, but show the latest details
+		existing.Message = message
+		return
+	}
+
+	now := time.Now().In(a.location)
+	alert := &Alert{
+		Severity:    severity,
+		Message:     message,
+		Timestamp:   now,
+		TriggerTime: now,
+		Metric:      metric,
+	}
+	a.alerts[metric] = alert
+	a.history = append(a.history, *alert)
+
+	// Trim history
+	if len(a.history) > a.maxHistory {
+		a.history = a.history[1:]
+	}
+}
+
+// Acknowledge hides an active alert from the alert bar. It stays active,
+// and shows again if its severity changes or it clears and comes back.
+func (a *AlertManager) Acknowledge(metric string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if alert, ok := a.alerts[metric]; ok {
+		alert.Acknowledged = true
+	}
+}
+
+// GetActiveAlerts returns all active alerts, most severe first and then
+// oldest first, so the order is stable between renders
 func (a *AlertManager) GetActiveAlerts() []Alert {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -136,6 +195,16 @@ func (a *AlertManager) GetActiveAlerts() []Alert {
 	for _, alert := range a.alerts {
 		alerts = append(alerts, *alert)
 	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Severity != alerts[j].Severity {
+			return alerts[i].Severity > alerts[j].Severity
+		}
+		if !alerts[i].TriggerTime.Equal(alerts[j].TriggerTime) {
+			return alerts[i].TriggerTime.Before(alerts[j].TriggerTime)
+		}
+		return alerts[i].Metric < alerts[j].Metric
+	})
 	return alerts
 }
 
@@ -162,23 +231,28 @@ type AlertBar struct {
 	style        lipgloss.Style
 	warningStyle lipgloss.Style
 	criticalStyle lipgloss.Style
+	mutedStyle   lipgloss.Style
 	width        int
+	maxItems     int // 0 = as many as fit the width
 	visible      bool
 }
 
 // NewAlertBar creates a new alert bar
 func NewAlertBar(manager *AlertManager) *AlertBar {
-	var colorForeground = lipgloss.Color("#f8f8f2")
-	var colorOrange = lipgloss.Color("#ffb86c")
-	var colorRed = lipgloss.Color("#ff5555")
-
-	return &AlertBar{
-		manager:      manager,
-		style:        lipgloss.NewStyle().Foreground(colorForeground),
-		warningStyle: lipgloss.NewStyle().Foreground(colorOrange).Bold(true),
-		criticalStyle: lipgloss.NewStyle().Foreground(colorRed).Bold(true),
-		visible:      false,
+	a := &AlertBar{
+		manager: manager,
+		visible: false,
 	}
+	a.SetTheme(DarkTheme())
+	return a
+}
+
+// SetTheme rebuilds the alert bar styles from the given theme
+func (a *AlertBar) SetTheme(t *Theme) {
+	a.style = lipgloss.NewStyle().Foreground(t.Foreground)
+	a.warningStyle = lipgloss.NewStyle().Foreground(t.Orange).Bold(true)
+	a.criticalStyle = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	a.mutedStyle = lipgloss.NewStyle().Foreground(t.Comment)
 }
 
 // SetWidth sets the width
@@ -186,6 +260,14 @@ func (a *AlertBar) SetWidth(w int) {
 	a.width = w
 }
 
+// SetMaxItems sets the maximum number of alerts shown (0 = fit to width)
+func (a *AlertBar) SetMaxItems(n int) {
+	if n < 0 {
+		n = 0
+	}
+	a.maxItems = n
+}
+
 // Show shows the alert bar
 func (a *AlertBar) Show() {
 	a.visible = true
@@ -196,22 +278,88 @@ func (a *AlertBar) Hide() {
 	a.visible = false
 }
 
+// barItem is one rendered entry of the alert bar
+type barItem struct {
+	metric string // Alert shown, empty for the overflow label
+	text   string
+}
+
+const alertSeparator = " | "
+
 // Render returns the rendered alert bar
 func (a *AlertBar) Render() string {
+	var parts []string
+	for _, item := range a.items() {
+		parts = append(parts, item.text)
+	}
+	return strings.Join(parts, alertSeparator)
+}
+
+// AlertAt returns the metric of the alert rendered at column x of the
+// bar, or "" if there is none there
+func (a *AlertBar) AlertAt(x int) string {
+	pos := 0
+	for i, item := range a.items() {
+		if i > 0 {
+			pos += lipgloss.Width(alertSeparator)
+		}
+		width := lipgloss.Width(item.text)
+		if x >= pos && x < pos+width {
+			return item.metric
+		}
+		pos += width
+	}
+	return ""
+}
+
+// items lays out the unacknowledged alerts that fit the bar, followed by
+// a count of those that didn't
+func (a *AlertBar) items() []barItem {
 	if !a.visible {
-		return ""
+		return nil
 	}
 
-	alerts := a.manager.GetActiveAlerts()
+	var alerts []Alert
+	for _, alert := range a.manager.GetActiveAlerts() {
+		if !alert.Acknowledged {
+			alerts = append(alerts, alert)
+		}
+	}
 	if len(alerts) == 0 {
-		return ""
+		return nil
 	}
 
-	var b strings.Builder
+	// Alerts arrive critical-first, so truncation drops the least severe
+	limit := len(alerts)
+	if a.maxItems > 0 && limit > a.maxItems {
+		limit = a.maxItems
+	}
 
-	for i, alert := range alerts {
-		if i > 0 {
-			b.WriteString(" | ")
+	const minMessageWidth = 10
+
+	var parts []barItem
+	used := 0
+	for i := 0; i < limit; i++ {
+		alert := alerts[i]
+		msg := alert.TriggerTime.Format("15:04") + " " + alert.Message
+
+		if a.width > 0 {
+			if i > 0 {
+				used += lipgloss.Width(alertSeparator)
+			}
+
+			// Leave room for the overflow suffix if alerts remain after this one
+			reserve := 0
+			if remaining := len(alerts) - i - 1; remaining > 0 {
+				reserve = lipgloss.Width(overflowLabel(remaining)) + lipgloss.Width(alertSeparator)
+			}
+
+			avail := a.width - used - reserve
+			if avail < minMessageWidth {
+				break
+			}
+			msg = truncateString(msg, avail)
+			used += lipgloss.Width(msg)
 		}
 
 		var style lipgloss.Style
@@ -223,8 +371,17 @@ func (a *AlertBar) Render() string {
 			style = a.style
 		}
 
-		b.WriteString(style.Render(alert.Message))
+		parts = append(parts, barItem{metric: alert.Metric, text: style.Render(msg)})
 	}
 
-	return b.String()
+	if hidden := len(alerts) - len(parts); hidden > 0 {
+		parts = append(parts, barItem{text: a.mutedStyle.Render(overflowLabel(hidden))})
+	}
+
+	return parts
+}
+
+// overflowLabel returns the suffix shown for alerts that did not fit
+func overflowLabel(hidden int) string {
+	return fmt.Sprintf("+%d more", hidden)
 }
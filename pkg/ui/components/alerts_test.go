@@ -0,0 +1,33 @@
+package components
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGetActiveAlertsOrder(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewAlertManager()
+	m.alerts = map[string]*Alert{
+		"memory":      {Metric: "memory", Severity: Warning, TriggerTime: base.Add(2 * time.Minute)},
+		"cpu":         {Metric: "cpu", Severity: Critical, TriggerTime: base.Add(time.Minute)},
+		"disk":        {Metric: "disk", Severity: Warning, TriggerTime: base},
+		"temperature": {Metric: "temperature", Severity: Critical, TriggerTime: base.Add(3 * time.Minute)},
+		"load":        {Metric: "load", Severity: Info, TriggerTime: base},
+		// Same severity and trigger time as disk: falls back to the name
+		"conntrack": {Metric: "conntrack", Severity: Warning, TriggerTime: base},
+	}
+
+	want := []string{"cpu", "temperature", "conntrack", "disk", "memory", "load"}
+	for i := 0; i < 20; i++ {
+		alerts := m.GetActiveAlerts()
+		got := make([]string, len(alerts))
+		for j, alert := range alerts {
+			got[j] = alert.Metric
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("call %d: order = %v, want %v", i, got, want)
+		}
+	}
+}
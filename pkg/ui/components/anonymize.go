@@ -0,0 +1,95 @@
+package components
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"regexp"
+	"strconv"
+
+	gohost "github.com/shirou/gopsutil/v4/host"
+	gonet "github.com/shirou/gopsutil/v4/net"
+)
+
+// serialSuffix matches the serial number appended to disk device info
+var serialSuffix = regexp.MustCompile(`\s*\(S/N [^)]*\)$`)
+
+// anonymizeSnapshot redacts identifying data from a snapshot so it can be
+// shared: the hostname becomes a stable hash, host IDs, MAC addresses and
+// disk serials are dropped, and interface and session addresses and user
+// names are masked. Metric
+// values are left intact. The snapshot's nested metrics are shared with the
+// live system data, so anything changed is copied first.
+func anonymizeSnapshot(snapshot *Snapshot) {
+	if snapshot.Host != nil {
+		host := *snapshot.Host
+		if host.Info.Hostname != "" {
+			host.Info.Hostname = hashHostname(host.Info.Hostname)
+		}
+		host.Info.HostID = ""
+		if len(host.Users) > 0 {
+			host.Users = make([]gohost.UserStat, len(snapshot.Host.Users))
+			for i, user := range snapshot.Host.Users {
+				user.User = "redacted"
+				if user.Host != "" {
+					user.Host = maskAddr(user.Host)
+				}
+				host.Users[i] = user
+			}
+		}
+		snapshot.Host = &host
+	}
+
+	if snapshot.Network != nil {
+		network := *snapshot.Network
+		network.Interfaces = make([]gonet.InterfaceStat, len(snapshot.Network.Interfaces))
+		for i, iface := range snapshot.Network.Interfaces {
+			iface.HardwareAddr = ""
+			addrs := make(gonet.InterfaceAddrList, len(iface.Addrs))
+			for j, addr := range iface.Addrs {
+				addrs[j] = gonet.InterfaceAddr{Addr: maskAddr(addr.Addr)}
+			}
+			iface.Addrs = addrs
+			network.Interfaces[i] = iface
+		}
+		snapshot.Network = &network
+	}
+
+	if snapshot.Disk != nil && len(snapshot.Disk.Devices) > 0 {
+		disk := *snapshot.Disk
+		disk.Devices = make(map[string]string, len(snapshot.Disk.Devices))
+		for mount, info := range snapshot.Disk.Devices {
+			disk.Devices[mount] = serialSuffix.ReplaceAllString(info, "")
+		}
+		snapshot.Disk = &disk
+	}
+}
+
+// hashHostname replaces a hostname with a short hash, so snapshots from the
+// same machine can still be matched up
+func hashHostname(hostname string) string {
+	sum := sha256.Sum256([]byte(hostname))
+	return "host-" + hex.EncodeToString(sum[:4])
+}
+
+// maskAddr hides an interface address, keeping its family and prefix
+// length, e.g. "192.168.1.20/24" becomes "x.x.x.x/24"
+func maskAddr(addr string) string {
+	ip, ipNet, err := net.ParseCIDR(addr)
+	if err != nil {
+		ip = net.ParseIP(addr)
+		if ip == nil {
+			return "redacted"
+		}
+	}
+
+	masked := "x:x:x:x:x:x:x:x"
+	if ip.To4() != nil {
+		masked = "x.x.x.x"
+	}
+	if ipNet != nil {
+		ones, _ := ipNet.Mask.Size()
+		masked += "/" + strconv.Itoa(ones)
+	}
+	return masked
+}
@@ -0,0 +1,131 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// summaryMaxAlerts is how many active alerts a clipboard summary lists
+const summaryMaxAlerts = 3
+
+// Summary formats the current metrics and the most severe active alerts as
+// a few lines of plain text, for pasting into chat or tickets
+func Summary(systemData *data.SystemData, alerts *AlertManager, loc *time.Location) string {
+	if systemData == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	host := "this system"
+	if systemData.Host != nil && systemData.Host.Info.Hostname != "" {
+		host = systemData.Host.Info.Hostname
+	}
+	fmt.Fprintf(&b, "metrics-tui summary for %s at %s\n", host, time.Now().In(loc).Format("2006-01-02 15:04:05 MST"))
+
+	var parts []string
+	if cpu := systemData.CPU; cpu != nil {
+		parts = append(parts, fmt.Sprintf("CPU %.1f%%", cpu.Total))
+	}
+	if mem := systemData.Memory; mem != nil {
+		parts = append(parts, fmt.Sprintf("Mem %.1f%% (%s / %s)", mem.UsedPercent, formatBytes(mem.Used), formatBytes(mem.Total)))
+		if mem.Swap.Total > 0 {
+			parts = append(parts, fmt.Sprintf("Swap %.1f%%", mem.Swap.UsedPercent))
+		}
+	}
+	if systemData.Host != nil && systemData.Host.LoadAvg != nil {
+		load := systemData.Host.LoadAvg
+		parts = append(parts, fmt.Sprintf("Load %.2f %.2f %.2f", load.Load1, load.Load5, load.Load15))
+	}
+	if sensors := systemData.Sensors; sensors != nil && len(sensors.Temperatures) > 0 {
+		maxTemp := 0.0
+		for _, temp := range sensors.Temperatures {
+			if temp.Temperature > maxTemp {
+				maxTemp = temp.Temperature
+			}
+		}
+		parts = append(parts, fmt.Sprintf("Temp %.0f°C", maxTemp))
+	}
+	b.WriteString(strings.Join(parts, " | "))
+	b.WriteString("\n")
+
+	var active []Alert
+	if alerts != nil {
+		active = alerts.GetActiveAlerts()
+	}
+	if len(active) == 0 {
+		b.WriteString("Alerts: none")
+		return b.String()
+	}
+	messages := make([]string, 0, summaryMaxAlerts)
+	for i, alert := range active {
+		if i == summaryMaxAlerts {
+			messages = append(messages, fmt.Sprintf("and %d more", len(active)-i))
+			break
+		}
+		messages = append(messages, alert.Message)
+	}
+	b.WriteString("Alerts: " + strings.Join(messages, "; "))
+
+	return b.String()
+}
+
+// clipboardCommands are the native clipboard tools tried in order; each is
+// used only when its environment check passes
+var clipboardCommands = []struct {
+	name   string
+	args   []string
+	usable func() bool
+}{
+	{"pbcopy", nil, func() bool { return runtime.GOOS == "darwin" }},
+	{"clip.exe", nil, func() bool { return runtime.GOOS == "windows" || os.Getenv("WSL_DISTRO_NAME") != "" }},
+	{"wl-copy", nil, func() bool { return os.Getenv("WAYLAND_DISPLAY") != "" }},
+	{"xclip", []string{"-selection", "clipboard"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+	{"xsel", []string{"--clipboard", "--input"}, func() bool { return os.Getenv("DISPLAY") != "" }},
+}
+
+// CopyToClipboard puts text on the system clipboard and returns how it was
+// delivered. Over SSH, or when no native clipboard tool works, it falls back
+// to an OSC 52 escape sequence written to out, which the terminal emulator
+// turns into a clipboard update; terminals without OSC 52 support ignore it.
+func CopyToClipboard(text string, out io.Writer) (method string, err error) {
+	if os.Getenv("SSH_TTY") == "" && os.Getenv("SSH_CONNECTION") == "" {
+		for _, tool := range clipboardCommands {
+			if !tool.usable() {
+				continue
+			}
+			path, lookErr := exec.LookPath(tool.name)
+			if lookErr != nil {
+				continue
+			}
+			cmd := exec.Command(path, tool.args...)
+			cmd.Stdin = strings.NewReader(text)
+			if cmd.Run() == nil {
+				return tool.name, nil
+			}
+		}
+	}
+
+	if out == nil {
+		return "", errors.New("no clipboard available")
+	}
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	if _, err := seq.WriteTo(out); err != nil {
+		return "", fmt.Errorf("failed to write OSC 52 sequence: %w", err)
+	}
+	return "OSC 52", nil
+}
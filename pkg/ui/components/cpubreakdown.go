@@ -0,0 +1,74 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// CPUBreakdownBar renders aggregate CPU time as a stacked
+// user/system/iowait/other bar with a color legend
+type CPUBreakdownBar struct {
+	userStyle   lipgloss.Style
+	systemStyle lipgloss.Style
+	iowaitStyle lipgloss.Style
+	otherStyle  lipgloss.Style
+	mutedStyle  lipgloss.Style
+	bar         *ProgressBar
+}
+
+// NewCPUBreakdownBar creates a new CPU breakdown bar
+func NewCPUBreakdownBar() *CPUBreakdownBar {
+	c := &CPUBreakdownBar{
+		bar: NewProgressBar(),
+	}
+	c.SetTheme(DarkTheme())
+	return c
+}
+
+// SetTheme rebuilds the segment styles from the given theme
+func (c *CPUBreakdownBar) SetTheme(t *Theme) {
+	c.userStyle = lipgloss.NewStyle().Foreground(t.Green)
+	c.systemStyle = lipgloss.NewStyle().Foreground(t.Pink)
+	c.iowaitStyle = lipgloss.NewStyle().Foreground(t.Cyan)
+	c.otherStyle = lipgloss.NewStyle().Foreground(t.Purple)
+	c.mutedStyle = lipgloss.NewStyle().Foreground(t.Comment)
+	c.bar.SetTheme(t)
+}
+
+// SetWidth sets the bar width
+func (c *CPUBreakdownBar) SetWidth(w int) {
+	c.bar.SetWidth(w)
+}
+
+// Render returns the stacked bar
+func (c *CPUBreakdownBar) Render(b *data.CPUBreakdown) string {
+	return c.bar.RenderStacked([]BarSegment{
+		{Percent: b.User, Style: c.userStyle},
+		{Percent: b.System, Style: c.systemStyle},
+		{Percent: b.IOWait, Style: c.iowaitStyle},
+		{Percent: b.Other, Style: c.otherStyle},
+	})
+}
+
+// RenderLegend returns the color legend with each category's share
+func (c *CPUBreakdownBar) RenderLegend(b *data.CPUBreakdown) string {
+	entries := []struct {
+		name    string
+		percent float64
+		style   lipgloss.Style
+	}{
+		{"usr", b.User, c.userStyle},
+		{"sys", b.System, c.systemStyle},
+		{"io", b.IOWait, c.iowaitStyle},
+		{"oth", b.Other, c.otherStyle},
+	}
+
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = e.style.Render("■") + c.mutedStyle.Render(fmt.Sprintf(" %s %.1f%%", e.name, e.percent))
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,100 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// ExportBundle is everything the TUI tracks, gathered into one file
+type ExportBundle struct {
+	Version   int               `json:"version"`
+	Timestamp time.Time         `json:"timestamp"`
+	Snapshot  *Snapshot         `json:"snapshot"`
+	History   *data.HistoryData `json:"history"`
+	Alerts    []Alert           `json:"alerts"`
+}
+
+// Exporter writes full metric history bundles for bug reports
+type Exporter struct {
+	snapshots *SnapshotManager
+	outputDir string
+	location  *time.Location
+}
+
+// NewExporter creates an exporter writing to outputDir
+func NewExporter(outputDir string) *Exporter {
+	return &Exporter{
+		snapshots: NewSnapshotManager(outputDir, "json"),
+		outputDir: outputDir,
+		location:  time.Local,
+	}
+}
+
+// NewExporterWithDefaults creates an exporter writing next to snapshots
+func NewExporterWithDefaults() *Exporter {
+	return NewExporter(DefaultSnapshotDir())
+}
+
+// SetLocation sets the timezone for bundle timestamps and filenames
+func (e *Exporter) SetLocation(loc *time.Location) {
+	e.location = loc
+	e.snapshots.SetLocation(loc)
+}
+
+// SetAnonymize redacts hostnames, addresses and serials from the bundled
+// snapshot
+func (e *Exporter) SetAnonymize(anonymize bool) {
+	e.snapshots.SetAnonymize(anonymize)
+}
+
+// Bundle gathers the current state, history and alert history
+func (e *Exporter) Bundle(systemData *data.SystemData, history *data.HistoryData, alerts *AlertManager) (*ExportBundle, error) {
+	snapshot, err := e.snapshots.TakeSnapshot(systemData)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &ExportBundle{
+		Version:   SnapshotSchemaVersion,
+		Timestamp: snapshot.Timestamp,
+		Snapshot:  snapshot,
+		History:   history,
+		Alerts:    []Alert{},
+	}
+	if alerts != nil {
+		bundle.Alerts = alerts.GetHistory()
+	}
+
+	return bundle, nil
+}
+
+// Export writes a bundle to a timestamped file and returns its path
+func (e *Exporter) Export(systemData *data.SystemData, history *data.HistoryData, alerts *AlertManager) (string, error) {
+	bundle, err := e.Bundle(systemData, history, alerts)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(e.outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal export bundle: %w", err)
+	}
+
+	filepath := fmt.Sprintf("%s/monitor-export-%s.json",
+		e.outputDir,
+		bundle.Timestamp.Format("20060102-150405"),
+	)
+	if err := os.WriteFile(filepath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return filepath, nil
+}
@@ -1,24 +1,41 @@
 package components
 
 import (
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 // Footer displays the bottom bar with keybindings
 type Footer struct {
-	footerStyle lipgloss.Style
-	width       int
+	footerStyle  lipgloss.Style
+	messageStyle lipgloss.Style
+	width        int
+	message      string
+	messageUntil time.Time
 }
 
 // NewFooter creates a new footer component
 func NewFooter() *Footer {
-	var colorComment = lipgloss.Color("#6272a4")
+	f := &Footer{}
+	f.SetTheme(DarkTheme())
+	return f
+}
 
-	return &Footer{
-		footerStyle: lipgloss.NewStyle().
-			Foreground(colorComment).
-			Padding(0, 1),
-	}
+// SetTheme rebuilds the footer styles from the given theme
+func (f *Footer) SetTheme(t *Theme) {
+	f.footerStyle = lipgloss.NewStyle().
+		Foreground(t.Comment).
+		Padding(0, 1)
+	f.messageStyle = lipgloss.NewStyle().
+		Foreground(t.Pink).
+		Padding(0, 1)
+}
+
+// ShowMessage displays a transient message in place of the keybindings
+func (f *Footer) ShowMessage(msg string, d time.Duration) {
+	f.message = msg
+	f.messageUntil = time.Now().Add(d)
 }
 
 // SetWidth sets the footer width
@@ -28,6 +45,10 @@ func (f *Footer) SetWidth(w int) {
 
 // Render returns the rendered footer
 func (f *Footer) Render() string {
-	help := "[q] quit [h] help [s] snapshot [↑/↓] scroll"
+	if f.message != "" && time.Now().Before(f.messageUntil) {
+		return f.messageStyle.Width(f.width).Render(f.message)
+	}
+
+	help := "[q] quit [h] help [0-7] tabs [s] snapshot [T] theme [↑/↓] scroll"
 	return f.footerStyle.Width(f.width).Render(help)
 }
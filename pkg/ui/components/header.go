@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
@@ -10,19 +11,33 @@ import (
 // Header displays the top bar with host info
 type Header struct {
 	headerStyle lipgloss.Style
+	pausedStyle lipgloss.Style
 	width       int
+	location    *time.Location
+	busy        *SparkLine
+	busyHistory []float64
+	paused      bool
 }
 
 // NewHeader creates a new header component with default styles
 func NewHeader() *Header {
-	var colorCyan = lipgloss.Color("#8be9fd")
-
-	return &Header{
-		headerStyle: lipgloss.NewStyle().
-			Foreground(colorCyan).
-			Bold(true).
-			Padding(0, 1),
+	h := &Header{
+		location: time.Local,
+		busy:     NewSparkLine(),
 	}
+	h.busy.SetWidth(12)
+	h.SetTheme(DarkTheme())
+	return h
+}
+
+// SetTheme rebuilds the header styles from the given theme
+func (h *Header) SetTheme(t *Theme) {
+	h.headerStyle = lipgloss.NewStyle().
+		Foreground(t.Cyan).
+		Bold(true).
+		Padding(0, 1)
+	h.pausedStyle = lipgloss.NewStyle().Foreground(t.Orange).Bold(true)
+	h.busy.SetTheme(t)
 }
 
 // SetWidth sets the header width
@@ -30,6 +45,27 @@ func (h *Header) SetWidth(w int) {
 	h.width = w
 }
 
+// SetLocation sets the timezone used for the clock
+func (h *Header) SetLocation(loc *time.Location) {
+	h.location = loc
+}
+
+// SetBusyHistory sets the composite system busy series shown at the end of
+// the header; nil hides it
+func (h *Header) SetBusyHistory(history []float64) {
+	h.busyHistory = history
+}
+
+// SetPaused shows or hides the PAUSED indicator
+func (h *Header) SetPaused(paused bool) {
+	h.paused = paused
+}
+
+// Clock returns the current time formatted for the header
+func (h *Header) Clock(now time.Time) string {
+	return now.In(h.location).Format("15:04:05 MST")
+}
+
 // Render returns the rendered header
 func (h *Header) Render(systemData *data.SystemData) string {
 	if systemData == nil || systemData.Host == nil {
@@ -58,6 +94,19 @@ func (h *Header) Render(systemData *data.SystemData) string {
 		parts = append(parts, loadAvg)
 	}
 
+	// Clock
+	parts = append(parts, h.Clock(time.Now()))
+
+	// Composite busy trend goes last, as its colors end the header style
+	if len(h.busyHistory) > 0 {
+		h.busy.SetData(h.busyHistory)
+		latest := h.busyHistory[len(h.busyHistory)-1]
+		parts = append(parts, fmt.Sprintf("Busy: %3.0f%% %s", latest, h.busy.RenderWithColor(70, 90)))
+	}
+	if h.paused {
+		parts = append(parts, h.pausedStyle.Render("PAUSED"))
+	}
+
 	// Join parts with spacing
 	var content string
 	for i, part := range parts {
@@ -0,0 +1,29 @@
+package components
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeaderClockLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+	now := time.Date(2024, 1, 1, 12, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		loc  *time.Location
+		want string
+	}{
+		{time.UTC, "12:30:45 UTC"},
+		{tokyo, "21:30:45 JST"},
+	}
+	for _, tt := range tests {
+		h := NewHeader()
+		h.SetLocation(tt.loc)
+		if got := h.Clock(now); got != tt.want {
+			t.Errorf("Clock in %s = %q, want %q", tt.loc, got, tt.want)
+		}
+	}
+}
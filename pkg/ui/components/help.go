@@ -6,38 +6,49 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Help displays the help screen
+// Help displays the help screen as a bordered modal centered over the
+// terminal, scrolling when it is taller than the terminal
 type Help struct {
 	titleStyle   lipgloss.Style
 	headerStyle  lipgloss.Style
 	keyStyle     lipgloss.Style
 	descStyle    lipgloss.Style
 	footerStyle  lipgloss.Style
+	borderStyle  lipgloss.Style
 	visible      bool
 	width        int
 	height       int
+	viewport     *Viewport
 }
 
 // NewHelp creates a new help component
 func NewHelp() *Help {
-	var colorPurple = lipgloss.Color("#bd93f9")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorComment = lipgloss.Color("#6272a4")
-
-	return &Help{
-		titleStyle:  lipgloss.NewStyle().Foreground(colorPurple).Bold(true),
-		headerStyle: lipgloss.NewStyle().Foreground(colorCyan).Bold(true),
-		keyStyle:    lipgloss.NewStyle().Foreground(colorGreen),
-		descStyle:   lipgloss.NewStyle().Foreground(colorComment),
-		footerStyle: lipgloss.NewStyle().Foreground(colorComment).Italic(true),
-		visible:     false,
+	h := &Help{
+		visible:  false,
+		viewport: NewViewport(),
 	}
+	h.SetTheme(DarkTheme())
+	return h
 }
 
-// Show displays the help screen
+// SetTheme rebuilds the help styles from the given theme
+func (h *Help) SetTheme(t *Theme) {
+	h.titleStyle = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	h.headerStyle = lipgloss.NewStyle().Foreground(t.Cyan).Bold(true)
+	h.keyStyle = lipgloss.NewStyle().Foreground(t.Green)
+	h.descStyle = lipgloss.NewStyle().Foreground(t.Comment)
+	h.footerStyle = lipgloss.NewStyle().Foreground(t.Comment).Italic(true)
+	h.borderStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(1, 2)
+	h.viewport.SetTheme(t)
+}
+
+// Show displays the help screen, scrolled to the top
 func (h *Help) Show() {
 	h.visible = true
+	h.viewport.GotoTop()
 }
 
 // Hide hides the help screen
@@ -54,6 +65,19 @@ func (h *Help) IsVisible() bool {
 func (h *Help) SetSize(width, height int) {
 	h.width = width
 	h.height = height
+
+	// The border, padding and close hint take 6 lines
+	h.viewport.SetHeight(max(height-6, 1))
+}
+
+// ScrollUp scrolls the help text up
+func (h *Help) ScrollUp() {
+	h.viewport.ScrollUp()
+}
+
+// ScrollDown scrolls the help text down
+func (h *Help) ScrollDown() {
+	h.viewport.ScrollDown()
 }
 
 // Render returns the rendered help screen
@@ -74,9 +98,20 @@ func (h *Help) Render() string {
 	helpItems := [][]string{
 		{"q, Ctrl+C", "Quit the application"},
 		{"h, ?", "Show/hide this help screen"},
-		{"1-6", "Switch between metric panels"},
+		{"T", "Cycle color themes"},
+		{"D", "Collector health (debug_overlay only)"},
+		{"p", "Pause / resume updates"},
+		{"r", "Refresh all metrics now"},
+		{"R", "Clear all history and sparklines"},
+		{"o", "Sort processes by CPU, memory, disk I/O or GPU (top mode)"},
+		{"/", "Filter processes by name, user or PID (top mode)"},
+		{"x, X", "Send SIGTERM / SIGKILL to the selected process (top mode)"},
+		{"y", "Copy a metrics summary (ui.clipboard only)"},
+		{"0-9", "Switch between metric panels"},
+		{"Tab", "Next panel (Shift+Tab: previous)"},
 		{"↑, k", "Scroll up"},
 		{"↓, j", "Scroll down"},
+		{"Mouse", "Click a tab to switch, click an alert to acknowledge it, wheel to scroll"},
 	}
 
 	for _, item := range helpItems {
@@ -92,12 +127,21 @@ func (h *Help) Render() string {
 	b.WriteString(h.headerStyle.Render("Panels"))
 	b.WriteString("\n")
 	panelItems := [][]string{
+		{"0", "Dashboard - All metrics at a glance"},
 		{"1", "CPU - Processor usage and load"},
 		{"2", "Memory - RAM and swap usage"},
 		{"3", "Disk - Storage usage and I/O stats"},
 		{"4", "Network - Interface traffic statistics"},
 		{"5", "Temperature - Sensor readings"},
 		{"6", "Load - System load average"},
+		{"7", "Custom - Script metrics (when configured)"},
+		{"8", "GPU - Utilization, VRAM and temperature"},
+		{"9", "Containers - Per-container usage"},
+		{"Tab", "Connections - Open sockets by state"},
+		{"Tab", "Ports - Listening ports and their processes"},
+		{"Tab", "Services - systemd units, failed first"},
+		{"Tab", "Kubernetes - Pods on this node, requests vs usage"},
+		{"Tab", "VMs - libvirt domains with CPU, memory and I/O"},
 	}
 
 	for _, item := range panelItems {
@@ -125,45 +169,8 @@ func (h *Help) Render() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-
-	// Footer
-	b.WriteString(h.footerStyle.Render("Press any key to close"))
-
-	// Center the help content if we have space
-	content := b.String()
-	lines := strings.Split(content, "\n")
-
-	// Calculate padding to center
-	maxWidth := 0
-	for _, line := range lines {
-		if len(line) > maxWidth {
-			maxWidth = len(line)
-		}
-	}
-
-	padding := (h.width - maxWidth) / 2
-	if padding < 0 {
-		padding = 0
-	}
-
-	padStyle := lipgloss.NewStyle().Padding(0, padding)
-
-	// Add padding to each line and center vertically
-	var result strings.Builder
-	verticalPadding := (h.height - len(lines)) / 2
-	if verticalPadding < 0 {
-		verticalPadding = 0
-	}
-
-	for i := 0; i < verticalPadding; i++ {
-		result.WriteString("\n")
-	}
-
-	for _, line := range lines {
-		result.WriteString(padStyle.Render(line))
-		result.WriteString("\n")
-	}
+	h.viewport.SetContent(b.String())
+	modal := h.viewport.Render() + "\n\n" + h.footerStyle.Render("Press any key to close, ↑/↓ to scroll")
 
-	return result.String()
+	return lipgloss.Place(h.width, h.height, lipgloss.Center, lipgloss.Center, h.borderStyle.Render(modal))
 }
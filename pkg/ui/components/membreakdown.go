@@ -0,0 +1,82 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// MemoryBreakdownBar renders total memory as a stacked
+// apps/buffers/cache/slab/hugepages bar with a color legend
+type MemoryBreakdownBar struct {
+	appsStyle    lipgloss.Style
+	buffersStyle lipgloss.Style
+	cacheStyle   lipgloss.Style
+	slabStyle    lipgloss.Style
+	hugeStyle    lipgloss.Style
+	mutedStyle   lipgloss.Style
+	bar          *ProgressBar
+}
+
+// NewMemoryBreakdownBar creates a new memory breakdown bar
+func NewMemoryBreakdownBar() *MemoryBreakdownBar {
+	m := &MemoryBreakdownBar{
+		bar: NewProgressBar(),
+	}
+	m.SetTheme(DarkTheme())
+	return m
+}
+
+// SetTheme rebuilds the segment styles from the given theme
+func (m *MemoryBreakdownBar) SetTheme(t *Theme) {
+	m.appsStyle = lipgloss.NewStyle().Foreground(t.Green)
+	m.buffersStyle = lipgloss.NewStyle().Foreground(t.Purple)
+	m.cacheStyle = lipgloss.NewStyle().Foreground(t.Cyan)
+	m.slabStyle = lipgloss.NewStyle().Foreground(t.Pink)
+	m.hugeStyle = lipgloss.NewStyle().Foreground(t.Orange)
+	m.mutedStyle = lipgloss.NewStyle().Foreground(t.Comment)
+	m.bar.SetTheme(t)
+}
+
+// SetWidth sets the bar width
+func (m *MemoryBreakdownBar) SetWidth(w int) {
+	m.bar.SetWidth(w)
+}
+
+// Render returns the stacked bar; the empty remainder is free memory
+func (m *MemoryBreakdownBar) Render(b *data.MemoryBreakdown) string {
+	return m.bar.RenderStacked([]BarSegment{
+		{Percent: b.Apps, Style: m.appsStyle},
+		{Percent: b.Buffers, Style: m.buffersStyle},
+		{Percent: b.Cache, Style: m.cacheStyle},
+		{Percent: b.Slab, Style: m.slabStyle},
+		{Percent: b.HugePages, Style: m.hugeStyle},
+	})
+}
+
+// RenderLegend returns the color legend with each category's share. Huge
+// pages are left out on the usual systems without a pool.
+func (m *MemoryBreakdownBar) RenderLegend(b *data.MemoryBreakdown) string {
+	type entry struct {
+		name    string
+		percent float64
+		style   lipgloss.Style
+	}
+	entries := []entry{
+		{"app", b.Apps, m.appsStyle},
+		{"buf", b.Buffers, m.buffersStyle},
+		{"cache", b.Cache, m.cacheStyle},
+		{"slab", b.Slab, m.slabStyle},
+	}
+	if b.HugePages > 0 {
+		entries = append(entries, entry{"huge", b.HugePages, m.hugeStyle})
+	}
+
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = e.style.Render("■") + m.mutedStyle.Render(fmt.Sprintf(" %s %.1f%%", e.name, e.percent))
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// BandwidthMetrics renders per-process network traffic as a table
+type BandwidthMetrics struct {
+	title    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	table    *components.Table
+}
+
+// NewBandwidthMetrics creates a new per-process bandwidth renderer
+func NewBandwidthMetrics() *BandwidthMetrics {
+	b := &BandwidthMetrics{
+		table: components.NewTable([]components.Column{
+			{Title: "PID", Width: 7, Align: components.AlignRight},
+			{Title: "PROCESS", MinWidth: 12},
+			{Title: "CONN", Width: 5, Align: components.AlignRight},
+			{Title: "RECV", Width: 12, Align: components.AlignRight},
+			{Title: "SENT", Width: 12, Align: components.AlignRight},
+		}),
+	}
+	b.SetTheme(components.DarkTheme())
+	return b
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (b *BandwidthMetrics) SetTheme(t *components.Theme) {
+	b.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	b.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	b.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	b.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	b.table.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (b *BandwidthMetrics) SetWidth(w int) {
+	b.width = w
+	b.table.SetWidth(w)
+}
+
+// SetHeight sets the render height; rows beyond it are cut off
+func (b *BandwidthMetrics) SetHeight(h int) {
+	// Title, table header and footer take 6 lines
+	rows := 0
+	if h > 0 {
+		rows = max(h-6, 1)
+	}
+	b.table.SetHeight(rows)
+}
+
+// Render returns the rendered per-process bandwidth
+func (b *BandwidthMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "bandwidth"); state != data.StateReady {
+		return renderState(state, systemData, "bandwidth", "bandwidth", b.muted, b.critical)
+	}
+
+	processes := systemData.Bandwidth.Processes
+	var sb strings.Builder
+
+	// Title
+	sb.WriteString(b.title.Render("Bandwidth by Process"))
+	sb.WriteString(b.muted.Render(" (TCP)"))
+	sb.WriteString("\n\n")
+
+	if len(processes) == 0 {
+		sb.WriteString(b.muted.Render("No processes with TCP connections"))
+		return sb.String()
+	}
+
+	rows := make([]components.Row, 0, len(processes))
+	for _, process := range processes {
+		pid, style := strconv.Itoa(int(process.PID)), b.value
+		if process.PID == 0 {
+			// Sockets of processes we may not inspect (other users)
+			pid, style = "-", b.muted
+		}
+		rows = append(rows, components.Row{
+			{Text: pid, Style: b.muted},
+			{Text: process.Name, Style: style},
+			{Text: strconv.Itoa(process.Connections), Style: b.muted},
+			{Text: b.formatBytes(uint64(process.RxPerSec)) + "/s", Style: b.rateStyle(process.RxPerSec)},
+			{Text: b.formatBytes(uint64(process.TxPerSec)) + "/s", Style: b.rateStyle(process.TxPerSec)},
+		})
+	}
+	b.table.SetRows(rows)
+
+	sb.WriteString(b.table.Render())
+	sb.WriteString("\n\n")
+
+	first, last := b.table.VisibleRange()
+	sb.WriteString(b.muted.Render(fmt.Sprintf("Showing %d-%d of %d processes", first+1, last, len(processes))))
+
+	return sb.String()
+}
+
+// rateStyle dims idle connections so the busy processes stand out
+func (b *BandwidthMetrics) rateStyle(rate float64) lipgloss.Style {
+	if rate == 0 {
+		return b.muted
+	}
+	return b.value
+}
+
+func (b *BandwidthMetrics) formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// connectionStates is the order socket states are summarized in: the
+// lifecycle of a TCP connection, then listening and UDP sockets
+var connectionStates = []string{
+	"ESTABLISHED", "SYN_SENT", "SYN_RECV", "FIN_WAIT1", "FIN_WAIT2",
+	"TIME_WAIT", "CLOSE", "CLOSE_WAIT", "LAST_ACK", "CLOSING", "LISTEN", "UDP",
+}
+
+// ConnectionMetrics renders socket counts by state and a scrollable table
+// of every TCP and UDP socket
+type ConnectionMetrics struct {
+	title    lipgloss.Style
+	label    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	warning  lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	table    *components.Table
+}
+
+// NewConnectionMetrics creates a new connection metrics renderer
+func NewConnectionMetrics() *ConnectionMetrics {
+	c := &ConnectionMetrics{
+		table: components.NewTable([]components.Column{
+			{Title: "PROTO", Width: 5},
+			{Title: "LOCAL", MinWidth: 15},
+			{Title: "REMOTE", MinWidth: 15},
+			{Title: "STATE", Width: 11},
+			{Title: "PID", Width: 7, Align: components.AlignRight},
+			{Title: "PROCESS", Width: 16},
+		}),
+	}
+	c.SetTheme(components.DarkTheme())
+	return c
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (c *ConnectionMetrics) SetTheme(t *components.Theme) {
+	c.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	c.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	c.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	c.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	c.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	c.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	c.table.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (c *ConnectionMetrics) SetWidth(w int) {
+	c.width = w
+	c.table.SetWidth(w)
+}
+
+// SetHeight sets the render height; rows beyond it scroll
+func (c *ConnectionMetrics) SetHeight(h int) {
+	// Title, state summary, table header and footer take 8 lines
+	rows := 0
+	if h > 0 {
+		rows = max(h-8, 1)
+	}
+	c.table.SetHeight(rows)
+}
+
+// ScrollUp scrolls the connection table up one row
+func (c *ConnectionMetrics) ScrollUp() {
+	c.table.ScrollUp()
+}
+
+// ScrollDown scrolls the connection table down one row
+func (c *ConnectionMetrics) ScrollDown() {
+	c.table.ScrollDown()
+}
+
+// Render returns the rendered connection metrics
+func (c *ConnectionMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "connections"); state != data.StateReady {
+		return renderState(state, systemData, "connections", "connection", c.muted, c.critical)
+	}
+
+	metrics := systemData.Connections
+	var b strings.Builder
+
+	// Title
+	b.WriteString(c.title.Render("Connections"))
+	b.WriteString(c.muted.Render(fmt.Sprintf(" (%d sockets)", len(metrics.Connections))))
+	b.WriteString("\n\n")
+
+	b.WriteString(c.renderStates(metrics.States))
+	b.WriteString("\n\n")
+
+	if len(metrics.Connections) == 0 {
+		b.WriteString(c.muted.Render("No open TCP or UDP sockets"))
+		return b.String()
+	}
+
+	rows := make([]components.Row, 0, len(metrics.Connections))
+	for _, conn := range metrics.Connections {
+		pid, process := "-", conn.Process
+		if conn.PID > 0 {
+			pid = strconv.Itoa(int(conn.PID))
+		}
+		if process == "" {
+			process = "-"
+		}
+		remote := conn.RemoteAddr
+		if remote == "" {
+			remote = "-"
+		}
+		rows = append(rows, components.Row{
+			{Text: conn.Protocol, Style: c.muted},
+			{Text: conn.LocalAddr, Style: c.value},
+			{Text: remote, Style: c.value},
+			{Text: conn.Status, Style: c.stateStyle(conn.Status)},
+			{Text: pid, Style: c.muted},
+			{Text: process, Style: c.value},
+		})
+	}
+	c.table.SetRows(rows)
+
+	b.WriteString(c.table.Render())
+	b.WriteString("\n\n")
+
+	first, last := c.table.VisibleRange()
+	b.WriteString(c.muted.Render(fmt.Sprintf("Showing %d-%d of %d sockets (↑/↓ to scroll)", first+1, last, len(metrics.Connections))))
+
+	return b.String()
+}
+
+// renderStates renders the socket count of every state that has sockets
+func (c *ConnectionMetrics) renderStates(states map[string]int) string {
+	var parts []string
+	for _, state := range connectionStates {
+		if count := states[state]; count > 0 {
+			parts = append(parts, c.label.Render(state)+" "+c.stateStyle(state).Render(strconv.Itoa(count)))
+		}
+	}
+	if len(parts) == 0 {
+		return c.muted.Render("No sockets")
+	}
+	return strings.Join(parts, c.muted.Render(" · "))
+}
+
+// stateStyle highlights CLOSE_WAIT, where sockets pile up when an
+// application never closes connections the peer has closed
+func (c *ConnectionMetrics) stateStyle(state string) lipgloss.Style {
+	if state == "CLOSE_WAIT" {
+		return c.warning
+	}
+	return c.value
+}
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// ContainerMetrics renders per-container metrics as a table
+type ContainerMetrics struct {
+	title    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	normal   lipgloss.Style
+	warning  lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	table    *components.Table
+}
+
+// NewContainerMetrics creates a new container metrics renderer
+func NewContainerMetrics() *ContainerMetrics {
+	c := &ContainerMetrics{
+		table: components.NewTable([]components.Column{
+			{Title: "NAME", MinWidth: 12},
+			{Title: "CPU%", Width: 6, Align: components.AlignRight},
+			{Title: "MEMORY", Width: 19, Align: components.AlignRight},
+			{Title: "NET ↓", Width: 10, Align: components.AlignRight},
+			{Title: "NET ↑", Width: 10, Align: components.AlignRight},
+		}),
+	}
+	c.SetTheme(components.DarkTheme())
+	return c
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (c *ContainerMetrics) SetTheme(t *components.Theme) {
+	c.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	c.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	c.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	c.normal = lipgloss.NewStyle().Foreground(t.Green)
+	c.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	c.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	c.table.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (c *ContainerMetrics) SetWidth(w int) {
+	c.width = w
+	c.table.SetWidth(w)
+}
+
+// SetHeight sets the render height; rows beyond it are cut off
+func (c *ContainerMetrics) SetHeight(h int) {
+	// Title, table header and footer take 6 lines
+	rows := 0
+	if h > 0 {
+		rows = max(h-6, 1)
+	}
+	c.table.SetHeight(rows)
+}
+
+// Render returns the rendered container metrics
+func (c *ContainerMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "containers"); state != data.StateReady {
+		return renderState(state, systemData, "containers", "container", c.muted, c.critical)
+	}
+
+	metrics := systemData.Containers
+	var b strings.Builder
+
+	// Title
+	b.WriteString(c.title.Render("Containers"))
+	if len(metrics.Runtimes) > 0 {
+		b.WriteString(c.muted.Render(" (" + strings.Join(metrics.Runtimes, ", ") + ")"))
+	}
+	b.WriteString("\n\n")
+
+	if len(metrics.Runtimes) == 0 {
+		b.WriteString(c.muted.Render("No container runtime found"))
+		b.WriteString("\n")
+		b.WriteString(c.muted.Render("(looked for Docker and Podman sockets and containerd state)"))
+		return b.String()
+	}
+	if len(metrics.Containers) == 0 {
+		b.WriteString(c.muted.Render("No running containers"))
+		return b.String()
+	}
+
+	rows := make([]components.Row, 0, len(metrics.Containers))
+	for _, container := range metrics.Containers {
+		memory := c.formatBytes(container.MemoryUsed)
+		memStyle := c.value
+		if container.MemoryLimit > 0 {
+			memory += " / " + c.formatBytes(container.MemoryLimit)
+			memStyle = c.getMetricStyle(float64(container.MemoryUsed)/float64(container.MemoryLimit)*100, 80, 95)
+		}
+		rows = append(rows, components.Row{
+			{Text: container.Name, Style: c.value},
+			{Text: fmt.Sprintf("%.1f", container.CPU), Style: c.getMetricStyle(container.CPU, 70, 90)},
+			{Text: memory, Style: memStyle},
+			{Text: c.formatBytes(uint64(container.NetRxPerSec)) + "/s", Style: c.muted},
+			{Text: c.formatBytes(uint64(container.NetTxPerSec)) + "/s", Style: c.muted},
+		})
+	}
+	c.table.SetRows(rows)
+
+	b.WriteString(c.table.Render())
+	b.WriteString("\n\n")
+
+	first, last := c.table.VisibleRange()
+	b.WriteString(c.muted.Render(fmt.Sprintf("Showing %d-%d of %d containers", first+1, last, len(metrics.Containers))))
+
+	if partial := renderPartial(systemData, "containers", c.warning); partial != "" {
+		b.WriteString("\n")
+		b.WriteString(partial)
+	}
+
+	return b.String()
+}
+
+func (c *ContainerMetrics) getMetricStyle(value float64, warning, critical float64) lipgloss.Style {
+	if value >= critical {
+		return c.critical
+	}
+	if value >= warning {
+		return c.warning
+	}
+	return c.normal
+}
+
+func (c *ContainerMetrics) formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
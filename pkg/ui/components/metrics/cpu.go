@@ -2,7 +2,10 @@ package metrics
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
@@ -22,34 +25,47 @@ type CPUMetrics struct {
 	width         int
 	progressBar   *components.ProgressBar
 	sparkline     *components.SparkLine
+	powerLine     *components.SparkLine
 	scrollOffset  int
 	visibleCores  int
 	totalCoreRows int
+	mode          string // percent or cores
+	breakdown     bool
+	coreTemps     bool
+	breakdownBar  *components.CPUBreakdownBar
+	trendArrow    *components.TrendIndicator
+	trend         components.Trend
 }
 
 // NewCPUMetrics creates a new CPU metrics renderer
 func NewCPUMetrics() *CPUMetrics {
-	var colorForeground = lipgloss.Color("#f8f8f2")
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorPurple = lipgloss.Color("#bd93f9")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorOrange = lipgloss.Color("#ffb86c")
-	var colorRed = lipgloss.Color("#ff5555")
-
-	return &CPUMetrics{
-		sectionTitle: lipgloss.NewStyle().Foreground(colorPurple).Bold(true),
-		label:        lipgloss.NewStyle().Foreground(colorCyan),
-		value:        lipgloss.NewStyle().Foreground(colorForeground),
-		muted:        lipgloss.NewStyle().Foreground(colorComment),
-		normal:       lipgloss.NewStyle().Foreground(colorGreen),
-		warning:      lipgloss.NewStyle().Foreground(colorOrange),
-		critical:     lipgloss.NewStyle().Foreground(colorRed).Bold(true),
+	c := &CPUMetrics{
 		progressBar:  components.NewProgressBar(),
 		sparkline:    components.NewSparkLine(),
+		powerLine:    components.NewSparkLine(),
+		breakdownBar: components.NewCPUBreakdownBar(),
+		trendArrow:   components.NewTrendIndicator(),
 		scrollOffset: 0,
 		visibleCores: 16, // Show 16 cores at a time (8 rows of 2)
 	}
+	c.SetTheme(components.DarkTheme())
+	return c
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (c *CPUMetrics) SetTheme(t *components.Theme) {
+	c.sectionTitle = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	c.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	c.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	c.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	c.normal = lipgloss.NewStyle().Foreground(t.Green)
+	c.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	c.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	c.progressBar.SetTheme(t)
+	c.sparkline.SetTheme(t)
+	c.powerLine.SetTheme(t)
+	c.breakdownBar.SetTheme(t)
+	c.trendArrow.SetTheme(t)
 }
 
 // SetWidth sets the render width
@@ -61,11 +77,40 @@ func (c *CPUMetrics) SetWidth(w int) {
 		sparkWidth = 10
 	}
 	c.sparkline.SetWidth(sparkWidth)
+	c.powerLine.SetWidth(sparkWidth)
+}
+
+// SetMode sets how total usage is shown: "percent" or "cores" (busy cores)
+func (c *CPUMetrics) SetMode(mode string) {
+	c.mode = mode
+}
+
+// SetBreakdown enables the stacked user/system/iowait total bar
+func (c *CPUMetrics) SetBreakdown(enabled bool) {
+	c.breakdown = enabled
 }
 
-// SetHistory sets the historical data for sparklines
-func (c *CPUMetrics) SetHistory(data []float64) {
+// SetCoreTemps shows each core's temperature next to its usage, where the
+// CPU has per-core sensors
+func (c *CPUMetrics) SetCoreTemps(enabled bool) {
+	c.coreTemps = enabled
+}
+
+// SetHistory sets the historical data for sparklines and when each value
+// was sampled
+func (c *CPUMetrics) SetHistory(data []float64, times []time.Time) {
 	c.sparkline.SetData(data)
+	c.sparkline.SetTimes(times)
+}
+
+// SetPowerHistory sets the CPU package power history in watts
+func (c *CPUMetrics) SetPowerHistory(data []float64) {
+	c.powerLine.SetData(data)
+}
+
+// SetTrend sets the direction arrow shown next to the total
+func (c *CPUMetrics) SetTrend(trend components.Trend) {
+	c.trend = trend
 }
 
 // ScrollUp scrolls up through the cores
@@ -113,8 +158,8 @@ func (c *CPUMetrics) ResetScroll() {
 
 // Render returns the rendered CPU metrics
 func (c *CPUMetrics) Render(systemData *data.SystemData) string {
-	if systemData == nil || systemData.CPU == nil {
-		return c.muted.Render("Loading CPU data...")
+	if state := panelState(systemData, "cpu"); state != data.StateReady {
+		return renderState(state, systemData, "cpu", "CPU", c.muted, c.critical)
 	}
 
 	cpu := systemData.CPU
@@ -126,28 +171,63 @@ func (c *CPUMetrics) Render(systemData *data.SystemData) string {
 
 	// Total usage with progress bar
 	totalStyle := c.getMetricStyle(cpu.Total, 70, 90)
-	b.WriteString(fmt.Sprintf("Total: %s%.1f%%%s\n",
-		totalStyle,
-		cpu.Total,
-		c.value,
-	))
+	if c.mode == "cores" {
+		b.WriteString(fmt.Sprintf("Total: %s%.1f%s of %s cores busy%s\n",
+			totalStyle,
+			busyCores(cpu),
+			c.value,
+			formatCores(cpu.CapacityCores()),
+			c.trendArrow.Render(c.trend),
+		))
+	} else {
+		b.WriteString(fmt.Sprintf("Total: %s%.1f%%%s%s\n",
+			totalStyle,
+			cpu.Total,
+			c.value,
+			c.trendArrow.Render(c.trend),
+		))
+	}
+	if cpu.CgroupCores > 0 {
+		b.WriteString(c.muted.Render(fmt.Sprintf("cgroup limit: %s cores", formatCores(cpu.CgroupCores))))
+		b.WriteString("\n")
+	}
 
-	// Progress bar for total usage
-	c.progressBar.SetWidth(30)
-	b.WriteString(c.progressBar.RenderDynamic(cpu.Total, 70, 90))
+	// Progress bar for total usage, split by time category once two
+	// samples are available
+	if c.breakdown && cpu.Breakdown != nil {
+		c.breakdownBar.SetWidth(30)
+		b.WriteString(c.breakdownBar.Render(cpu.Breakdown))
+		b.WriteString("\n")
+		b.WriteString(c.breakdownBar.RenderLegend(cpu.Breakdown))
+	} else {
+		c.progressBar.SetWidth(30)
+		b.WriteString(c.progressBar.RenderDynamic(cpu.Total, 70, 90))
+	}
 	b.WriteString("\n\n")
 
 	// Sparkline for CPU history
 	if c.sparkline.GetLastValue() > 0 {
-		b.WriteString(c.label.Render("History:"))
-		b.WriteString(" ")
-		b.WriteString(fmt.Sprintf("%.1f%% ", c.sparkline.GetLastValue()))
+		prefix := c.label.Render("History:") + " " + fmt.Sprintf("%.1f%% ", c.sparkline.GetLastValue())
+		b.WriteString(prefix)
 		b.WriteString(c.sparkline.RenderWithColor(70, 90))
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(" ", lipgloss.Width(prefix)))
+		b.WriteString(c.sparkline.RenderTimeAxis())
+		b.WriteString("\n\n")
+	}
+
+	// RAPL power draw
+	if len(cpu.Power) > 0 {
+		b.WriteString(c.renderPower(cpu.Power))
 		b.WriteString("\n\n")
 	}
 
-	// Core count
-	b.WriteString(c.muted.Render(fmt.Sprintf("Cores: %d", cpu.CoreCount)))
+	// Core count, taken from the per-core data so it matches the rows below
+	coreCount := cpu.DisplayCoreCount()
+	b.WriteString(c.muted.Render(fmt.Sprintf("Cores: %d", coreCount)))
+	if cpu.CoreCount > 0 && cpu.CoreCount != coreCount {
+		b.WriteString(c.warning.Render(fmt.Sprintf(" (system reports %d)", cpu.CoreCount)))
+	}
 	b.WriteString("\n\n")
 
 	// Per-core usage with progress bars (scrollable)
@@ -162,7 +242,7 @@ func (c *CPUMetrics) Render(systemData *data.SystemData) string {
 
 		// Add scroll indicator at top if needed
 		if c.CanScrollUp() {
-			upArrow := lipgloss.NewStyle().Foreground(lipgloss.Color("#bd93f9")).Bold(true).Render("▲")
+			upArrow := c.sectionTitle.Render("▲")
 			b.WriteString(fmt.Sprintf("%s %s\n", upArrow, c.muted.Render("Scroll up for more")))
 		}
 
@@ -182,27 +262,94 @@ func (c *CPUMetrics) Render(systemData *data.SystemData) string {
 			c.progressBar.SetWidth(15)
 			bar := c.progressBar.RenderDynamic(usage, 70, 90)
 
-			b.WriteString(fmt.Sprintf("%sCore %2d:%s %5.1f%% %s\n",
+			// Label with the real CPU number; it differs from the index when cores are offline
+			coreID := i
+			if i < len(cpu.CoreIDs) {
+				coreID = cpu.CoreIDs[i]
+			}
+
+			b.WriteString(fmt.Sprintf("%sCore %2d:%s %5.1f%% %s",
 				c.muted,
-				i,
+				coreID,
 				coreStyle,
 				usage,
 				bar,
 			))
+			if temp, ok := c.coreTemp(systemData, coreID); ok {
+				b.WriteString(" " + c.getMetricStyle(temp, 70, 85).Render(fmt.Sprintf("%3.0f°C", temp)))
+			}
+			b.WriteString("\n")
 
 			visibleCount++
 		}
 
 		// Add scroll indicator at bottom if needed
 		if c.CanScrollDown() {
-			downArrow := lipgloss.NewStyle().Foreground(lipgloss.Color("#bd93f9")).Bold(true).Render("▼")
+			downArrow := c.sectionTitle.Render("▼")
 			b.WriteString(fmt.Sprintf("\n%s %s", downArrow, c.muted.Render("Scroll down for more")))
 		}
 	}
 
+	// Offline (parked) cores have no usage data, list them separately
+	if len(cpu.Offline) > 0 {
+		ids := make([]string, len(cpu.Offline))
+		for i, id := range cpu.Offline {
+			ids[i] = fmt.Sprintf("%d", id)
+		}
+		b.WriteString("\n")
+		b.WriteString(c.warning.Render("Offline: "))
+		b.WriteString(c.muted.Render(strings.Join(ids, ", ")))
+	}
+
 	return b.String()
 }
 
+// powerLabels are the display names of the RAPL and macOS power domains
+var powerLabels = map[string]string{
+	"package": "Package",
+	"core":    "Cores",
+	"uncore":  "Uncore",
+	"dram":    "DRAM",
+	"psys":    "Platform",
+	"gpu":     "GPU",
+	"ane":     "Neural Engine",
+}
+
+// renderPower renders the watts of each power domain, followed by a
+// sparkline of package power once there is history
+func (c *CPUMetrics) renderPower(power []data.PowerStat) string {
+	parts := make([]string, len(power))
+	for i, stat := range power {
+		parts[i] = c.muted.Render(powerLabels[stat.Domain]+" ") + c.value.Render(fmt.Sprintf("%.1f W", stat.Watts))
+	}
+	line := c.label.Render("Power:") + " " + strings.Join(parts, c.muted.Render(" · "))
+	if c.powerLine.GetLastValue() > 0 {
+		line += "\n" + c.label.Render("Watts:") + " " + c.powerLine.Render()
+	}
+	return line
+}
+
+// coreTemp returns the temperature of the core a logical CPU runs on, when
+// core temperatures are shown and there is a reading for it
+func (c *CPUMetrics) coreTemp(systemData *data.SystemData, cpu int) (float64, bool) {
+	if !c.coreTemps || systemData.Sensors == nil {
+		return 0, false
+	}
+	temp, ok := systemData.Sensors.CoreTemps[cpu]
+	return temp, ok
+}
+
+// busyCores converts total usage percent into the number of cores in use
+func busyCores(cpu *data.CPUMetrics) float64 {
+	return cpu.Total / 100 * cpu.CapacityCores()
+}
+
+// formatCores renders a core count, with decimals only for fractional
+// cgroup quotas such as 1.5
+func formatCores(cores float64) string {
+	return strconv.FormatFloat(math.Round(cores*100)/100, 'f', -1, 64)
+}
+
 func (c *CPUMetrics) getMetricStyle(value float64, warning, critical float64) lipgloss.Style {
 	if value >= critical {
 		return c.critical
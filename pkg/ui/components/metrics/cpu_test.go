@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+func TestCPUMetricsRenderCoreCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		cpu      data.CPUMetrics
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "usage shorter than core count",
+			cpu:      data.CPUMetrics{Usage: []float64{10, 20}, CoreCount: 4},
+			contains: []string{"Cores: 2 (system reports 4)", "Core  0:", "Core  1:"},
+			excludes: []string{"Core  2:"},
+		},
+		{
+			name:     "usage longer than core count",
+			cpu:      data.CPUMetrics{Usage: []float64{10, 20, 30, 40}, CoreCount: 2},
+			contains: []string{"Cores: 4 (system reports 2)", "Core  3:"},
+		},
+		{
+			name: "offline cores",
+			cpu: data.CPUMetrics{
+				Usage:     []float64{10, 20, 30},
+				CoreCount: 3,
+				CoreIDs:   []int{0, 1, 4},
+				Offline:   []int{2, 3},
+			},
+			contains: []string{"Cores: 3\n", "Core  0:", "Core  1:", "Core  4:", "Offline: 2, 3"},
+			excludes: []string{"Core  2:", "system reports"},
+		},
+		{
+			name:     "core IDs shorter than usage",
+			cpu:      data.CPUMetrics{Usage: []float64{10, 20, 30}, CoreCount: 3, CoreIDs: []int{0}},
+			contains: []string{"Core  0:", "Core  1:", "Core  2:"},
+		},
+		{
+			name:     "no per-core usage",
+			cpu:      data.CPUMetrics{CoreCount: 8, Offline: []int{7}},
+			contains: []string{"Cores: 8", "Offline: 7"},
+			excludes: []string{"Per-Core Usage"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCPUMetrics()
+			c.SetWidth(60)
+			cpu := tt.cpu
+			out := ansi.Strip(c.Render(&data.SystemData{CPU: &cpu}))
+			for _, s := range tt.contains {
+				if !strings.Contains(out, s) {
+					t.Errorf("output missing %q:\n%s", s, out)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(out, s) {
+					t.Errorf("output has %q:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
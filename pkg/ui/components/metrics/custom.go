@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// CustomMetrics renders user-defined metrics such as script collector outputs
+type CustomMetrics struct {
+	title     lipgloss.Style
+	label     lipgloss.Style
+	value     lipgloss.Style
+	muted     lipgloss.Style
+	normal    lipgloss.Style
+	warning   lipgloss.Style
+	critical  lipgloss.Style
+	width     int
+	sparkline *components.SparkLine
+	history   map[string][]float64
+}
+
+// NewCustomMetrics creates a new custom metrics renderer
+func NewCustomMetrics() *CustomMetrics {
+	c := &CustomMetrics{
+		sparkline: components.NewSparkLine(),
+	}
+	c.SetTheme(components.DarkTheme())
+	return c
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (c *CustomMetrics) SetTheme(t *components.Theme) {
+	c.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	c.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	c.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	c.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	c.normal = lipgloss.NewStyle().Foreground(t.Green)
+	c.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	c.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	c.sparkline.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (c *CustomMetrics) SetWidth(w int) {
+	c.width = w
+	sparkWidth := w - 24
+	if sparkWidth < 10 {
+		sparkWidth = 10
+	}
+	c.sparkline.SetWidth(sparkWidth)
+}
+
+// SetHistory sets the per-metric history used for sparklines
+func (c *CustomMetrics) SetHistory(history map[string][]float64) {
+	c.history = history
+}
+
+// Render returns the rendered custom metrics
+func (c *CustomMetrics) Render(systemData *data.SystemData) string {
+	var b strings.Builder
+
+	b.WriteString(c.title.Render("Custom Metrics"))
+	b.WriteString("\n\n")
+
+	if systemData == nil || len(systemData.Custom) == 0 {
+		b.WriteString(c.muted.Render("No custom metrics collected yet"))
+		b.WriteString("\n")
+		b.WriteString(c.muted.Render("(Define collectors.scripts in the config file)"))
+		b.WriteString(c.renderErrors(systemData))
+		return b.String()
+	}
+
+	for _, metric := range systemData.Custom {
+		style := c.getMetricStyle(metric)
+
+		b.WriteString(c.label.Render(metric.Name))
+		b.WriteString("\n")
+
+		gauge := renderGauge(metric.Value, gaugeMax(metric, c.history[metric.Name]), 20, c.muted, style)
+		b.WriteString(fmt.Sprintf("  %s %s\n", gauge, style.Render(c.formatValue(metric.Value, metric.Unit))))
+
+		if history := c.history[metric.Name]; len(history) > 1 {
+			c.sparkline.SetData(history)
+			b.WriteString("  ")
+			b.WriteString(c.sparkline.Render())
+			b.WriteString("\n")
+		}
+
+		if metric.Critical > 0 {
+			b.WriteString(c.muted.Render(fmt.Sprintf("  warn: %s  crit: %s",
+				c.formatValue(metric.Warning, metric.Unit),
+				c.formatValue(metric.Critical, metric.Unit),
+			)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(c.renderErrors(systemData))
+	return b.String()
+}
+
+// renderErrors lists script collectors whose last run failed
+func (c *CustomMetrics) renderErrors(systemData *data.SystemData) string {
+	if systemData == nil {
+		return ""
+	}
+
+	var names []string
+	for name := range systemData.CollectorErrors {
+		if strings.HasPrefix(name, "script:") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString("\n")
+		b.WriteString(c.critical.Render(strings.TrimPrefix(name, "script:") + " failed"))
+		b.WriteString("\n")
+		b.WriteString(c.muted.Render("  " + systemData.CollectorErrors[name].Error()))
+	}
+	return b.String()
+}
+
+// getMetricStyle colors a metric by its own thresholds
+func (c *CustomMetrics) getMetricStyle(metric data.CustomMetric) lipgloss.Style {
+	if metric.Critical > 0 && metric.Value >= metric.Critical {
+		return c.critical
+	}
+	if metric.Warning > 0 && metric.Value >= metric.Warning {
+		return c.warning
+	}
+	return c.normal
+}
+
+// gaugeMax picks a full-scale value for a metric's gauge: 100 for
+// percentages, headroom above the critical level, or the history peak
+func gaugeMax(metric data.CustomMetric, history []float64) float64 {
+	if metric.Unit == "%" || metric.Unit == "percent" {
+		return 100
+	}
+	if metric.Critical > 0 {
+		return metric.Critical * 1.25
+	}
+
+	peak := metric.Value
+	for _, v := range history {
+		if v > peak {
+			peak = v
+		}
+	}
+	return peak
+}
+
+// formatValue formats a value according to its unit
+func (c *CustomMetrics) formatValue(value float64, unit string) string {
+	switch strings.ToLower(unit) {
+	case "%", "percent":
+		return fmt.Sprintf("%.1f%%", value)
+	case "°c", "c", "celsius":
+		return fmt.Sprintf("%.1f°C", value)
+	case "bytes", "b":
+		if value < 0 {
+			return fmt.Sprintf("%.0f B", value)
+		}
+		return c.formatBytes(uint64(value))
+	case "count", "":
+		if value == float64(int64(value)) {
+			return fmt.Sprintf("%d", int64(value))
+		}
+		return fmt.Sprintf("%.2f", value)
+	default:
+		return fmt.Sprintf("%.2f %s", value, unit)
+	}
+}
+
+// formatBytes formats a byte count as human-readable
+func (c *CustomMetrics) formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
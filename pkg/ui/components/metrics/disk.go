@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -11,6 +12,7 @@ import (
 
 // DiskMetrics renders disk metrics
 type DiskMetrics struct {
+	title       lipgloss.Style
 	label       lipgloss.Style
 	value       lipgloss.Style
 	muted       lipgloss.Style
@@ -19,45 +21,109 @@ type DiskMetrics struct {
 	critical    lipgloss.Style
 	width       int
 	progressBar *components.ProgressBar
+	labels      map[string]string // Mountpoint -> display label
+	values      ValueDisplay
+	inodeWarn   float64
+	inodeCrit   float64
+
+	// Read/write rate history per whole disk, drawn in the read and write
+	// colors that partition activity indicators use too
+	readLine   *components.SparkLine
+	writeLine  *components.SparkLine
+	io         map[string]data.RWHistory
+	readStyle  lipgloss.Style
+	writeStyle lipgloss.Style
 }
 
 // NewDiskMetrics creates a new disk metrics renderer
 func NewDiskMetrics() *DiskMetrics {
-	var colorForeground = lipgloss.Color("#f8f8f2")
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorOrange = lipgloss.Color("#ffb86c")
-	var colorRed = lipgloss.Color("#ff5555")
-
-	return &DiskMetrics{
-		label:       lipgloss.NewStyle().Foreground(colorCyan),
-		value:       lipgloss.NewStyle().Foreground(colorForeground),
-		muted:       lipgloss.NewStyle().Foreground(colorComment),
-		normal:      lipgloss.NewStyle().Foreground(colorGreen),
-		warning:     lipgloss.NewStyle().Foreground(colorOrange),
-		critical:    lipgloss.NewStyle().Foreground(colorRed).Bold(true),
+	d := &DiskMetrics{
 		progressBar: components.NewProgressBar(),
+		readLine:    components.NewSparkLine(),
+		writeLine:   components.NewSparkLine(),
+		values:      DefaultValueDisplay,
+		inodeWarn:   80,
+		inodeCrit:   95,
 	}
+	d.SetTheme(components.DarkTheme())
+	return d
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (d *DiskMetrics) SetTheme(t *components.Theme) {
+	d.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	d.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	d.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	d.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	d.normal = lipgloss.NewStyle().Foreground(t.Green)
+	d.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	d.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	d.progressBar.SetTheme(t)
+	d.readLine.SetTheme(t)
+	d.writeLine.SetTheme(t)
+	d.readStyle = lipgloss.NewStyle().Foreground(t.Cyan)
+	d.writeStyle = lipgloss.NewStyle().Foreground(t.Purple)
+	d.writeLine.SetStyle(d.writeStyle)
 }
 
 // SetWidth sets the render width
 func (d *DiskMetrics) SetWidth(w int) {
 	d.width = w
 	d.progressBar.SetWidth(25)
+
+	// "  Write: " and a rate padded to 12 cells come first
+	sparkWidth := w - 22
+	if sparkWidth < 10 {
+		sparkWidth = 10
+	}
+	d.readLine.SetWidth(sparkWidth)
+	d.writeLine.SetWidth(sparkWidth)
+}
+
+// SetIOHistory sets the read/write rate history of each whole disk, keyed
+// by device name
+func (d *DiskMetrics) SetIOHistory(history map[string]data.RWHistory) {
+	d.io = history
+}
+
+// SetValueDisplay sets whether usage is shown as percent, sizes, or both
+func (d *DiskMetrics) SetValueDisplay(values ValueDisplay) {
+	d.values = values
+}
+
+// SetLabels sets friendly display labels for partitions, keyed by mountpoint
+func (d *DiskMetrics) SetLabels(labels map[string]string) {
+	d.labels = labels
+}
+
+// SetInodeThresholds sets the inode usage percentages at which a partition's
+// inode line turns orange and red
+func (d *DiskMetrics) SetInodeThresholds(warning, critical float64) {
+	d.inodeWarn = warning
+	d.inodeCrit = critical
+}
+
+// mountLabel returns the configured label for a mountpoint. Config keys are
+// lowercased on load, so the lowercase path is tried as well.
+func (d *DiskMetrics) mountLabel(mountpoint string) (string, bool) {
+	if label, ok := d.labels[mountpoint]; ok {
+		return label, true
+	}
+	label, ok := d.labels[strings.ToLower(mountpoint)]
+	return label, ok
 }
 
 // Render returns the rendered disk metrics
 func (d *DiskMetrics) Render(systemData *data.SystemData) string {
-	if systemData == nil || systemData.Disk == nil {
-		return d.muted.Render("Loading disk data...")
+	if state := panelState(systemData, "disk"); state != data.StateReady {
+		return renderState(state, systemData, "disk", "disk", d.muted, d.critical)
 	}
 
 	disk := systemData.Disk
 	var b strings.Builder
 
 	// Title
-	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#bd93f9")).Bold(true).Render("Disk Usage"))
+	b.WriteString(d.title.Render("Disk Usage"))
 	b.WriteString("\n\n")
 
 	// Disk usage per partition with progress bars
@@ -67,31 +133,231 @@ func (d *DiskMetrics) Render(systemData *data.SystemData) string {
 			continue
 		}
 
-		b.WriteString(fmt.Sprintf("%s%s%s\n",
+		// A label replaces the mountpoint, which moves to a secondary line
+		label, hasLabel := d.mountLabel(partition.Mountpoint)
+		if !hasLabel {
+			label = partition.Mountpoint
+		}
+		b.WriteString(fmt.Sprintf("%s%s%s",
 			d.label,
-			partition.Mountpoint,
+			label,
 			d.value,
 		))
+		if rate, ok := disk.MountRates[partition.Mountpoint]; ok {
+			b.WriteString(" " + d.renderActivity(rate))
+		}
+		b.WriteString("\n")
+		if hasLabel {
+			b.WriteString(d.muted.Render("  " + partition.Mountpoint))
+			b.WriteString("\n")
+		}
+		if device := disk.Devices[partition.Mountpoint]; device != "" {
+			b.WriteString(d.muted.Render("  " + device))
+			b.WriteString("\n")
+		}
 
 		// Progress bar for disk usage
 		d.progressBar.SetWidth(25)
 		style := d.getMetricStyle(usage.UsedPercent, 80, 95)
 		b.WriteString(style.Render(d.progressBar.RenderDynamic(usage.UsedPercent, 80, 95)))
-		b.WriteString(fmt.Sprintf(" %s%.1f%%%s\n",
-			style,
-			usage.UsedPercent,
-			d.value,
+		if d.values.Percent {
+			b.WriteString(fmt.Sprintf(" %s%.1f%%%s",
+				style,
+				usage.UsedPercent,
+				d.value,
+			))
+		}
+		if temp, ok := disk.Temps[partition.Mountpoint]; ok {
+			b.WriteString(d.muted.Render(" / "))
+			b.WriteString(d.getMetricStyle(temp, 60, 70).Render(fmt.Sprintf("%.0f°C", temp)))
+		}
+		b.WriteString("\n")
+
+		if d.values.Absolute {
+			b.WriteString(fmt.Sprintf("  %s / %s\n",
+				d.formatBytes(usage.Used),
+				d.formatBytes(usage.Total),
+			))
+		}
+		// Filesystems without a fixed inode table (btrfs, vfat) report none
+		if usage.InodesTotal > 0 {
+			style := d.getMetricStyle(usage.InodesUsedPercent, d.inodeWarn, d.inodeCrit)
+			b.WriteString(d.muted.Render("  Inodes "))
+			b.WriteString(style.Render(fmt.Sprintf("%.1f%%", usage.InodesUsedPercent)))
+			b.WriteString(d.muted.Render(fmt.Sprintf(" (%s / %s)", d.formatCount(usage.InodesUsed), d.formatCount(usage.InodesTotal))))
+			b.WriteString("\n")
+		}
+		if health, ok := disk.Health[partition.Mountpoint]; ok {
+			b.WriteString(d.renderHealth(health))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(d.renderIO(disk))
+
+	if len(disk.RAID) > 0 {
+		b.WriteString(d.title.Render("RAID Arrays"))
+		b.WriteString("\n\n")
+		for _, array := range disk.RAID {
+			b.WriteString(d.renderRAID(array))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(renderPartial(systemData, "disk", d.warning))
+
+	return b.String()
+}
+
+// renderActivity renders read and write indicators for a partition, lit
+// while its device moved data since the previous collection, followed by
+// the rates when it did
+func (d *DiskMetrics) renderActivity(rate data.IORate) string {
+	read, write := d.muted.Render("R○"), d.muted.Render("W○")
+	if rate.ReadBytesPerSec > 0 {
+		read = d.readStyle.Render("R●")
+	}
+	if rate.WriteBytesPerSec > 0 {
+		write = d.writeStyle.Render("W●")
+	}
+	activity := read + " " + write
+	if rate.ReadBytesPerSec > 0 || rate.WriteBytesPerSec > 0 {
+		activity += d.muted.Render(fmt.Sprintf(" %s/s, %s/s",
+			d.formatBytes(uint64(rate.ReadBytesPerSec)),
+			d.formatBytes(uint64(rate.WriteBytesPerSec)),
 		))
+	}
+	return activity
+}
 
-		b.WriteString(fmt.Sprintf("  %s / %s\n\n",
-			d.formatBytes(usage.Used),
-			d.formatBytes(usage.Total),
+// renderIO renders the read and write rates of each whole disk with their
+// sparklines, once two collections have given rates
+func (d *DiskMetrics) renderIO(disk *data.DiskMetrics) string {
+	devices := make([]string, 0, len(disk.Rates))
+	for device := range disk.Rates {
+		if len(d.io[device].Read) > 0 {
+			devices = append(devices, device)
+		}
+	}
+	if len(devices) == 0 {
+		return ""
+	}
+	slices.Sort(devices)
+
+	var b strings.Builder
+	b.WriteString(d.title.Render("Disk I/O"))
+	b.WriteString("\n\n")
+	for _, device := range devices {
+		history := d.io[device]
+		rate := disk.Rates[device]
+		d.readLine.SetData(history.Read)
+		d.writeLine.SetData(history.Write)
+
+		b.WriteString(d.label.Render(device))
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("  %s %s %s\n",
+			d.muted.Render("Read: "),
+			d.value.Render(fmt.Sprintf("%-12s", d.formatBytes(uint64(rate.ReadBytesPerSec))+"/s")),
+			d.readLine.Render(),
+		))
+		b.WriteString(fmt.Sprintf("  %s %s %s\n",
+			d.muted.Render("Write:"),
+			d.value.Render(fmt.Sprintf("%-12s", d.formatBytes(uint64(rate.WriteBytesPerSec))+"/s")),
+			d.writeLine.Render(),
 		))
 	}
+	b.WriteString("\n")
+	return b.String()
+}
 
+// renderHealth renders the wear, spare and media error counts of an NVMe
+// drive, followed by any critical warnings it raises
+func (d *DiskMetrics) renderHealth(health data.DiskHealth) string {
+	var b strings.Builder
+
+	spareStyle := d.normal
+	if health.AvailableSpare <= health.SpareThreshold {
+		spareStyle = d.critical
+	}
+	errorStyle := d.normal
+	if health.MediaErrors > 0 {
+		errorStyle = d.critical
+	}
+	b.WriteString(d.muted.Render("  Wear "))
+	b.WriteString(d.getMetricStyle(float64(health.PercentUsed), 80, 100).Render(fmt.Sprintf("%d%%", health.PercentUsed)))
+	b.WriteString(d.muted.Render(" · Spare "))
+	b.WriteString(spareStyle.Render(fmt.Sprintf("%d%%", health.AvailableSpare)))
+	b.WriteString(d.muted.Render(" · Media errors "))
+	b.WriteString(errorStyle.Render(fmt.Sprintf("%d", health.MediaErrors)))
+	b.WriteString("\n")
+
+	for bit, warning := range nvmeCriticalWarnings {
+		if health.CriticalWarning&(1<<bit) != 0 {
+			b.WriteString(d.critical.Render("  " + warning))
+			b.WriteString("\n")
+		}
+	}
 	return b.String()
 }
 
+// renderRAID renders one software RAID array: its level, member map and
+// health, then the progress of a running resync or rebuild
+func (d *DiskMetrics) renderRAID(array data.RAIDArray) string {
+	var b strings.Builder
+
+	b.WriteString(d.label.Render(array.Name))
+	if array.Level != "" {
+		b.WriteString(d.muted.Render(" " + array.Level))
+	}
+	if array.Status != "" {
+		b.WriteString(d.value.Render(" [" + array.Status + "]"))
+	}
+	if array.Devices > 0 {
+		b.WriteString(d.muted.Render(fmt.Sprintf(" %d/%d", array.Active, array.Devices)))
+	}
+	b.WriteString(" ")
+	switch {
+	case array.Degraded():
+		state := "degraded"
+		if array.Failed > 0 {
+			state += fmt.Sprintf(", %d failed", array.Failed)
+		}
+		b.WriteString(d.critical.Render(state))
+	case array.State == "active":
+		b.WriteString(d.normal.Render("clean"))
+	default:
+		b.WriteString(d.warning.Render(array.State))
+	}
+	if array.Spares > 0 {
+		b.WriteString(d.muted.Render(fmt.Sprintf(" · %d spare", array.Spares)))
+	}
+	b.WriteString("\n")
+
+	if array.Sync != "" {
+		b.WriteString(d.muted.Render("  " + array.Sync + " "))
+		if array.SyncPercent > 0 {
+			d.progressBar.SetWidth(15)
+			b.WriteString(d.progressBar.Render(array.SyncPercent))
+			b.WriteString(d.value.Render(fmt.Sprintf(" %.1f%%", array.SyncPercent)))
+		}
+		if array.SyncFinish != "" {
+			b.WriteString(d.muted.Render(" · " + array.SyncFinish))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// nvmeCriticalWarnings describes the bits of the NVMe critical warning field
+var nvmeCriticalWarnings = []string{
+	"Spare capacity below threshold",
+	"Temperature out of range",
+	"Reliability degraded",
+	"Drive is read-only",
+	"Volatile memory backup failed",
+	"Persistent memory region is read-only",
+}
+
 func (d *DiskMetrics) getMetricStyle(value float64, warning, critical float64) lipgloss.Style {
 	if value >= critical {
 		return d.critical
@@ -114,3 +380,17 @@ func (d *DiskMetrics) formatBytes(b uint64) string {
 	}
 	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
 }
+
+// formatCount shortens a count with decimal K/M/G suffixes, e.g. 1.2M
+func (d *DiskMetrics) formatCount(n uint64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(n)/float64(div), "KMGTPE"[exp])
+}
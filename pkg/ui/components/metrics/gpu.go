@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// GPUMetrics renders GPU metrics
+type GPUMetrics struct {
+	title       lipgloss.Style
+	label       lipgloss.Style
+	value       lipgloss.Style
+	muted       lipgloss.Style
+	normal      lipgloss.Style
+	warning     lipgloss.Style
+	critical    lipgloss.Style
+	width       int
+	progressBar *components.ProgressBar
+}
+
+// NewGPUMetrics creates a new GPU metrics renderer
+func NewGPUMetrics() *GPUMetrics {
+	g := &GPUMetrics{
+		progressBar: components.NewProgressBar(),
+	}
+	g.SetTheme(components.DarkTheme())
+	return g
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (g *GPUMetrics) SetTheme(t *components.Theme) {
+	g.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	g.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	g.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	g.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	g.normal = lipgloss.NewStyle().Foreground(t.Green)
+	g.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	g.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	g.progressBar.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (g *GPUMetrics) SetWidth(w int) {
+	g.width = w
+	g.progressBar.SetWidth(30)
+}
+
+// Render returns the rendered GPU metrics
+func (g *GPUMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "gpu"); state != data.StateReady {
+		return renderState(state, systemData, "gpu", "GPU", g.muted, g.critical)
+	}
+
+	var b strings.Builder
+
+	// Title
+	b.WriteString(g.title.Render("GPU Usage"))
+	b.WriteString("\n\n")
+
+	gpus := systemData.GPU.GPUs
+	if len(gpus) == 0 {
+		b.WriteString(g.muted.Render("No supported GPU found"))
+		b.WriteString("\n")
+		b.WriteString(g.muted.Render("(NVIDIA GPUs need nvidia-smi from the driver package;"))
+		b.WriteString("\n")
+		b.WriteString(g.muted.Render(" Intel GPUs need the i915 driver on Linux)"))
+		return b.String()
+	}
+
+	for i, gpu := range gpus {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(g.renderGPU(gpu))
+	}
+
+	if partial := renderPartial(systemData, "gpu", g.warning); partial != "" {
+		b.WriteString("\n")
+		b.WriteString(partial)
+	}
+
+	return b.String()
+}
+
+// renderGPU renders the readings of a single GPU, skipping any the driver
+// doesn't report
+func (g *GPUMetrics) renderGPU(gpu data.GPUStat) string {
+	var b strings.Builder
+
+	name := fmt.Sprintf("GPU %d: %s", gpu.Index, gpu.Name)
+	if g.width > 0 && lipgloss.Width(name) > g.width {
+		name = lipgloss.NewStyle().MaxWidth(g.width).Render(name)
+	}
+	b.WriteString(g.label.Bold(true).Render(name))
+	b.WriteString("\n")
+
+	if gpu.Utilization >= 0 {
+		utilStyle := g.getMetricStyle(gpu.Utilization, 70, 90)
+		b.WriteString(fmt.Sprintf("%sUtil:%s  %s\n",
+			g.label,
+			g.value,
+			utilStyle.Render(fmt.Sprintf("%.0f%%", gpu.Utilization)),
+		))
+		b.WriteString(g.progressBar.RenderDynamic(gpu.Utilization, 70, 90))
+		b.WriteString("\n")
+	}
+
+	if gpu.MemoryTotal > 0 {
+		memPercent := float64(gpu.MemoryUsed) / float64(gpu.MemoryTotal) * 100
+		memStyle := g.getMetricStyle(memPercent, 80, 95)
+		b.WriteString(fmt.Sprintf("%sVRAM:%s  %s / %s %s\n",
+			g.label,
+			g.value,
+			g.formatBytes(gpu.MemoryUsed),
+			g.formatBytes(gpu.MemoryTotal),
+			memStyle.Render(fmt.Sprintf("(%.1f%%)", memPercent)),
+		))
+		b.WriteString(g.progressBar.RenderDynamic(memPercent, 80, 95))
+		b.WriteString("\n")
+	}
+
+	var details []string
+	if gpu.Temperature >= 0 {
+		tempStyle := g.getMetricStyle(gpu.Temperature, 70, 85)
+		details = append(details, g.label.Render("Temp: ")+tempStyle.Render(fmt.Sprintf("%.0f°C", gpu.Temperature)))
+	}
+	if gpu.PowerDraw >= 0 {
+		power := fmt.Sprintf("%.0f W", gpu.PowerDraw)
+		if gpu.PowerLimit > 0 {
+			power = fmt.Sprintf("%.0f / %.0f W", gpu.PowerDraw, gpu.PowerLimit)
+		}
+		details = append(details, g.label.Render("Power: ")+g.value.Render(power))
+	}
+	if gpu.GraphicsClock >= 0 {
+		clocks := g.label.Render("Clock: ") + g.value.Render(fmt.Sprintf("%.0f MHz", gpu.GraphicsClock))
+		if gpu.MemoryClock >= 0 {
+			clocks += g.muted.Render(fmt.Sprintf(" (mem %.0f MHz)", gpu.MemoryClock))
+		}
+		details = append(details, clocks)
+	}
+	for _, detail := range details {
+		b.WriteString(detail)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (g *GPUMetrics) getMetricStyle(value float64, warning, critical float64) lipgloss.Style {
+	if value >= critical {
+		return g.critical
+	}
+	if value >= warning {
+		return g.warning
+	}
+	return g.normal
+}
+
+func (g *GPUMetrics) formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
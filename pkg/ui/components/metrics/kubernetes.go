@@ -0,0 +1,242 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// PodMetrics renders the pods on the local Kubernetes node with their CPU
+// and memory usage against their requests as a scrollable table
+type PodMetrics struct {
+	title    lipgloss.Style
+	label    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	normal   lipgloss.Style
+	warning  lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	table    *components.Table
+}
+
+// NewPodMetrics creates a new Kubernetes pod renderer
+func NewPodMetrics() *PodMetrics {
+	p := &PodMetrics{
+		table: components.NewTable([]components.Column{
+			{Title: "NAMESPACE", Width: 16},
+			{Title: "POD", MinWidth: 20},
+			{Title: "STATUS", Width: 12},
+			{Title: "READY", Width: 5, Align: components.AlignRight},
+			{Title: "RESTARTS", Width: 8, Align: components.AlignRight},
+			{Title: "CPU / REQ", Width: 13, Align: components.AlignRight},
+			{Title: "MEMORY / REQ", Width: 21, Align: components.AlignRight},
+		}),
+	}
+	p.SetTheme(components.DarkTheme())
+	return p
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (p *PodMetrics) SetTheme(t *components.Theme) {
+	p.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	p.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	p.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	p.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	p.normal = lipgloss.NewStyle().Foreground(t.Green)
+	p.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	p.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	p.table.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (p *PodMetrics) SetWidth(w int) {
+	p.width = w
+	p.table.SetWidth(w)
+}
+
+// SetHeight sets the render height; rows beyond it scroll
+func (p *PodMetrics) SetHeight(h int) {
+	// Title, summary, table header, footer and a partial failure note take 9 lines
+	rows := 0
+	if h > 0 {
+		rows = max(h-9, 1)
+	}
+	p.table.SetHeight(rows)
+}
+
+// ScrollUp scrolls the pod table up one row
+func (p *PodMetrics) ScrollUp() {
+	p.table.ScrollUp()
+}
+
+// ScrollDown scrolls the pod table down one row
+func (p *PodMetrics) ScrollDown() {
+	p.table.ScrollDown()
+}
+
+// Render returns the rendered Kubernetes pods
+func (p *PodMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "kubernetes"); state != data.StateReady {
+		return renderState(state, systemData, "kubernetes", "kubernetes", p.muted, p.critical)
+	}
+
+	metrics := systemData.Kubernetes
+	var b strings.Builder
+
+	// Title
+	b.WriteString(p.title.Render("Kubernetes"))
+	if metrics.Node != "" {
+		b.WriteString(p.muted.Render(" (node " + metrics.Node + ")"))
+	}
+	b.WriteString("\n\n")
+
+	if !metrics.Configured {
+		b.WriteString(p.muted.Render("No Kubernetes cluster found"))
+		b.WriteString("\n")
+		b.WriteString(p.muted.Render("(looked for $KUBECONFIG, ~/.kube/config, k3s and an in-cluster service account)"))
+		return b.String()
+	}
+
+	// Summary
+	var running, pending, failed int
+	var cpuRequest, cpuUsage float64
+	var memoryRequest, memoryUsage uint64
+	for _, pod := range metrics.Pods {
+		switch pod.Phase {
+		case "Running":
+			running++
+		case "Pending":
+			pending++
+		case "Failed", "Unknown":
+			failed++
+		}
+		cpuRequest += pod.CPURequest
+		cpuUsage += pod.CPUUsage
+		memoryRequest += pod.MemoryRequest
+		memoryUsage += pod.MemoryUsage
+	}
+	b.WriteString(p.label.Render("Running "))
+	b.WriteString(p.value.Render(fmt.Sprintf("%d", running)))
+	b.WriteString(p.muted.Render(" · "))
+	b.WriteString(p.label.Render("Pending "))
+	if pending > 0 {
+		b.WriteString(p.warning.Render(fmt.Sprintf("%d", pending)))
+	} else {
+		b.WriteString(p.value.Render("0"))
+	}
+	b.WriteString(p.muted.Render(" · "))
+	b.WriteString(p.label.Render("Failed "))
+	if failed > 0 {
+		b.WriteString(p.critical.Render(fmt.Sprintf("%d", failed)))
+	} else {
+		b.WriteString(p.normal.Render("0"))
+	}
+	b.WriteString(p.muted.Render(" · "))
+	b.WriteString(p.label.Render("CPU "))
+	b.WriteString(p.value.Render(p.usage(p.formatCPU(cpuUsage), p.formatCPU(cpuRequest), metrics.HasUsage, cpuRequest > 0)))
+	b.WriteString(p.muted.Render(" · "))
+	b.WriteString(p.label.Render("Memory "))
+	b.WriteString(p.value.Render(p.usage(p.formatBytes(memoryUsage), p.formatBytes(memoryRequest), metrics.HasUsage, memoryRequest > 0)))
+	b.WriteString("\n\n")
+
+	if len(metrics.Pods) == 0 {
+		b.WriteString(p.muted.Render("No pods on this node"))
+		return b.String()
+	}
+
+	rows := make([]components.Row, 0, len(metrics.Pods))
+	for _, pod := range metrics.Pods {
+		statusStyle := p.normal
+		switch pod.Phase {
+		case "Succeeded":
+			statusStyle = p.muted
+		case "Pending":
+			statusStyle = p.warning
+		case "Failed", "Unknown":
+			statusStyle = p.critical
+		}
+		readyStyle := p.value
+		if pod.Phase == "Running" && pod.Ready < pod.Containers {
+			readyStyle = p.warning
+		}
+		restartStyle := p.value
+		if pod.Restarts > 0 {
+			restartStyle = p.warning
+		}
+
+		cpu := p.usage(p.formatCPU(pod.CPUUsage), p.formatCPU(pod.CPURequest), metrics.HasUsage, pod.CPURequest > 0)
+		memory := p.usage(p.formatBytes(pod.MemoryUsage), p.formatBytes(pod.MemoryRequest), metrics.HasUsage, pod.MemoryRequest > 0)
+		rows = append(rows, components.Row{
+			{Text: pod.Namespace, Style: p.muted},
+			{Text: pod.Name, Style: p.value},
+			{Text: pod.Phase, Style: statusStyle},
+			{Text: fmt.Sprintf("%d/%d", pod.Ready, pod.Containers), Style: readyStyle},
+			{Text: fmt.Sprintf("%d", pod.Restarts), Style: restartStyle},
+			{Text: cpu, Style: p.requestStyle(pod.CPUUsage, pod.CPURequest)},
+			{Text: memory, Style: p.requestStyle(float64(pod.MemoryUsage), float64(pod.MemoryRequest))},
+		})
+	}
+	p.table.SetRows(rows)
+
+	b.WriteString(p.table.Render())
+	b.WriteString("\n\n")
+
+	first, last := p.table.VisibleRange()
+	b.WriteString(p.muted.Render(fmt.Sprintf("Showing %d-%d of %d pods (↑/↓ to scroll)", first+1, last, len(metrics.Pods))))
+
+	if partial := renderPartial(systemData, "kubernetes", p.warning); partial != "" {
+		b.WriteString("\n")
+		b.WriteString(partial)
+	}
+
+	return b.String()
+}
+
+// usage joins usage and request as "used / requested", with "-" for the
+// usage when metrics-server isn't available and for a missing request
+func (p *PodMetrics) usage(used, requested string, hasUsage, hasRequest bool) string {
+	if !hasUsage {
+		used = "-"
+	}
+	if !hasRequest {
+		requested = "-"
+	}
+	return used + " / " + requested
+}
+
+// requestStyle flags usage above the pod's request, which the scheduler
+// didn't reserve and which is the first to be reclaimed under pressure
+func (p *PodMetrics) requestStyle(used, requested float64) lipgloss.Style {
+	if requested > 0 && used > requested {
+		return p.warning
+	}
+	return p.value
+}
+
+// formatCPU formats cores the way Kubernetes does, in millicores below one core
+func (p *PodMetrics) formatCPU(cores float64) string {
+	if cores == 0 {
+		return "0"
+	}
+	if cores < 1 {
+		return fmt.Sprintf("%.0fm", cores*1000)
+	}
+	return fmt.Sprintf("%.2f", cores)
+}
+
+func (p *PodMetrics) formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
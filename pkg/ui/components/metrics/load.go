@@ -2,13 +2,19 @@ package metrics
 
 import (
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+	"github.com/shirou/gopsutil/v4/host"
 )
 
 // LoadMetrics renders load average metrics
 type LoadMetrics struct {
+	title    lipgloss.Style
 	label    lipgloss.Style
 	value    lipgloss.Style
 	muted    lipgloss.Style
@@ -16,36 +22,81 @@ type LoadMetrics struct {
 	warning  lipgloss.Style
 	critical lipgloss.Style
 	width    int
+	location *time.Location // Timezone for session login times
+
+	// Clock offsets in milliseconds, either direction, that color the clock line
+	offsetWarn float64
+	offsetCrit float64
+
+	// Context switch, interrupt and fork rate history
+	ctxtLine *components.SparkLine
+	intrLine *components.SparkLine
+	forkLine *components.SparkLine
 }
 
 // NewLoadMetrics creates a new load metrics renderer
 func NewLoadMetrics() *LoadMetrics {
-	var colorForeground = lipgloss.Color("#f8f8f2")
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorOrange = lipgloss.Color("#ffb86c")
-	var colorRed = lipgloss.Color("#ff5555")
-
-	return &LoadMetrics{
-		label:    lipgloss.NewStyle().Foreground(colorCyan),
-		value:    lipgloss.NewStyle().Foreground(colorForeground),
-		muted:    lipgloss.NewStyle().Foreground(colorComment),
-		normal:   lipgloss.NewStyle().Foreground(colorGreen),
-		warning:  lipgloss.NewStyle().Foreground(colorOrange),
-		critical: lipgloss.NewStyle().Foreground(colorRed).Bold(true),
+	l := &LoadMetrics{
+		location:   time.Local,
+		offsetWarn: 100,
+		offsetCrit: 1000,
+		ctxtLine:   components.NewSparkLine(),
+		intrLine:   components.NewSparkLine(),
+		forkLine:   components.NewSparkLine(),
 	}
+	l.SetTheme(components.DarkTheme())
+	return l
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (l *LoadMetrics) SetTheme(t *components.Theme) {
+	l.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	l.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	l.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	l.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	l.normal = lipgloss.NewStyle().Foreground(t.Green)
+	l.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	l.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	l.ctxtLine.SetTheme(t)
+	l.intrLine.SetTheme(t)
+	l.forkLine.SetTheme(t)
 }
 
 // SetWidth sets the render width
 func (l *LoadMetrics) SetWidth(w int) {
 	l.width = w
+	sparkWidth := w - 24
+	if sparkWidth < 10 {
+		sparkWidth = 10
+	}
+	l.ctxtLine.SetWidth(sparkWidth)
+	l.intrLine.SetWidth(sparkWidth)
+	l.forkLine.SetWidth(sparkWidth)
+}
+
+// SetLocation sets the timezone session login times are shown in
+func (l *LoadMetrics) SetLocation(loc *time.Location) {
+	l.location = loc
+}
+
+// SetClockThresholds sets the clock offsets in milliseconds at which the
+// clock line turns orange and red
+func (l *LoadMetrics) SetClockThresholds(warning, critical float64) {
+	l.offsetWarn = warning
+	l.offsetCrit = critical
+}
+
+// SetActivityHistory sets the context switch, interrupt and fork rate history
+func (l *LoadMetrics) SetActivityHistory(history data.ActivityHistory) {
+	l.ctxtLine.SetData(history.ContextSwitches)
+	l.intrLine.SetData(history.Interrupts)
+	l.forkLine.SetData(history.Forks)
 }
 
 // Render returns the rendered load metrics
 func (l *LoadMetrics) Render(systemData *data.SystemData) string {
-	if systemData == nil || systemData.Host == nil {
-		return l.muted.Render("Loading load average data...")
+	if state := panelState(systemData, "host"); state != data.StateReady {
+		return renderState(state, systemData, "host", "load average", l.muted, l.critical)
 	}
 
 	if systemData.Host.LoadAvg == nil {
@@ -56,13 +107,13 @@ func (l *LoadMetrics) Render(systemData *data.SystemData) string {
 	var content string
 
 	// Title
-	content += lipgloss.NewStyle().Foreground(lipgloss.Color("#bd93f9")).Bold(true).Render("Load Average")
+	content += l.title.Render("Load Average")
 	content += "\n\n"
 
 	// Get CPU count for context
 	cpuCount := 1.0
-	if systemData.CPU != nil && systemData.CPU.CoreCount > 0 {
-		cpuCount = float64(systemData.CPU.CoreCount)
+	if systemData.CPU != nil && systemData.CPU.LoadCoreCount() > 0 {
+		cpuCount = float64(systemData.CPU.LoadCoreCount())
 	}
 
 	// 1 minute average
@@ -106,6 +157,17 @@ func (l *LoadMetrics) Render(systemData *data.SystemData) string {
 
 	content += l.muted.Render(fmt.Sprintf(" (%.0f%%)\n\n", load.Load15/cpuCount*100))
 
+	// Kernel activity: rising context switch or interrupt rates point to
+	// contention before load averages catch up
+	if activity := systemData.Host.Activity; activity != nil {
+		content += l.label.Render("Kernel Activity:")
+		content += "\n"
+		content += l.renderRate("ctxt/s", activity.ContextSwitchesPerSec, l.ctxtLine)
+		content += l.renderRate("intr/s", activity.InterruptsPerSec, l.intrLine)
+		content += l.renderRate("fork/s", activity.ForksPerSec, l.forkLine)
+		content += "\n"
+	}
+
 	// System info
 	if systemData.Host.Info.Uptime > 0 {
 		content += l.label.Render("System Uptime:")
@@ -128,9 +190,224 @@ func (l *LoadMetrics) Render(systemData *data.SystemData) string {
 		content += fmt.Sprintf("  %s\n", systemData.Host.Info.KernelVersion)
 	}
 
+	content += l.renderUpdates(systemData)
+
+	if clock := systemData.Clock; clock != nil && clock.Source != "" {
+		content += l.label.Render("Clock:")
+		content += "\n"
+		content += l.renderClock(*clock)
+	}
+
+	content += l.renderUPS(systemData)
+
+	if entropy := systemData.Host.Entropy; entropy != nil {
+		content += l.label.Render("Entropy:")
+		content += "\n"
+		content += "  " + l.renderEntropy(*entropy) + "\n"
+	}
+
+	if users := systemData.Host.Users; len(users) > 0 {
+		content += "\n"
+		content += l.label.Render(fmt.Sprintf("Users (%d session%s):", len(users),
+			map[bool]string{true: "s", false: ""}[len(users) > 1]))
+		content += "\n"
+		content += l.renderSessions(users)
+	}
+
 	return content
 }
 
+// Entropy levels, in bits, below which reads from /dev/random could block
+// on older kernels and services waiting for randomness stall
+const (
+	entropyWarning  = 200
+	entropyCritical = 100
+)
+
+// renderEntropy renders the entropy pool's fill level as a gauge
+func (l *LoadMetrics) renderEntropy(entropy data.EntropyStat) string {
+	style := l.normal
+	switch {
+	case entropy.Available < entropyCritical:
+		style = l.critical
+	case entropy.Available < entropyWarning:
+		style = l.warning
+	}
+	gauge := renderGauge(float64(entropy.Available), float64(entropy.PoolSize), 20, l.muted, style)
+	return gauge + " " + style.Render(fmt.Sprintf("%d", entropy.Available)) +
+		l.muted.Render(fmt.Sprintf(" / %d bits", entropy.PoolSize))
+}
+
+// renderClock renders the NTP sync state, the offset from the reference and
+// the server the time service follows
+func (l *LoadMetrics) renderClock(clock data.ClockMetrics) string {
+	state := l.normal.Render("synchronized")
+	if !clock.Synced {
+		state = l.warning.Render("not synchronized")
+	}
+	content := fmt.Sprintf("  %s %s", state, l.muted.Render("via "+clock.Source))
+	if clock.HasOffset {
+		style := l.getMetricStyle(math.Abs(clock.Offset), l.offsetWarn, l.offsetCrit)
+		content += l.muted.Render(", offset ") + style.Render(formatOffset(clock.Offset))
+	}
+	content += "\n"
+
+	if clock.Server != "" {
+		reference := clock.Server
+		if clock.Stratum > 0 {
+			reference += fmt.Sprintf(", stratum %d", clock.Stratum)
+		}
+		content += "  " + l.muted.Render(reference) + "\n"
+	}
+	return content
+}
+
+// renderUpdates renders the number of pending package updates and how
+// many of them are security fixes, when the updates collector is enabled
+func (l *LoadMetrics) renderUpdates(systemData *data.SystemData) string {
+	if panelState(systemData, "updates") == data.StateError {
+		return l.label.Render("Updates:") + "\n" +
+			l.muted.Render("  "+systemData.CollectorErrors["updates"].Error()) + "\n"
+	}
+	updates := systemData.Updates
+	if updates == nil || updates.Manager == "" {
+		return ""
+	}
+
+	content := l.label.Render("Updates:") + "\n  "
+	if updates.Pending == 0 {
+		content += l.normal.Render("up to date")
+	} else {
+		content += l.value.Render(fmt.Sprintf("%d pending", updates.Pending))
+		if updates.Security > 0 {
+			content += l.muted.Render(", ") + l.warning.Render(fmt.Sprintf("%d security", updates.Security))
+		}
+	}
+	return content + " " + l.muted.Render("via "+updates.Manager) + "\n"
+}
+
+// UPS battery charge levels, in percent, below which the gauge turns
+// orange and red
+const (
+	upsChargeWarning  = 50
+	upsChargeCritical = 20
+)
+
+// renderUPS renders each UPS the NUT server reports: whether it runs on
+// mains or battery, the battery charge and the output load and runtime
+func (l *LoadMetrics) renderUPS(systemData *data.SystemData) string {
+	if panelState(systemData, "ups") == data.StateError {
+		return l.label.Render("UPS:") + "\n" +
+			l.muted.Render("  "+systemData.CollectorErrors["ups"].Error()) + "\n"
+	}
+	if systemData.UPS == nil || len(systemData.UPS.UPSes) == 0 {
+		return ""
+	}
+
+	content := l.label.Render("UPS:") + "\n"
+	for _, ups := range systemData.UPS.UPSes {
+		state := l.normal.Render("online")
+		switch {
+		case ups.LowBattery:
+			state = l.critical.Render("on battery, low")
+		case ups.OnBattery:
+			state = l.critical.Render("on battery")
+		}
+		content += fmt.Sprintf("  %s %s", l.value.Render(ups.Name), state)
+		if ups.Model != "" {
+			content += " " + l.muted.Render(ups.Model)
+		}
+		content += "\n"
+
+		var details []string
+		if ups.Load >= 0 {
+			details = append(details, fmt.Sprintf("load %.0f%%", ups.Load))
+		}
+		if ups.Runtime > 0 {
+			details = append(details, fmt.Sprintf("%d min runtime", int(ups.Runtime.Minutes())))
+		}
+		line := "  "
+		if ups.Charge >= 0 {
+			style := l.normal
+			switch {
+			case ups.Charge < upsChargeCritical:
+				style = l.critical
+			case ups.Charge < upsChargeWarning:
+				style = l.warning
+			}
+			line += renderGauge(ups.Charge, 100, 20, l.muted, style) + " " + style.Render(fmt.Sprintf("%.0f%%", ups.Charge))
+			if len(details) > 0 {
+				line += " "
+			}
+		}
+		if len(details) > 0 {
+			line += l.muted.Render(strings.Join(details, ", "))
+		}
+		if line != "  " {
+			content += line + "\n"
+		}
+	}
+	content += renderPartial(systemData, "ups", l.warning)
+	return content
+}
+
+// formatOffset renders a clock offset in milliseconds with its sign,
+// switching to seconds for large drifts
+func formatOffset(ms float64) string {
+	if math.Abs(ms) >= 1000 {
+		return fmt.Sprintf("%+.2f s", ms/1000)
+	}
+	return fmt.Sprintf("%+.2f ms", ms)
+}
+
+// maxSessions caps the session list so a busy shell server doesn't push
+// the rest of the panel off screen
+const maxSessions = 8
+
+// renderSessions lists logged-in sessions like who(1): user, terminal,
+// remote host and login time
+func (l *LoadMetrics) renderSessions(users []host.UserStat) string {
+	var content string
+	for i, user := range users {
+		if i == maxSessions {
+			content += l.muted.Render(fmt.Sprintf("  +%d more\n", len(users)-maxSessions))
+			break
+		}
+		from := user.Host
+		if from == "" {
+			from = "local"
+		}
+		started := time.Unix(int64(user.Started), 0).In(l.location).Format("Jan 02 15:04")
+		content += fmt.Sprintf("  %s %s %s %s\n",
+			l.value.Render(fmt.Sprintf("%-10s", user.User)),
+			l.muted.Render(fmt.Sprintf("%-8s", user.Terminal)),
+			fmt.Sprintf("%-16s", from),
+			l.muted.Render(started),
+		)
+	}
+	return content
+}
+
+// renderRate renders a per-second rate followed by its sparkline
+func (l *LoadMetrics) renderRate(label string, rate float64, line *components.SparkLine) string {
+	return fmt.Sprintf("  %s %s %s\n",
+		l.muted.Render(label),
+		l.value.Render(fmt.Sprintf("%7s", formatRate(rate))),
+		line.Render(),
+	)
+}
+
+// formatRate shortens large event rates, e.g. 12345 to "12.3k"
+func formatRate(rate float64) string {
+	switch {
+	case rate >= 1e6:
+		return fmt.Sprintf("%.1fM", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%.1fk", rate/1e3)
+	}
+	return fmt.Sprintf("%.0f", rate)
+}
+
 func formatUptime(seconds uint64) string {
 	days := seconds / 86400
 	hours := (seconds % 86400) / 3600
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/shirou/gopsutil/v4/load"
+)
+
+func TestLoadMetricsCoreDivisor(t *testing.T) {
+	tests := []struct {
+		name string
+		cpu  data.CPUMetrics
+		want string
+	}{
+		{"logical cores", data.CPUMetrics{CoreCount: 8, LoadCores: 8}, "(25% of 8 cores)"},
+		{"physical cores", data.CPUMetrics{CoreCount: 8, LoadCores: 4}, "(50% of 4 cores)"},
+		{"no load cores", data.CPUMetrics{CoreCount: 8}, "(25% of 8 cores)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLoadMetrics()
+			l.SetWidth(80)
+			cpu := tt.cpu
+			out := ansi.Strip(l.Render(&data.SystemData{
+				CPU:  &cpu,
+				Host: &data.HostMetrics{LoadAvg: &load.AvgStat{Load1: 2, Load5: 2, Load15: 2}},
+			}))
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, out)
+			}
+		})
+	}
+}
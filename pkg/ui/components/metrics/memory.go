@@ -3,6 +3,7 @@ package metrics
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
@@ -11,6 +12,7 @@ import (
 
 // MemoryMetrics renders memory metrics
 type MemoryMetrics struct {
+	title       lipgloss.Style
 	label       lipgloss.Style
 	value       lipgloss.Style
 	muted       lipgloss.Style
@@ -19,28 +21,51 @@ type MemoryMetrics struct {
 	critical    lipgloss.Style
 	width       int
 	progressBar *components.ProgressBar
+	breakdown   *components.MemoryBreakdownBar
 	sparkline   *components.SparkLine
+	trendArrow  *components.TrendIndicator
+	trend       components.Trend
+	values      ValueDisplay
+
+	// Memory pressure gauge, see data.MemoryPressure
+	pressure         data.PressureWeights
+	pressureWarning  float64
+	pressureCritical float64
 }
 
 // NewMemoryMetrics creates a new memory metrics renderer
 func NewMemoryMetrics() *MemoryMetrics {
-	var colorForeground = lipgloss.Color("#f8f8f2")
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorOrange = lipgloss.Color("#ffb86c")
-	var colorRed = lipgloss.Color("#ff5555")
-
-	return &MemoryMetrics{
-		label:       lipgloss.NewStyle().Foreground(colorCyan),
-		value:       lipgloss.NewStyle().Foreground(colorForeground),
-		muted:       lipgloss.NewStyle().Foreground(colorComment),
-		normal:      lipgloss.NewStyle().Foreground(colorGreen),
-		warning:     lipgloss.NewStyle().Foreground(colorOrange),
-		critical:    lipgloss.NewStyle().Foreground(colorRed).Bold(true),
+	m := &MemoryMetrics{
 		progressBar: components.NewProgressBar(),
+		breakdown:   components.NewMemoryBreakdownBar(),
 		sparkline:   components.NewSparkLine(),
+		trendArrow:  components.NewTrendIndicator(),
+		values:      DefaultValueDisplay,
+		pressure: data.PressureWeights{
+			Available:    0.6,
+			Swap:         0.4,
+			SwapFullRate: 10 * 1024 * 1024,
+		},
+		pressureWarning:  60,
+		pressureCritical: 85,
 	}
+	m.SetTheme(components.DarkTheme())
+	return m
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (m *MemoryMetrics) SetTheme(t *components.Theme) {
+	m.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	m.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	m.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	m.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	m.normal = lipgloss.NewStyle().Foreground(t.Green)
+	m.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	m.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	m.progressBar.SetTheme(t)
+	m.breakdown.SetTheme(t)
+	m.sparkline.SetTheme(t)
+	m.trendArrow.SetTheme(t)
 }
 
 // SetWidth sets the render width
@@ -54,39 +79,75 @@ func (m *MemoryMetrics) SetWidth(w int) {
 	m.sparkline.SetWidth(sparkWidth)
 }
 
-// SetHistory sets the historical data for sparklines
-func (m *MemoryMetrics) SetHistory(data []float64) {
+// SetHistory sets the historical data for sparklines and when each value
+// was sampled
+func (m *MemoryMetrics) SetHistory(data []float64, times []time.Time) {
 	m.sparkline.SetData(data)
+	m.sparkline.SetTimes(times)
+}
+
+// SetValueDisplay sets whether used memory is shown as percent, size, or both
+func (m *MemoryMetrics) SetValueDisplay(values ValueDisplay) {
+	m.values = values
+}
+
+// SetTrend sets the direction arrow shown next to used memory
+func (m *MemoryMetrics) SetTrend(trend components.Trend) {
+	m.trend = trend
+}
+
+// SetPressure sets the weights and coloring levels of the memory pressure
+// gauge at the top of the panel
+func (m *MemoryMetrics) SetPressure(weights data.PressureWeights, warning, critical float64) {
+	m.pressure = weights
+	m.pressureWarning = warning
+	m.pressureCritical = critical
 }
 
 // Render returns the rendered memory metrics
 func (m *MemoryMetrics) Render(systemData *data.SystemData) string {
-	if systemData == nil || systemData.Memory == nil {
-		return m.muted.Render("Loading memory data...")
+	if state := panelState(systemData, "memory"); state != data.StateReady {
+		return renderState(state, systemData, "memory", "memory", m.muted, m.critical)
 	}
 
 	mem := systemData.Memory
 	var b strings.Builder
 
 	// Title
-	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#bd93f9")).Bold(true).Render("Memory Usage"))
+	b.WriteString(m.title.Render("Memory Usage"))
 	b.WriteString("\n\n")
 
+	// Pressure gauge first: it says more than used% about whether the
+	// system is actually short on memory
+	if pressure, ok := data.MemoryPressure(mem, m.pressure); ok {
+		pressureStyle := m.getMetricStyle(pressure, m.pressureWarning, m.pressureCritical)
+		b.WriteString(fmt.Sprintf("%sPressure:%s  %s\n",
+			m.label,
+			m.value,
+			pressureStyle.Render(fmt.Sprintf("%.1f%%", pressure)),
+		))
+		m.progressBar.SetWidth(30)
+		b.WriteString(m.progressBar.RenderDynamic(pressure, m.pressureWarning, m.pressureCritical))
+		b.WriteString("\n\n")
+	}
+
 	// Memory stats with progress bar
-	b.WriteString(fmt.Sprintf("%sTotal:%s     %s\n",
+	b.WriteString(fmt.Sprintf("%sTotal:%s     %s",
 		m.label,
 		m.value,
 		m.formatBytes(mem.Total),
 	))
+	if mem.Cgroup {
+		b.WriteString(m.muted.Render(" (cgroup limit)"))
+	}
+	b.WriteString("\n")
 
 	usedStyle := m.getMetricStyle(mem.UsedPercent, 80, 95)
-	b.WriteString(fmt.Sprintf("%sUsed:%s      %s (%s%.1f%%%s)\n",
+	b.WriteString(fmt.Sprintf("%sUsed:%s      %s%s\n",
 		m.label,
 		m.value,
-		m.formatBytes(mem.Used),
-		usedStyle,
-		mem.UsedPercent,
-		m.value,
+		m.values.usageText(m.formatBytes(mem.Used), "", mem.UsedPercent, usedStyle, m.value),
+		m.trendArrow.Render(m.trend),
 	))
 
 	// Progress bar for memory usage
@@ -96,10 +157,12 @@ func (m *MemoryMetrics) Render(systemData *data.SystemData) string {
 
 	// Sparkline for memory history
 	if m.sparkline.GetLastValue() > 0 {
-		b.WriteString(m.label.Render("History:"))
-		b.WriteString(" ")
-		b.WriteString(fmt.Sprintf("%.1f%% ", m.sparkline.GetLastValue()))
+		prefix := m.label.Render("History:") + " " + fmt.Sprintf("%.1f%% ", m.sparkline.GetLastValue())
+		b.WriteString(prefix)
 		b.WriteString(m.sparkline.RenderWithColor(80, 95))
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(" ", lipgloss.Width(prefix)))
+		b.WriteString(m.sparkline.RenderTimeAxis())
 		b.WriteString("\n\n")
 	}
 
@@ -115,6 +178,12 @@ func (m *MemoryMetrics) Render(systemData *data.SystemData) string {
 		m.formatBytes(mem.Free),
 	))
 
+	// What the memory is used for, from /proc/meminfo
+	if mem.Breakdown != nil {
+		b.WriteString("\n")
+		b.WriteString(m.renderBreakdown(mem))
+	}
+
 	// Swap info
 	if mem.Swap.Total > 0 {
 		b.WriteString("\n")
@@ -122,21 +191,115 @@ func (m *MemoryMetrics) Render(systemData *data.SystemData) string {
 		b.WriteString("\n")
 
 		swapStyle := m.getMetricStyle(mem.Swap.UsedPercent, 50, 80)
-		b.WriteString(fmt.Sprintf("  %s / %s (%s%.1f%%%s)\n",
+		b.WriteString("  ")
+		b.WriteString(m.values.usageText(
 			m.formatBytes(mem.Swap.Used),
 			m.formatBytes(mem.Swap.Total),
-			swapStyle,
 			mem.Swap.UsedPercent,
+			swapStyle,
 			m.value,
 		))
+		b.WriteString("\n")
 
 		// Swap progress bar
 		m.progressBar.SetWidth(25)
 		b.WriteString("  ")
 		b.WriteString(m.progressBar.RenderDynamic(mem.Swap.UsedPercent, 50, 80))
 		b.WriteString("\n")
+
+		if mem.Swap.InPerSec > 0 || mem.Swap.OutPerSec > 0 {
+			b.WriteString(m.muted.Render(fmt.Sprintf("  in %s/s, out %s/s",
+				m.formatBytes(uint64(mem.Swap.InPerSec)),
+				m.formatBytes(uint64(mem.Swap.OutPerSec)),
+			)))
+			b.WriteString("\n")
+		}
+	}
+
+	// Per-node breakdown on multi-socket machines
+	if len(mem.NUMA) > 0 {
+		b.WriteString("\n")
+		b.WriteString(m.label.Render("NUMA Nodes:"))
+		b.WriteString("\n")
+		for _, node := range mem.NUMA {
+			b.WriteString(m.renderNUMANode(node))
+		}
+	}
+
+	if partial := renderPartial(systemData, "memory", m.warning); partial != "" {
+		b.WriteString("\n")
+		b.WriteString(partial)
+	}
+
+	return b.String()
+}
+
+// renderBreakdown renders the stacked apps/buffers/cache/slab bar with its
+// legend, the shared and dirty page totals, and the huge page pool if any
+func (m *MemoryMetrics) renderBreakdown(mem *data.MemoryMetrics) string {
+	var b strings.Builder
+	b.WriteString(m.label.Render("Breakdown:"))
+	b.WriteString("\n  ")
+	m.breakdown.SetWidth(30)
+	b.WriteString(m.breakdown.Render(mem.Breakdown))
+	b.WriteString("\n  ")
+	b.WriteString(m.breakdown.RenderLegend(mem.Breakdown))
+	b.WriteString("\n")
+
+	b.WriteString(m.muted.Render(fmt.Sprintf("  buffers %s, cached %s, shared %s",
+		m.formatBytes(mem.Buffers),
+		m.formatBytes(mem.Cached),
+		m.formatBytes(mem.Shared),
+	)))
+	b.WriteString("\n")
+	b.WriteString(m.muted.Render(fmt.Sprintf("  slab %s, dirty %s",
+		m.formatBytes(mem.Slab),
+		m.formatBytes(mem.Dirty),
+	)))
+	b.WriteString("\n")
+
+	if huge := mem.HugePages; huge.Total > 0 {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			m.value.Render(fmt.Sprintf("HugePages %d/%d used", huge.Total-huge.Free, huge.Total)),
+			m.muted.Render(fmt.Sprintf("(%s pages, %s pool)",
+				m.formatBytes(huge.PageSize),
+				m.formatBytes(huge.Total*huge.PageSize),
+			)),
+		))
+	}
+	return b.String()
+}
+
+// renderNUMANode renders a node's memory usage and how many of its
+// allocations stayed local since boot
+func (m *MemoryMetrics) renderNUMANode(node data.NUMANode) string {
+	var b strings.Builder
+	if node.Total == 0 {
+		b.WriteString(fmt.Sprintf("  node%d %s\n", node.ID, m.muted.Render("no memory")))
+		return b.String()
 	}
 
+	usedPercent := node.UsedPercent()
+	b.WriteString(fmt.Sprintf("  node%d ", node.ID))
+	b.WriteString(m.values.usageText(
+		m.formatBytes(node.Used),
+		m.formatBytes(node.Total),
+		usedPercent,
+		m.getMetricStyle(usedPercent, 80, 95),
+		m.value,
+	))
+	b.WriteString("\n")
+
+	m.progressBar.SetWidth(25)
+	b.WriteString("  ")
+	b.WriteString(m.progressBar.RenderDynamic(usedPercent, 80, 95))
+	b.WriteString("\n")
+
+	if local, ok := node.LocalPercent(); ok {
+		b.WriteString(m.muted.Render(fmt.Sprintf("  local %.1f%%, miss %d, foreign %d pages",
+			local, node.Miss, node.Foreign)))
+		b.WriteString("\n")
+	}
 	return b.String()
 }
 
@@ -2,57 +2,181 @@ package metrics
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
 )
 
 // NetworkMetrics renders network metrics
 type NetworkMetrics struct {
-	label   lipgloss.Style
-	value   lipgloss.Style
-	muted   lipgloss.Style
-	normal  lipgloss.Style
-	warning lipgloss.Style
-	width   int
+	title    lipgloss.Style
+	label    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	normal   lipgloss.Style
+	warning  lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	aliases  map[string]string // Interface name -> display name
+	minSpeed int               // Link speed in Mb/s below which a link is flagged; 0 disables
+	arrow    *components.TrendIndicator
+	rxTrend  components.Trend
+	txTrend  components.Trend
+
+	// Conntrack table usage percentages that color its gauge
+	conntrackWarn float64
+	conntrackCrit float64
+
+	// Days left before expiry that color TLS certificates
+	certWarn float64
+	certCrit float64
+
+	// Ping round-trip time and loss history, keyed by target
+	sparkline *components.SparkLine
+	latency   map[string][]float64
+	loss      map[string][]float64
+
+	// Receive/transmit rate history per interface, RX drawn above TX
+	rxLine     *components.SparkLine
+	txLine     *components.SparkLine
+	interfaces map[string]data.RxTxHistory
 }
 
 // NewNetworkMetrics creates a new network metrics renderer
 func NewNetworkMetrics() *NetworkMetrics {
-	var colorForeground = lipgloss.Color("#f8f8f2")
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorOrange = lipgloss.Color("#ffb86c")
-
-	return &NetworkMetrics{
-		label:   lipgloss.NewStyle().Foreground(colorCyan),
-		value:   lipgloss.NewStyle().Foreground(colorForeground),
-		muted:   lipgloss.NewStyle().Foreground(colorComment),
-		normal:  lipgloss.NewStyle().Foreground(colorGreen),
-		warning: lipgloss.NewStyle().Foreground(colorOrange),
+	n := &NetworkMetrics{
+		arrow:         components.NewTrendIndicator(),
+		sparkline:     components.NewSparkLine(),
+		rxLine:        components.NewSparkLine(),
+		txLine:        components.NewSparkLine(),
+		conntrackWarn: 80,
+		conntrackCrit: 95,
+		certWarn:      30,
+		certCrit:      7,
 	}
+	n.SetTheme(components.DarkTheme())
+	return n
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (n *NetworkMetrics) SetTheme(t *components.Theme) {
+	n.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	n.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	n.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	n.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	n.normal = lipgloss.NewStyle().Foreground(t.Green)
+	n.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	n.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	n.arrow.SetTheme(t)
+	n.sparkline.SetTheme(t)
+	n.rxLine.SetTheme(t)
+	n.txLine.SetTheme(t)
+	n.txLine.SetStyle(lipgloss.NewStyle().Foreground(t.Purple))
 }
 
 // SetWidth sets the render width
 func (n *NetworkMetrics) SetWidth(w int) {
 	n.width = w
+	sparkWidth := w - 12
+	if sparkWidth < 10 {
+		sparkWidth = 10
+	}
+	n.sparkline.SetWidth(sparkWidth)
+
+	// "  RX: ", a byte rate padded to 12 cells and a bit rate padded to 11
+	// come first
+	rateWidth := w - 31
+	if rateWidth < 10 {
+		rateWidth = 10
+	}
+	n.rxLine.SetWidth(rateWidth)
+	n.txLine.SetWidth(rateWidth)
+}
+
+// SetLatencyHistory sets the ping round-trip time and loss history used
+// for sparklines, keyed by target
+func (n *NetworkMetrics) SetLatencyHistory(latency, loss map[string][]float64) {
+	n.latency = latency
+	n.loss = loss
+}
+
+// SetInterfaceHistory sets the receive/transmit rate history of each
+// interface, keyed by interface name
+func (n *NetworkMetrics) SetInterfaceHistory(history map[string]data.RxTxHistory) {
+	n.interfaces = history
+}
+
+// SetTrends sets the direction arrows for total receive and transmit rates
+func (n *NetworkMetrics) SetTrends(rx, tx components.Trend) {
+	n.rxTrend = rx
+	n.txTrend = tx
+}
+
+// SetAliases sets friendly display names for interfaces, keyed by the
+// real interface name
+func (n *NetworkMetrics) SetAliases(aliases map[string]string) {
+	n.aliases = aliases
+}
+
+// SetMinLinkSpeed sets the link speed in Mb/s below which a wired link is
+// highlighted as degraded; 0 disables the warning
+func (n *NetworkMetrics) SetMinLinkSpeed(mbps int) {
+	n.minSpeed = mbps
+}
+
+// SetConntrackThresholds sets the conntrack table usage percentages at
+// which its gauge turns orange and red
+func (n *NetworkMetrics) SetConntrackThresholds(warning, critical float64) {
+	n.conntrackWarn = warning
+	n.conntrackCrit = critical
+}
+
+// SetCertThresholds sets the days left before expiry at which a TLS
+// certificate turns orange and red
+func (n *NetworkMetrics) SetCertThresholds(warning, critical float64) {
+	n.certWarn = warning
+	n.certCrit = critical
+}
+
+// displayName returns the alias for an interface, or its real name. Config
+// keys are lowercased on load, so the lowercase name is tried as well.
+func (n *NetworkMetrics) displayName(name string) string {
+	if alias, ok := n.aliases[name]; ok {
+		return alias
+	}
+	if alias, ok := n.aliases[strings.ToLower(name)]; ok {
+		return alias
+	}
+	return name
 }
 
 // Render returns the rendered network metrics
 func (n *NetworkMetrics) Render(systemData *data.SystemData) string {
-	if systemData == nil || systemData.Network == nil {
-		return n.muted.Render("Loading network data...")
+	if state := panelState(systemData, "network"); state != data.StateReady {
+		return renderState(state, systemData, "network", "network", n.muted, n.warning)
 	}
 
 	net := systemData.Network
 	var content strings.Builder
 
 	// Title
-	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#bd93f9")).Bold(true).Render("Network Interfaces"))
+	content.WriteString(n.title.Render("Network Interfaces"))
+	if n.rxTrend != components.TrendSteady {
+		content.WriteString(n.muted.Render(" RX") + n.arrow.Render(n.rxTrend))
+	}
+	if n.txTrend != components.TrendSteady {
+		content.WriteString(n.muted.Render(" TX") + n.arrow.Render(n.txTrend))
+	}
 	content.WriteString("\n\n")
 
+	content.WriteString(n.renderLatency(systemData))
+	content.WriteString(n.renderConntrack(systemData.Conntrack))
+	content.WriteString(n.renderCerts(systemData))
+
 	// Network stats per interface
 	for _, iface := range net.Interfaces {
 		io, ok := net.IO[iface.Name]
@@ -62,7 +186,7 @@ func (n *NetworkMetrics) Render(systemData *data.SystemData) string {
 
 		content.WriteString(fmt.Sprintf("%s%s%s\n",
 			n.label,
-			iface.Name,
+			n.displayName(iface.Name),
 			n.value,
 		))
 
@@ -74,60 +198,285 @@ func (n *NetworkMetrics) Render(systemData *data.SystemData) string {
 			))
 		}
 
-		// RX with gauge (scale to 1 GB max for visualization)
-		maxBytes := uint64(1024 * 1024 * 1024) // 1 GB
-		rxGauge := n.renderByteGauge(io.BytesRecv, maxBytes)
-		txGauge := n.renderByteGauge(io.BytesSent, maxBytes)
-
-		content.WriteString(fmt.Sprintf("  %sRX:%s %s %s\n",
-			n.muted,
-			n.value,
-			n.formatBytes(io.BytesRecv),
-			rxGauge,
-		))
+		if wifi, ok := net.Wireless[iface.Name]; ok {
+			content.WriteString(n.renderWireless(wifi))
+		} else if link, ok := net.Links[iface.Name]; ok {
+			content.WriteString(n.renderLink(link))
+		}
 
-		content.WriteString(fmt.Sprintf("  %sTX:%s %s %s\n\n",
-			n.muted,
-			n.value,
-			n.formatBytes(io.BytesSent),
-			txGauge,
-		))
+		// Throughput graphs once there are rates, with the lifetime
+		// counters below. Rates need two collections, so the first one
+		// only has the counters.
+		if history, ok := n.interfaces[iface.Name]; ok && len(history.Rx) > 0 {
+			content.WriteString(n.renderThroughput(history, io.BytesRecv, io.BytesSent))
+			continue
+		}
+		content.WriteString(fmt.Sprintf("  %s\n", n.muted.Render("RX/TX: measuring…")))
+		content.WriteString(n.renderTotals(io.BytesRecv, io.BytesSent))
 	}
 
+	content.WriteString(renderPartial(systemData, "network", n.warning))
+
 	return content.String()
 }
 
-// renderByteGauge creates a visual gauge for bytes transferred
-func (n *NetworkMetrics) renderByteGauge(bytes, maxBytes uint64) string {
-	width := 15
+// renderThroughput renders an interface's current receive and transmit
+// rates in bytes and bits per second with their sparklines, RX above TX,
+// and the total bytes moved
+func (n *NetworkMetrics) renderThroughput(history data.RxTxHistory, totalRecv, totalSent uint64) string {
+	var b strings.Builder
+	n.rxLine.SetData(history.Rx)
+	n.txLine.SetData(history.Tx)
+	b.WriteString(n.renderRate("RX:", n.rxLine))
+	b.WriteString(n.renderRate("TX:", n.txLine))
+	b.WriteString(n.renderTotals(totalRecv, totalSent))
+	return b.String()
+}
+
+// renderRate renders one direction's latest rate and its sparkline
+func (n *NetworkMetrics) renderRate(label string, line *components.SparkLine) string {
+	rate := line.GetLastValue()
+	return fmt.Sprintf("  %s %s %s %s\n",
+		n.muted.Render(label),
+		n.value.Render(fmt.Sprintf("%-12s", n.formatBytes(uint64(rate))+"/s")),
+		n.muted.Render(fmt.Sprintf("%-11s", formatBitRate(rate))),
+		line.Render(),
+	)
+}
+
+// renderTotals renders the bytes an interface has moved since boot
+func (n *NetworkMetrics) renderTotals(totalRecv, totalSent uint64) string {
+	return fmt.Sprintf("  %s\n\n", n.muted.Render(fmt.Sprintf("Total: %s in, %s out",
+		n.formatBytes(totalRecv),
+		n.formatBytes(totalSent),
+	)))
+}
+
+// Round-trip times (ms) and loss (%) above which a ping target is flagged
+const (
+	rttWarning   = 100
+	rttCritical  = 300
+	lossCritical = 50
+)
 
-	if bytes == 0 {
-		return strings.Repeat("░", width)
+// renderLatency renders the round-trip time and loss of each ping target
+// with their sparklines, so slowness can be told apart from a local problem
+func (n *NetworkMetrics) renderLatency(systemData *data.SystemData) string {
+	if panelState(systemData, "ping") == data.StateError {
+		return n.label.Render("Latency") + "\n" +
+			n.muted.Render("  "+systemData.CollectorErrors["ping"].Error()) + "\n\n"
+	}
+	if systemData.Ping == nil || len(systemData.Ping.Targets) == 0 {
+		return ""
 	}
 
-	// Calculate fill percentage
-	var percent float64
-	if bytes >= maxBytes {
-		percent = 1.0
-	} else {
-		percent = float64(bytes) / float64(maxBytes)
+	var b strings.Builder
+	b.WriteString(n.label.Render("Latency"))
+	b.WriteString("\n")
+	for _, target := range systemData.Ping.Targets {
+		rttStyle := n.normal
+		switch {
+		case target.RTT >= rttCritical:
+			rttStyle = n.critical
+		case target.RTT >= rttWarning:
+			rttStyle = n.warning
+		}
+		lossStyle := n.normal
+		switch {
+		case target.Loss >= lossCritical:
+			lossStyle = n.critical
+		case target.Loss > 0:
+			lossStyle = n.warning
+		}
+
+		rtt := n.muted.Render("no reply")
+		if target.Loss < 100 {
+			rtt = rttStyle.Render(fmt.Sprintf("%.1f ms", target.RTT))
+		}
+		b.WriteString(fmt.Sprintf("  %s %s %s\n",
+			n.value.Render(target.Host),
+			rtt,
+			lossStyle.Render(fmt.Sprintf("loss %.0f%%", target.Loss)),
+		))
+
+		if history := n.latency[target.Host]; len(history) > 1 {
+			n.sparkline.SetData(history)
+			b.WriteString("    " + n.muted.Render("RTT  ") + n.sparkline.Render() + "\n")
+		}
+		if history := n.loss[target.Host]; len(history) > 1 {
+			n.sparkline.SetData(history)
+			b.WriteString("    " + n.muted.Render("Loss ") + n.sparkline.Render() + "\n")
+		}
 	}
+	b.WriteString(renderPartial(systemData, "ping", n.warning))
+	b.WriteString("\n")
+	return b.String()
+}
 
-	filledWidth := int(float64(width) * percent)
-	if filledWidth > width {
-		filledWidth = width
+// renderConntrack renders how full the netfilter connection tracking table
+// is, and how many new connections it dropped, when nf_conntrack is loaded
+func (n *NetworkMetrics) renderConntrack(conntrack *data.ConntrackMetrics) string {
+	if conntrack == nil || conntrack.Max == 0 {
+		return ""
 	}
 
-	// Choose color based on usage
+	percent := conntrack.UsedPercent()
 	style := n.normal
-	if percent > 0.7 {
+	switch {
+	case percent >= n.conntrackCrit:
+		style = n.critical
+	case percent >= n.conntrackWarn:
 		style = n.warning
 	}
+	gauge := renderGauge(float64(conntrack.Count), float64(conntrack.Max), 20, n.muted, style)
+	line := "  " + gauge + " " + style.Render(fmt.Sprintf("%d", conntrack.Count)) +
+		n.muted.Render(fmt.Sprintf(" / %d (%.1f%%)", conntrack.Max, percent))
+	if conntrack.Drops > 0 {
+		line += " " + n.warning.Render(fmt.Sprintf("%d dropped", conntrack.Drops))
+	}
+	return n.label.Render("Conntrack") + "\n" + line + "\n\n"
+}
 
-	filled := strings.Repeat("█", filledWidth)
-	empty := strings.Repeat("░", width-filledWidth)
+// renderCerts renders the days left before each watched TLS endpoint's
+// certificate expires, flagging chains the system doesn't trust
+func (n *NetworkMetrics) renderCerts(systemData *data.SystemData) string {
+	if panelState(systemData, "certs") == data.StateError {
+		return n.label.Render("Certificates") + "\n" +
+			n.muted.Render("  "+systemData.CollectorErrors["certs"].Error()) + "\n\n"
+	}
+	partial := renderPartial(systemData, "certs", n.warning)
+	if (systemData.Certs == nil || len(systemData.Certs.Certs) == 0) && partial == "" {
+		return ""
+	}
 
-	return style.Render(filled) + n.normal.Render(empty)
+	var b strings.Builder
+	b.WriteString(n.label.Render("Certificates"))
+	b.WriteString("\n")
+	now := time.Now()
+	if systemData.Certs != nil {
+		for _, cert := range systemData.Certs.Certs {
+			days := cert.DaysLeft(now)
+			style := n.normal
+			switch {
+			case days < n.certCrit:
+				style = n.critical
+			case days < n.certWarn:
+				style = n.warning
+			}
+			left := fmt.Sprintf("%.0f days", math.Floor(days))
+			if days < 0 {
+				left = "expired"
+			}
+			b.WriteString(fmt.Sprintf("  %s %s %s",
+				n.value.Render(cert.Endpoint),
+				style.Render(left),
+				n.muted.Render(cert.NotAfter.Local().Format("Jan 02 2006")),
+			))
+			if !cert.Verified {
+				b.WriteString(" " + n.warning.Render("untrusted"))
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(partial)
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderWireless renders the Wi-Fi line of a wireless interface: SSID,
+// signal and quality, bitrate and channel, skipping unknown readings
+func (n *NetworkMetrics) renderWireless(wifi data.WirelessStat) string {
+	var parts []string
+	if wifi.SSID != "" {
+		parts = append(parts, n.value.Render(wifi.SSID))
+	}
+	if wifi.Signal < 0 {
+		signal := fmt.Sprintf("%.0f dBm", wifi.Signal)
+		if wifi.Quality >= 0 {
+			signal += fmt.Sprintf(" (%.0f%%)", wifi.Quality)
+		}
+		parts = append(parts, n.signalStyle(wifi.Signal).Render(signal))
+	}
+	if wifi.Bitrate > 0 {
+		parts = append(parts, n.value.Render(fmt.Sprintf("%.0f Mbit/s", wifi.Bitrate)))
+	}
+	if wifi.Channel > 0 {
+		parts = append(parts, n.muted.Render(fmt.Sprintf("ch %d", wifi.Channel)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + n.muted.Render("WiFi:") + " " + strings.Join(parts, n.muted.Render(" · ")) + "\n"
+}
+
+// renderLink renders the Link line of a wired interface, e.g.
+// "1000 Mb/s full, up". Links slower than the minimum speed, half duplex
+// links and links that aren't up are highlighted.
+func (n *NetworkMetrics) renderLink(link data.LinkStat) string {
+	var parts []string
+	if link.Speed > 0 {
+		style := n.value
+		if n.minSpeed > 0 && link.Speed < n.minSpeed {
+			style = n.warning
+		}
+		speed := n.formatSpeed(link.Speed)
+		if link.Duplex != "" {
+			duplex := link.Duplex
+			if duplex == "half" {
+				duplex = n.warning.Render(duplex)
+			}
+			speed = style.Render(speed) + " " + duplex
+		} else {
+			speed = style.Render(speed)
+		}
+		parts = append(parts, speed)
+	}
+	if link.OperState != "" && link.OperState != "unknown" {
+		style := n.normal
+		if link.OperState != "up" {
+			style = n.warning
+		}
+		parts = append(parts, style.Render(link.OperState))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + n.muted.Render("Link:") + " " + strings.Join(parts, n.muted.Render(", ")) + "\n"
+}
+
+// formatSpeed formats a link speed, switching to Gb/s for 10 Gb/s and up
+func (n *NetworkMetrics) formatSpeed(mbps int) string {
+	if mbps >= 10000 && mbps%1000 == 0 {
+		return fmt.Sprintf("%d Gb/s", mbps/1000)
+	}
+	return fmt.Sprintf("%d Mb/s", mbps)
+}
+
+// formatBitRate formats a byte rate in bits per second, in the same units
+// as link speeds so the two compare at a glance
+func formatBitRate(bytesPerSec float64) string {
+	bits := bytesPerSec * 8
+	switch {
+	case bits >= 1e9:
+		return fmt.Sprintf("%.1f Gb/s", bits/1e9)
+	case bits >= 1e6:
+		return fmt.Sprintf("%.1f Mb/s", bits/1e6)
+	case bits >= 1e3:
+		return fmt.Sprintf("%.1f kb/s", bits/1e3)
+	}
+	return fmt.Sprintf("%.0f b/s", bits)
+}
+
+// signalStyle colors a Wi-Fi signal: above -67 dBm is good for most uses,
+// below -80 dBm the link is unreliable
+func (n *NetworkMetrics) signalStyle(dbm float64) lipgloss.Style {
+	if dbm < -80 {
+		return n.critical
+	}
+	if dbm < -67 {
+		return n.warning
+	}
+	return n.normal
 }
 
 func (n *NetworkMetrics) formatBytes(b uint64) string {
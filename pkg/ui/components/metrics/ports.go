@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// PortMetrics renders the listening TCP and UDP ports as a scrollable table
+type PortMetrics struct {
+	title    lipgloss.Style
+	label    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	table    *components.Table
+}
+
+// NewPortMetrics creates a new listening ports renderer
+func NewPortMetrics() *PortMetrics {
+	p := &PortMetrics{
+		table: components.NewTable([]components.Column{
+			{Title: "PORT", Width: 6, Align: components.AlignRight},
+			{Title: "PROTO", Width: 5},
+			{Title: "ADDRESS", MinWidth: 15},
+			{Title: "PID", Width: 7, Align: components.AlignRight},
+			{Title: "PROCESS", Width: 16},
+		}),
+	}
+	p.SetTheme(components.DarkTheme())
+	return p
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (p *PortMetrics) SetTheme(t *components.Theme) {
+	p.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	p.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	p.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	p.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	p.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	p.table.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (p *PortMetrics) SetWidth(w int) {
+	p.width = w
+	p.table.SetWidth(w)
+}
+
+// SetHeight sets the render height; rows beyond it scroll
+func (p *PortMetrics) SetHeight(h int) {
+	// Title, table header and footer take 6 lines
+	rows := 0
+	if h > 0 {
+		rows = max(h-6, 1)
+	}
+	p.table.SetHeight(rows)
+}
+
+// ScrollUp scrolls the port table up one row
+func (p *PortMetrics) ScrollUp() {
+	p.table.ScrollUp()
+}
+
+// ScrollDown scrolls the port table down one row
+func (p *PortMetrics) ScrollDown() {
+	p.table.ScrollDown()
+}
+
+// Render returns the rendered listening ports
+func (p *PortMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "connections"); state != data.StateReady {
+		return renderState(state, systemData, "connections", "connection", p.muted, p.critical)
+	}
+
+	ports := systemData.Connections.ListeningPorts()
+	var b strings.Builder
+
+	// Title
+	b.WriteString(p.title.Render("Listening Ports"))
+	b.WriteString("\n\n")
+
+	if len(ports) == 0 {
+		b.WriteString(p.muted.Render("No listening ports"))
+		return b.String()
+	}
+
+	rows := make([]components.Row, 0, len(ports))
+	for _, port := range ports {
+		pid, process := "-", port.Process
+		if port.PID > 0 {
+			pid = strconv.Itoa(int(port.PID))
+		}
+		if process == "" {
+			process = "-"
+		}
+		// Ports open to every address are the ones reachable from outside
+		addressStyle := p.muted
+		if port.Address == "*" {
+			addressStyle = p.value
+		}
+		rows = append(rows, components.Row{
+			{Text: strconv.Itoa(int(port.Port)), Style: p.label},
+			{Text: port.Protocol, Style: p.muted},
+			{Text: port.Address, Style: addressStyle},
+			{Text: pid, Style: p.muted},
+			{Text: process, Style: p.value},
+		})
+	}
+	p.table.SetRows(rows)
+
+	b.WriteString(p.table.Render())
+	b.WriteString("\n\n")
+
+	first, last := p.table.VisibleRange()
+	b.WriteString(p.muted.Render(fmt.Sprintf("Showing %d-%d of %d ports", first+1, last, len(ports))))
+
+	return b.String()
+}
@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// ServiceMetrics renders failed systemd units and the running services with
+// their CPU and memory usage as a scrollable table
+type ServiceMetrics struct {
+	title    lipgloss.Style
+	label    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	normal   lipgloss.Style
+	warning  lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	table    *components.Table
+}
+
+// NewServiceMetrics creates a new systemd service renderer
+func NewServiceMetrics() *ServiceMetrics {
+	s := &ServiceMetrics{
+		table: components.NewTable([]components.Column{
+			{Title: "UNIT", MinWidth: 20},
+			{Title: "STATE", Width: 12},
+			{Title: "CPU%", Width: 6, Align: components.AlignRight},
+			{Title: "MEMORY", Width: 10, Align: components.AlignRight},
+			{Title: "DESCRIPTION", MinWidth: 15},
+		}),
+	}
+	s.SetTheme(components.DarkTheme())
+	return s
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (s *ServiceMetrics) SetTheme(t *components.Theme) {
+	s.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	s.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	s.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	s.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	s.normal = lipgloss.NewStyle().Foreground(t.Green)
+	s.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	s.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	s.table.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (s *ServiceMetrics) SetWidth(w int) {
+	s.width = w
+	s.table.SetWidth(w)
+}
+
+// SetHeight sets the render height; rows beyond it scroll
+func (s *ServiceMetrics) SetHeight(h int) {
+	// Title, state summary, table header and footer take 8 lines
+	rows := 0
+	if h > 0 {
+		rows = max(h-8, 1)
+	}
+	s.table.SetHeight(rows)
+}
+
+// ScrollUp scrolls the service table up one row
+func (s *ServiceMetrics) ScrollUp() {
+	s.table.ScrollUp()
+}
+
+// ScrollDown scrolls the service table down one row
+func (s *ServiceMetrics) ScrollDown() {
+	s.table.ScrollDown()
+}
+
+// Render returns the rendered systemd services
+func (s *ServiceMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "services"); state != data.StateReady {
+		return renderState(state, systemData, "services", "service", s.muted, s.critical)
+	}
+
+	metrics := systemData.Services
+	var b strings.Builder
+
+	// Title
+	b.WriteString(s.title.Render("Services"))
+	b.WriteString(s.muted.Render(" (systemd)"))
+	b.WriteString("\n\n")
+
+	// Summary
+	active := len(metrics.Services)
+	for _, service := range metrics.Services {
+		if service.ActiveState == "failed" {
+			active--
+		}
+	}
+	b.WriteString(s.label.Render("Active "))
+	b.WriteString(s.value.Render(fmt.Sprintf("%d", active)))
+	b.WriteString(s.muted.Render(" · "))
+	b.WriteString(s.label.Render("Failed "))
+	if metrics.Failed > 0 {
+		b.WriteString(s.critical.Render(fmt.Sprintf("%d", metrics.Failed)))
+	} else {
+		b.WriteString(s.normal.Render("0"))
+	}
+	b.WriteString("\n\n")
+
+	if len(metrics.Services) == 0 {
+		b.WriteString(s.muted.Render("No active services"))
+		return b.String()
+	}
+
+	rows := make([]components.Row, 0, len(metrics.Services))
+	for _, service := range metrics.Services {
+		nameStyle, stateStyle := s.value, s.normal
+		switch service.ActiveState {
+		case "failed":
+			nameStyle, stateStyle = s.critical, s.critical
+		case "active":
+			if service.SubState != "running" {
+				stateStyle = s.muted
+			}
+		default:
+			// activating, deactivating, reloading
+			stateStyle = s.warning
+		}
+		cpu, memory := "-", "-"
+		if service.ActiveState != "failed" {
+			cpu = fmt.Sprintf("%.1f", service.CPU)
+			if service.Memory > 0 {
+				memory = s.formatBytes(service.Memory)
+			}
+		}
+		rows = append(rows, components.Row{
+			{Text: service.Name, Style: nameStyle},
+			{Text: service.SubState, Style: stateStyle},
+			{Text: cpu, Style: s.value},
+			{Text: memory, Style: s.value},
+			{Text: service.Description, Style: s.muted},
+		})
+	}
+	s.table.SetRows(rows)
+
+	b.WriteString(s.table.Render())
+	b.WriteString("\n\n")
+
+	first, last := s.table.VisibleRange()
+	b.WriteString(s.muted.Render(fmt.Sprintf("Showing %d-%d of %d units (↑/↓ to scroll)", first+1, last, len(metrics.Services))))
+
+	return b.String()
+}
+
+func (s *ServiceMetrics) formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
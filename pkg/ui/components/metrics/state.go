@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// panelState returns the state of the collector backing a panel
+func panelState(systemData *data.SystemData, collector string) data.CollectorState {
+	if systemData == nil {
+		return data.StateLoading
+	}
+	return systemData.CollectorState(collector)
+}
+
+// renderState renders the placeholder shown when a panel has no data to display
+func renderState(state data.CollectorState, systemData *data.SystemData, collector, name string, muted, errStyle lipgloss.Style) string {
+	switch state {
+	case data.StateDisabled:
+		return muted.Render(fmt.Sprintf("%s collector disabled in config", name))
+	case data.StateError:
+		msg := errStyle.Render(fmt.Sprintf("%s collection failed", name))
+		if err := systemData.CollectorErrors[collector]; err != nil {
+			msg += "\n" + muted.Render(err.Error())
+		}
+		return msg
+	default:
+		return muted.Render(fmt.Sprintf("Loading %s data...", name))
+	}
+}
+
+// renderPartial renders a note listing the items a collector could not read,
+// or nothing if its last collection fully succeeded
+func renderPartial(systemData *data.SystemData, collector string, warnStyle lipgloss.Style) string {
+	partial := systemData.PartialFailure(collector)
+	if partial == nil {
+		return ""
+	}
+	return warnStyle.Render("Unavailable: "+strings.Join(partial.Items(), ", ")) + "\n"
+}
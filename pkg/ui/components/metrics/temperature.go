@@ -7,10 +7,12 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
 )
 
 // TemperatureMetrics renders temperature metrics
 type TemperatureMetrics struct {
+	title        lipgloss.Style
 	label        lipgloss.Style
 	value        lipgloss.Style
 	muted        lipgloss.Style
@@ -19,26 +21,32 @@ type TemperatureMetrics struct {
 	critical     lipgloss.Style
 	width        int
 	targetHeight int
+
+	// Temperature history per sensor key
+	sparkLine *components.SparkLine
+	history   map[string][]float64
 }
 
 // NewTemperatureMetrics creates a new temperature metrics renderer
 func NewTemperatureMetrics() *TemperatureMetrics {
-	var colorForeground = lipgloss.Color("#f8f8f2")
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorOrange = lipgloss.Color("#ffb86c")
-	var colorRed = lipgloss.Color("#ff5555")
-
-	return &TemperatureMetrics{
-		label:        lipgloss.NewStyle().Foreground(colorCyan),
-		value:        lipgloss.NewStyle().Foreground(colorForeground),
-		muted:        lipgloss.NewStyle().Foreground(colorComment),
-		normal:       lipgloss.NewStyle().Foreground(colorGreen),
-		warning:      lipgloss.NewStyle().Foreground(colorOrange),
-		critical:     lipgloss.NewStyle().Foreground(colorRed).Bold(true),
+	t := &TemperatureMetrics{
 		targetHeight: 0,
+		sparkLine:    components.NewSparkLine(),
 	}
+	t.SetTheme(components.DarkTheme())
+	return t
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (t *TemperatureMetrics) SetTheme(theme *components.Theme) {
+	t.title = lipgloss.NewStyle().Foreground(theme.Purple).Bold(true)
+	t.label = lipgloss.NewStyle().Foreground(theme.Cyan)
+	t.value = lipgloss.NewStyle().Foreground(theme.Foreground)
+	t.muted = lipgloss.NewStyle().Foreground(theme.Comment)
+	t.normal = lipgloss.NewStyle().Foreground(theme.Green)
+	t.warning = lipgloss.NewStyle().Foreground(theme.Orange)
+	t.critical = lipgloss.NewStyle().Foreground(theme.Red).Bold(true)
+	t.sparkLine.SetTheme(theme)
 }
 
 // SetWidth sets the render width
@@ -51,10 +59,16 @@ func (t *TemperatureMetrics) SetHeight(h int) {
 	t.targetHeight = h
 }
 
+// SetHistory sets the temperature history of each sensor, keyed by sensor
+// key
+func (t *TemperatureMetrics) SetHistory(history map[string][]float64) {
+	t.history = history
+}
+
 // Render returns the rendered temperature metrics
 func (t *TemperatureMetrics) Render(systemData *data.SystemData) string {
-	if systemData == nil || systemData.Sensors == nil {
-		result := t.muted.Render("Loading temperature data...")
+	if state := panelState(systemData, "sensors"); state != data.StateReady {
+		result := renderState(state, systemData, "sensors", "temperature", t.muted, t.critical)
 		return t.padToHeight(result)
 	}
 
@@ -62,9 +76,13 @@ func (t *TemperatureMetrics) Render(systemData *data.SystemData) string {
 	var content strings.Builder
 
 	// Title
-	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#bd93f9")).Bold(true).Render("Temperatures"))
+	content.WriteString(t.title.Render("Temperatures"))
 	content.WriteString("\n\n")
 
+	// Raspberry Pi SoC telemetry and throttling state
+	piSection := t.renderPi(systemData.Pi)
+	content.WriteString(piSection)
+
 	// Display fan speeds first with visual gauge (always visible if available)
 	if len(sensors.Fans) > 0 {
 		content.WriteString(t.label.Render("Fan Speeds"))
@@ -82,8 +100,25 @@ func (t *TemperatureMetrics) Render(systemData *data.SystemData) string {
 		content.WriteString("\n")
 	}
 
+	// Power supply states from the BMC on IPMI servers
+	if len(sensors.PowerSupplies) > 0 {
+		content.WriteString(t.label.Render("Power Supplies"))
+		content.WriteString("\n")
+		for _, psu := range sensors.PowerSupplies {
+			style := t.normal
+			if psu.Failed {
+				style = t.critical
+			}
+			content.WriteString(fmt.Sprintf("  %s  %s\n", psu.Name, style.Render(psu.Status)))
+		}
+		content.WriteString("\n")
+	}
+
 	if len(sensors.Temperatures) == 0 {
-		result := t.muted.Render("No temperature sensors found")
+		result := piSection + t.muted.Render("No temperature sensors found")
+		if partial := renderPartial(systemData, "sensors", t.warning); partial != "" {
+			result += "\n" + partial
+		}
 		return t.padToHeight(result)
 	}
 
@@ -114,6 +149,8 @@ func (t *TemperatureMetrics) Render(systemData *data.SystemData) string {
 		}
 	}
 
+	content.WriteString(renderPartial(systemData, "sensors", t.warning))
+
 	return t.padToHeight(content.String())
 }
 
@@ -157,22 +194,76 @@ func (t *TemperatureMetrics) renderSummaryTemp(sensorType string, temps []TempEn
 	return sb.String()
 }
 
-// renderTempGauge renders a temperature with visual gauge
+// renderTempGauge renders a temperature with visual gauge, followed by a
+// sparkline of its recent history when there is room for one
 func (t *TemperatureMetrics) renderTempGauge(temp TempEntry) string {
 	tempStyle := t.getMetricStyle(temp.Temp, 70, 85)
 
 	// Temperature gauge: 0-100°C range
 	gauge := renderGauge(temp.Temp, 100, 20, t.normal, tempStyle)
 
+	var crit string
+	if temp.Critical != 0 {
+		crit = t.muted.Render(fmt.Sprintf(" (crit: %.0f°C)", temp.Critical))
+	}
+
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("  %s\n    %s%.1f°C",
-		temp.Key,
-		gauge,
-		temp.Temp,
-	))
+	sb.WriteString(fmt.Sprintf("  %s\n    %s", temp.Key, gauge))
+
+	// Indent, gauge and a padded value come before the sparkline
+	sparkWidth := t.width - 4 - 20 - 9 - lipgloss.Width(crit)
+	if history := t.history[temp.Key]; len(history) > 1 && sparkWidth >= 5 {
+		t.sparkLine.SetWidth(sparkWidth)
+		t.sparkLine.SetData(history)
+		sb.WriteString(fmt.Sprintf("%-9s", fmt.Sprintf("%.1f°C", temp.Temp)))
+		sb.WriteString(t.sparkLine.RenderWithColor(70, 85))
+	} else {
+		sb.WriteString(fmt.Sprintf("%.1f°C", temp.Temp))
+	}
 
-	if temp.Critical != 0 {
-		sb.WriteString(t.muted.Render(fmt.Sprintf(" (crit: %.0f°C)", temp.Critical)))
+	sb.WriteString(crit)
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// renderPi renders the Raspberry Pi SoC readings and the firmware's
+// under-voltage and throttling flags, current ones highlighted
+func (t *TemperatureMetrics) renderPi(pi *data.PiMetrics) string {
+	if pi == nil || pi.Model == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(t.label.Render(pi.Model))
+	sb.WriteString("\n")
+	if pi.SoCTemp > 0 {
+		sb.WriteString(t.renderTempGauge(TempEntry{Key: "SoC", Temp: pi.SoCTemp}))
+	}
+
+	var readings []string
+	if pi.CoreVolts > 0 {
+		readings = append(readings, fmt.Sprintf("Core %.2f V", pi.CoreVolts))
+	}
+	if pi.ARMClock > 0 {
+		readings = append(readings, fmt.Sprintf("ARM %d MHz", pi.ARMClock/1_000_000))
+	}
+	if len(readings) > 0 {
+		sb.WriteString("  " + t.value.Render(strings.Join(readings, " · ")) + "\n")
+	}
+
+	if pi.HasThrottled {
+		active := pi.ActiveReasons()
+		switch {
+		case pi.Active(data.PiUnderVoltage):
+			sb.WriteString("  " + t.critical.Render("⚠ "+strings.Join(active, ", ")) + "\n")
+		case len(active) > 0:
+			sb.WriteString("  " + t.warning.Render("⚠ "+strings.Join(active, ", ")) + "\n")
+		default:
+			sb.WriteString("  " + t.normal.Render("Not throttled") + "\n")
+		}
+		if past := pi.PastReasons(); len(past) > 0 {
+			sb.WriteString("  " + t.muted.Render("Since boot: "+strings.Join(past, ", ")) + "\n")
+		}
 	}
 	sb.WriteString("\n")
 	return sb.String()
@@ -214,6 +305,11 @@ func estimateMaxFanRPM(name string, currentRPM uint64) float64 {
 		return 2500
 	}
 
+	// Server fan banks read through IPMI run far faster than desktop fans
+	if strings.HasPrefix(name, "ipmi") {
+		return max(16000, float64(currentRPM)*1.2)
+	}
+
 	// Default for case fans
 	if currentRPM > 2000 {
 		return float64(currentRPM) * 1.2
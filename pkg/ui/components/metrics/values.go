@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ValueDisplay selects how used/total quantities are written: as a
+// percentage, as absolute sizes, or both
+type ValueDisplay struct {
+	Percent  bool
+	Absolute bool
+}
+
+// DefaultValueDisplay shows both sizes and percentages
+var DefaultValueDisplay = ValueDisplay{Percent: true, Absolute: true}
+
+// usageText formats used/total according to the display mode, with the
+// percentage in the given style: "1.2 GiB / 4.0 GiB
+// (30.0%)", "30.0%", or "1.2 GiB / 4.0 GiB". An empty total omits it.
+func (v ValueDisplay) usageText(used, total string, percent float64, style, reset lipgloss.Style) string {
+	size := used
+	if total != "" {
+		size = used + " / " + total
+	}
+	pct := fmt.Sprintf("%s%.1f%%%s", style, percent, reset)
+
+	switch {
+	case v.Percent && !v.Absolute:
+		return pct
+	case v.Absolute && !v.Percent:
+		return size
+	}
+	return fmt.Sprintf("%s (%s)", size, pct)
+}
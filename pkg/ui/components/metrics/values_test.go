@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestValueDisplayUsageText(t *testing.T) {
+	tests := []struct {
+		name    string
+		display ValueDisplay
+		total   string
+		want    string
+	}{
+		{"percent only", ValueDisplay{Percent: true}, "4.0 GiB", "30.0%"},
+		{"absolute only", ValueDisplay{Absolute: true}, "4.0 GiB", "1.2 GiB / 4.0 GiB"},
+		{"both", DefaultValueDisplay, "4.0 GiB", "1.2 GiB / 4.0 GiB (30.0%)"},
+		{"both without total", DefaultValueDisplay, "", "1.2 GiB (30.0%)"},
+		{"absolute without total", ValueDisplay{Absolute: true}, "", "1.2 GiB"},
+		{"neither falls back to both", ValueDisplay{}, "4.0 GiB", "1.2 GiB / 4.0 GiB (30.0%)"},
+	}
+	style := lipgloss.NewStyle()
+	for _, tt := range tests {
+		got := ansi.Strip(tt.display.usageText("1.2 GiB", tt.total, 30, style, style))
+		if got != tt.want {
+			t.Errorf("%s: usageText = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
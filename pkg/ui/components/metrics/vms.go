@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// VMMetrics renders libvirt virtual machines with their CPU, memory, disk
+// and network usage as a scrollable table
+type VMMetrics struct {
+	title    lipgloss.Style
+	label    lipgloss.Style
+	value    lipgloss.Style
+	muted    lipgloss.Style
+	normal   lipgloss.Style
+	warning  lipgloss.Style
+	critical lipgloss.Style
+	width    int
+	table    *components.Table
+}
+
+// NewVMMetrics creates a new virtual machine renderer
+func NewVMMetrics() *VMMetrics {
+	v := &VMMetrics{
+		table: components.NewTable([]components.Column{
+			{Title: "NAME", MinWidth: 16},
+			{Title: "STATE", Width: 13},
+			{Title: "VCPU", Width: 4, Align: components.AlignRight},
+			{Title: "CPU%", Width: 6, Align: components.AlignRight},
+			{Title: "MEMORY", Width: 21, Align: components.AlignRight},
+			{Title: "DISK R", Width: 11, Align: components.AlignRight},
+			{Title: "DISK W", Width: 11, Align: components.AlignRight},
+			{Title: "NET RX", Width: 11, Align: components.AlignRight},
+			{Title: "NET TX", Width: 11, Align: components.AlignRight},
+		}),
+	}
+	v.SetTheme(components.DarkTheme())
+	return v
+}
+
+// SetTheme rebuilds the renderer styles from the given theme
+func (v *VMMetrics) SetTheme(t *components.Theme) {
+	v.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	v.label = lipgloss.NewStyle().Foreground(t.Cyan)
+	v.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	v.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	v.normal = lipgloss.NewStyle().Foreground(t.Green)
+	v.warning = lipgloss.NewStyle().Foreground(t.Orange)
+	v.critical = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	v.table.SetTheme(t)
+}
+
+// SetWidth sets the render width
+func (v *VMMetrics) SetWidth(w int) {
+	v.width = w
+	v.table.SetWidth(w)
+}
+
+// SetHeight sets the render height; rows beyond it scroll
+func (v *VMMetrics) SetHeight(h int) {
+	// Title, state summary, table header and footer take 8 lines
+	rows := 0
+	if h > 0 {
+		rows = max(h-8, 1)
+	}
+	v.table.SetHeight(rows)
+}
+
+// ScrollUp scrolls the VM table up one row
+func (v *VMMetrics) ScrollUp() {
+	v.table.ScrollUp()
+}
+
+// ScrollDown scrolls the VM table down one row
+func (v *VMMetrics) ScrollDown() {
+	v.table.ScrollDown()
+}
+
+// Render returns the rendered virtual machines
+func (v *VMMetrics) Render(systemData *data.SystemData) string {
+	if state := panelState(systemData, "vms"); state != data.StateReady {
+		return renderState(state, systemData, "vms", "VM", v.muted, v.critical)
+	}
+
+	metrics := systemData.VMs
+	var b strings.Builder
+
+	// Title
+	b.WriteString(v.title.Render("Virtual Machines"))
+	if metrics.URI != "" {
+		b.WriteString(v.muted.Render(" (" + metrics.URI + ")"))
+	}
+	b.WriteString("\n\n")
+
+	if metrics.URI == "" {
+		b.WriteString(v.muted.Render("libvirt not found (virsh is not installed)"))
+		return b.String()
+	}
+
+	// Summary
+	var running, paused, stopped int
+	for _, vm := range metrics.VMs {
+		switch vm.State {
+		case "running":
+			running++
+		case "paused", "suspended", "blocked":
+			paused++
+		default:
+			stopped++
+		}
+	}
+	b.WriteString(v.label.Render("Running "))
+	b.WriteString(v.normal.Render(fmt.Sprintf("%d", running)))
+	b.WriteString(v.muted.Render(" · "))
+	b.WriteString(v.label.Render("Paused "))
+	b.WriteString(v.value.Render(fmt.Sprintf("%d", paused)))
+	b.WriteString(v.muted.Render(" · "))
+	b.WriteString(v.label.Render("Stopped "))
+	b.WriteString(v.value.Render(fmt.Sprintf("%d", stopped)))
+	b.WriteString("\n\n")
+
+	if len(metrics.VMs) == 0 {
+		b.WriteString(v.muted.Render("No virtual machines defined"))
+		return b.String()
+	}
+
+	rows := make([]components.Row, 0, len(metrics.VMs))
+	for _, vm := range metrics.VMs {
+		stateStyle := v.muted
+		switch vm.State {
+		case "running":
+			stateStyle = v.normal
+		case "paused", "suspended", "blocked", "shutting down":
+			stateStyle = v.warning
+		case "crashed":
+			stateStyle = v.critical
+		}
+
+		if vm.State != "running" {
+			rows = append(rows, components.Row{
+				{Text: vm.Name, Style: v.muted},
+				{Text: vm.State, Style: stateStyle},
+				{Text: fmt.Sprintf("%d", vm.VCPUs), Style: v.muted},
+				{Text: "-", Style: v.muted},
+				{Text: "-", Style: v.muted},
+				{Text: "-", Style: v.muted},
+				{Text: "-", Style: v.muted},
+				{Text: "-", Style: v.muted},
+				{Text: "-", Style: v.muted},
+			})
+			continue
+		}
+
+		// CPU% is of one host core, so a VM can use up to 100% per vCPU
+		cpuPercent := vm.CPU
+		if vm.VCPUs > 0 {
+			cpuPercent /= float64(vm.VCPUs)
+		}
+		memory := v.formatBytes(vm.MemoryUsed)
+		if vm.MemoryMax > 0 {
+			memory += " / " + v.formatBytes(vm.MemoryMax)
+		}
+		rows = append(rows, components.Row{
+			{Text: vm.Name, Style: v.value},
+			{Text: vm.State, Style: stateStyle},
+			{Text: fmt.Sprintf("%d", vm.VCPUs), Style: v.value},
+			{Text: fmt.Sprintf("%.1f", vm.CPU), Style: v.getMetricStyle(cpuPercent, 70, 90)},
+			{Text: memory, Style: v.value},
+			{Text: v.formatBytes(uint64(vm.DiskReadPerSec)) + "/s", Style: v.muted},
+			{Text: v.formatBytes(uint64(vm.DiskWritePerSec)) + "/s", Style: v.muted},
+			{Text: v.formatBytes(uint64(vm.NetRxPerSec)) + "/s", Style: v.muted},
+			{Text: v.formatBytes(uint64(vm.NetTxPerSec)) + "/s", Style: v.muted},
+		})
+	}
+	v.table.SetRows(rows)
+
+	b.WriteString(v.table.Render())
+	b.WriteString("\n\n")
+
+	first, last := v.table.VisibleRange()
+	b.WriteString(v.muted.Render(fmt.Sprintf("Showing %d-%d of %d VMs (↑/↓ to scroll)", first+1, last, len(metrics.VMs))))
+
+	return b.String()
+}
+
+func (v *VMMetrics) getMetricStyle(value float64, warning, critical float64) lipgloss.Style {
+	if value >= critical {
+		return v.critical
+	}
+	if value >= warning {
+		return v.warning
+	}
+	return v.normal
+}
+
+func (v *VMMetrics) formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -2,6 +2,9 @@ package components
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -22,74 +25,358 @@ type ProcessList struct {
 	mutedStyle    lipgloss.Style
 	width         int
 	height        int
-	processes      []ProcessInfo
+	processes     []ProcessInfo
+	shown         []ProcessInfo // processes matching the filter, in row order
+	table         *Table
+
+	// Coloring levels for the CPU% and MEM% columns
+	cpuWarning   float64
+	cpuCritical  float64
+	memWarning   float64
+	memCritical  float64
+	normalizeCPU bool // Show CPU% as a share of all cores rather than one
+	cores        int
+
+	sortBy  string // "cpu", "memory", "io" or "gpu"
+	showGPU bool   // GPU%/VRAM columns, when per-process GPU usage is known
+
+	filter    string // Only processes whose name, user or PID contain this
+	filtering bool   // The filter is being typed, so a cursor is shown
+}
+
+// ProcessSortModes lists the process table orderings in the order the sort
+// key cycles through them; "gpu" is skipped while the GPU columns are hidden
+var ProcessSortModes = []string{"cpu", "memory", "io", "gpu"}
+
+// processSortTitles names each ordering in the table title
+var processSortTitles = map[string]string{
+	"cpu":    "CPU",
+	"memory": "memory",
+	"io":     "disk I/O",
+	"gpu":    "GPU",
+}
+
+// processColumns are the process table columns, without the GPU ones
+var processColumns = []Column{
+	{Title: "PID", Width: 7, Align: AlignRight},
+	{Title: "USER", Width: 9},
+	{Title: "NAME", MinWidth: 12},
+	{Title: "CPU%", Width: 6, Align: AlignRight},
+	{Title: "MEM%", Width: 6, Align: AlignRight},
+	{Title: "READ/s", Width: 10, Align: AlignRight},
+	{Title: "WRITE/s", Width: 10, Align: AlignRight},
+}
+
+// gpuColumns are appended to processColumns while GPU usage is shown
+var gpuColumns = []Column{
+	{Title: "GPU%", Width: 6, Align: AlignRight},
+	{Title: "VRAM", Width: 10, Align: AlignRight},
 }
 
 // ProcessInfo holds information about a single process
 type ProcessInfo struct {
 	PID     int
 	Name    string
+	User    string
 	CPU     float64
 	Memory  float64
 	Command string
+
+	// Storage read and write rates in bytes per second
+	ReadPerSec  float64
+	WritePerSec float64
+
+	// GPU busy percent and VRAM in bytes, 0 for processes not on a GPU
+	GPU       float64
+	GPUMemory uint64
 }
 
 // NewProcessList creates a new process list component
 func NewProcessList() *ProcessList {
-	var colorPurple = lipgloss.Color("#bd93f9")
-	var colorCyan = lipgloss.Color("#8be9fd")
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorOrange = lipgloss.Color("#ffb86c")
-	var colorRed = lipgloss.Color("#ff5555")
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorForeground = lipgloss.Color("#f8f8f2")
-
-	return &ProcessList{
-		titleStyle:    lipgloss.NewStyle().Foreground(colorPurple).Bold(true),
-		headerStyle:   lipgloss.NewStyle().Foreground(colorCyan).Bold(true),
-		pidStyle:      lipgloss.NewStyle().Foreground(colorComment),
-		nameStyle:     lipgloss.NewStyle().Foreground(colorForeground),
-		cpuStyle:      lipgloss.NewStyle().Foreground(colorGreen),
-		memStyle:      lipgloss.NewStyle().Foreground(colorGreen),
-		normalStyle:   lipgloss.NewStyle().Foreground(colorGreen),
-		warningStyle:  lipgloss.NewStyle().Foreground(colorOrange),
-		criticalStyle: lipgloss.NewStyle().Foreground(colorRed).Bold(true),
-		mutedStyle:    lipgloss.NewStyle().Foreground(colorComment),
-		processes:      make([]ProcessInfo, 0, 10),
+	p := &ProcessList{
+		processes:   make([]ProcessInfo, 0, 10),
+		cpuWarning:  20,
+		cpuCritical: 50,
+		memWarning:  20,
+		memCritical: 50,
+		sortBy:      "cpu",
+		table:       NewTable(processColumns),
+	}
+	p.table.SetSelectable(true)
+	p.SetTheme(DarkTheme())
+	return p
+}
+
+// SetTheme rebuilds the process list styles from the given theme
+func (p *ProcessList) SetTheme(t *Theme) {
+	p.titleStyle = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	p.headerStyle = lipgloss.NewStyle().Foreground(t.Cyan).Bold(true)
+	p.pidStyle = lipgloss.NewStyle().Foreground(t.Comment)
+	p.nameStyle = lipgloss.NewStyle().Foreground(t.Foreground)
+	p.cpuStyle = lipgloss.NewStyle().Foreground(t.Green)
+	p.memStyle = lipgloss.NewStyle().Foreground(t.Green)
+	p.normalStyle = lipgloss.NewStyle().Foreground(t.Green)
+	p.warningStyle = lipgloss.NewStyle().Foreground(t.Orange)
+	p.criticalStyle = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	p.mutedStyle = lipgloss.NewStyle().Foreground(t.Comment)
+	p.table.SetTheme(t)
+	p.updateRows()
+}
+
+// SetCPUThresholds sets the CPU% levels at which rows turn warning and
+// critical colored
+func (p *ProcessList) SetCPUThresholds(warning, critical float64) {
+	p.cpuWarning = warning
+	p.cpuCritical = critical
+	p.updateRows()
+}
+
+// SetMemThresholds sets the MEM% levels at which rows turn warning and
+// critical colored
+func (p *ProcessList) SetMemThresholds(warning, critical float64) {
+	p.memWarning = warning
+	p.memCritical = critical
+	p.updateRows()
+}
+
+// SetNormalizeCPU divides process CPU% by the core count, so a process
+// using one full core on an 8-core machine shows 12.5 instead of 100
+func (p *ProcessList) SetNormalizeCPU(normalize bool) {
+	p.normalizeCPU = normalize
+	p.updateRows()
+}
+
+// SetSort sets the column the table is ordered by ("cpu", "memory", "io"
+// or "gpu"), highest first; other values are ignored
+func (p *ProcessList) SetSort(sortBy string) {
+	if _, ok := processSortTitles[sortBy]; !ok {
+		return
+	}
+	selected, ok := p.SelectedProcess()
+	p.sortBy = sortBy
+	p.sortProcesses()
+	p.updateRows()
+	if ok {
+		p.selectPID(selected.PID)
 	}
 }
 
+// Sort returns the column the table is ordered by
+func (p *ProcessList) Sort() string {
+	return p.sortBy
+}
+
+// NextSort switches to the next ordering in ProcessSortModes and returns it
+func (p *ProcessList) NextSort() string {
+	next := ProcessSortModes[0]
+	for i, mode := range ProcessSortModes {
+		if mode == p.sortBy {
+			next = ProcessSortModes[(i+1)%len(ProcessSortModes)]
+		}
+	}
+	if next == "gpu" && !p.showGPU {
+		next = ProcessSortModes[0]
+	}
+	p.SetSort(next)
+	return next
+}
+
+// SetShowGPU shows or hides the per-process GPU% and VRAM columns
+func (p *ProcessList) SetShowGPU(show bool) {
+	if show == p.showGPU {
+		return
+	}
+	p.showGPU = show
+	if show {
+		p.table.SetColumns(append(slices.Clone(processColumns), gpuColumns...))
+	} else {
+		p.table.SetColumns(processColumns)
+	}
+	p.updateRows()
+}
+
+// SetFilter narrows the table to processes whose name, user or PID contain
+// filter, ignoring case; editing shows a cursor after it while it's typed
+func (p *ProcessList) SetFilter(filter string, editing bool) {
+	p.filtering = editing
+	if filter == p.filter {
+		return
+	}
+	selected, ok := p.SelectedProcess()
+	p.filter = filter
+	p.updateRows()
+	if ok {
+		p.selectPID(selected.PID)
+	}
+}
+
+// matches reports whether a process passes the filter
+func (p *ProcessList) matches(proc ProcessInfo) bool {
+	if p.filter == "" {
+		return true
+	}
+	filter := strings.ToLower(p.filter)
+	return strings.Contains(strings.ToLower(proc.Name), filter) ||
+		strings.Contains(strings.ToLower(proc.User), filter) ||
+		strings.Contains(strconv.Itoa(proc.PID), filter)
+}
+
 // SetWidth sets the render width
 func (p *ProcessList) SetWidth(w int) {
 	p.width = w
+	p.table.SetWidth(w - 4)
 }
 
 // SetHeight sets the render height
 func (p *ProcessList) SetHeight(h int) {
 	p.height = h
+
+	// Title, table header, and footer take 6 lines
+	rows := 0
+	if h > 0 {
+		rows = h - 6
+		if rows < 1 {
+			rows = 1
+		}
+	}
+	p.table.SetHeight(rows)
+}
+
+// ScrollUp moves the selection up
+func (p *ProcessList) ScrollUp() {
+	p.table.ScrollUp()
 }
 
-// SetProcesses sets the process list
+// ScrollDown moves the selection down
+func (p *ProcessList) ScrollDown() {
+	p.table.ScrollDown()
+}
+
+// SelectedProcess returns the highlighted process, if any
+func (p *ProcessList) SelectedProcess() (ProcessInfo, bool) {
+	i := p.table.Selected()
+	if i < 0 || i >= len(p.shown) {
+		return ProcessInfo{}, false
+	}
+	return p.shown[i], true
+}
+
+// SetProcesses sets the process list. The selection follows the selected
+// process to its new row, so a refresh doesn't move it to another process.
 func (p *ProcessList) SetProcesses(procs []ProcessInfo) {
+	selected, ok := p.SelectedProcess()
 	p.processes = procs
+	p.sortProcesses()
+	p.updateRows()
+	if ok {
+		p.selectPID(selected.PID)
+	}
+}
+
+// selectPID moves the selection to the process with the given PID, leaving
+// it where it is when that process is gone
+func (p *ProcessList) selectPID(pid int) {
+	for i, proc := range p.shown {
+		if proc.PID == pid {
+			p.table.Select(i)
+			return
+		}
+	}
 }
 
 // AddProcess adds a process to the list
 func (p *ProcessList) AddProcess(proc ProcessInfo) {
 	p.processes = append(p.processes, proc)
+	p.updateRows()
 }
 
 // Clear clears the process list
 func (p *ProcessList) Clear() {
 	p.processes = make([]ProcessInfo, 0, 10)
+	p.updateRows()
+}
+
+// sortProcesses orders the processes by the sort column, highest first,
+// breaking ties by CPU and then memory
+func (p *ProcessList) sortProcesses() {
+	key := func(proc ProcessInfo) float64 {
+		switch p.sortBy {
+		case "memory":
+			return proc.Memory
+		case "io":
+			return proc.ReadPerSec + proc.WritePerSec
+		case "gpu":
+			return proc.GPU
+		}
+		return proc.CPU
+	}
+	sort.SliceStable(p.processes, func(i, j int) bool {
+		a, b := p.processes[i], p.processes[j]
+		if key(a) != key(b) {
+			return key(a) > key(b)
+		}
+		if p.sortBy == "gpu" && a.GPUMemory != b.GPUMemory {
+			return a.GPUMemory > b.GPUMemory
+		}
+		if a.CPU != b.CPU {
+			return a.CPU > b.CPU
+		}
+		return a.Memory > b.Memory
+	})
+}
+
+// updateRows rebuilds the table rows from the processes matching the filter
+func (p *ProcessList) updateRows() {
+	p.shown = p.shown[:0]
+	rows := make([]Row, 0, len(p.processes))
+	for _, proc := range p.processes {
+		if !p.matches(proc) {
+			continue
+		}
+		p.shown = append(p.shown, proc)
+		cpu := proc.CPU
+		if p.normalizeCPU && p.cores > 0 {
+			cpu /= float64(p.cores)
+		}
+		row := Row{
+			{Text: fmt.Sprintf("%d", proc.PID), Style: p.pidStyle},
+			{Text: proc.User, Style: p.mutedStyle},
+			{Text: proc.Name, Style: p.nameStyle},
+			{Text: fmt.Sprintf("%.1f", cpu), Style: p.getCPUStyle(cpu)},
+			{Text: fmt.Sprintf("%.1f", proc.Memory), Style: p.getMemStyle(proc.Memory)},
+			{Text: p.formatRate(proc.ReadPerSec), Style: p.getRateStyle(proc.ReadPerSec)},
+			{Text: p.formatRate(proc.WritePerSec), Style: p.getRateStyle(proc.WritePerSec)},
+		}
+		if p.showGPU {
+			row = append(row, p.gpuCells(proc)...)
+		}
+		rows = append(rows, row)
+	}
+	p.table.SetRows(rows)
 }
 
 // Render returns the rendered process list
 func (p *ProcessList) Render(systemData *data.SystemData) string {
 	var b strings.Builder
 
+	// Track the core count for normalized CPU%
+	if systemData != nil && systemData.CPU != nil && systemData.CPU.CoreCount != p.cores {
+		p.cores = systemData.CPU.CoreCount
+		if p.normalizeCPU {
+			p.updateRows()
+		}
+	}
+
 	// Title
 	b.WriteString(p.titleStyle.Render("Top Processes"))
+	b.WriteString(p.mutedStyle.Render(" by " + processSortTitles[p.sortBy]))
+	if p.filter != "" || p.filtering {
+		b.WriteString(p.mutedStyle.Render("  /"))
+		b.WriteString(p.nameStyle.Render(p.filter))
+		if p.filtering {
+			b.WriteString(p.nameStyle.Render("█"))
+		}
+	}
 	b.WriteString("\n\n")
 
 	if len(p.processes) == 0 {
@@ -99,58 +386,70 @@ func (p *ProcessList) Render(systemData *data.SystemData) string {
 		return b.String()
 	}
 
-	// Header
-	b.WriteString(fmt.Sprintf("%-7s %-20s %-8s %-8s\n",
-		p.headerStyle.Render("PID"),
-		p.headerStyle.Render("NAME"),
-		p.headerStyle.Render("CPU%"),
-		p.headerStyle.Render("MEM%"),
-	))
-	b.WriteString(p.mutedStyle.Render(strings.Repeat("-", p.width-4)))
-	b.WriteString("\n")
-
-	// Process rows
-	for _, proc := range p.processes {
-		cpuStyle := p.getCPUStyle(proc.CPU)
-		memStyle := p.getMemStyle(proc.Memory)
+	if len(p.shown) == 0 {
+		b.WriteString(p.mutedStyle.Render("No processes match"))
+		return b.String()
+	}
 
-		// Truncate name if too long
-		name := proc.Name
-		if len(name) > 20 {
-			name = name[:17] + "..."
-		}
+	b.WriteString(p.table.Render())
+	b.WriteString("\n\n")
 
-		b.WriteString(fmt.Sprintf("%-7d %-20s %-8s %-8s\n",
-			p.pidStyle.Render(fmt.Sprintf("%d", proc.PID)),
-			p.nameStyle.Render(name),
-			cpuStyle.Render(fmt.Sprintf("%.1f", proc.CPU)),
-			memStyle.Render(fmt.Sprintf("%.1f", proc.Memory)),
-		))
+	first, last := p.table.VisibleRange()
+	if p.filter != "" {
+		b.WriteString(p.mutedStyle.Render(fmt.Sprintf("Showing %d-%d of %d matching (%d processes)", first+1, last, len(p.shown), len(p.processes))))
+	} else {
+		b.WriteString(p.mutedStyle.Render(fmt.Sprintf("Showing %d-%d of %d processes", first+1, last, len(p.processes))))
 	}
 
-	b.WriteString("\n")
-	b.WriteString(p.mutedStyle.Render(fmt.Sprintf("Showing %d processes", len(p.processes))))
-
 	return b.String()
 }
 
 // getCPUStyle returns style based on CPU usage
 func (p *ProcessList) getCPUStyle(cpu float64) lipgloss.Style {
-	if cpu >= 50 {
+	if cpu >= p.cpuCritical {
 		return p.criticalStyle
 	}
-	if cpu >= 20 {
+	if cpu >= p.cpuWarning {
 		return p.warningStyle
 	}
 	return p.cpuStyle
 }
 
+// gpuCells renders a process's GPU% and VRAM, dashes for processes that
+// aren't on a GPU
+func (p *ProcessList) gpuCells(proc ProcessInfo) []Cell {
+	if proc.GPU <= 0 && proc.GPUMemory == 0 {
+		return []Cell{{Text: "-", Style: p.mutedStyle}, {Text: "-", Style: p.mutedStyle}}
+	}
+	return []Cell{
+		{Text: fmt.Sprintf("%.1f", proc.GPU), Style: p.getCPUStyle(proc.GPU)},
+		{Text: formatBytes(proc.GPUMemory), Style: p.nameStyle},
+	}
+}
+
+// formatRate formats a byte rate, showing a dash for processes without
+// disk I/O so the busy ones stand out
+func (p *ProcessList) formatRate(rate float64) string {
+	if rate < 1 {
+		return "-"
+	}
+	return formatBytes(uint64(rate))
+}
+
+// getRateStyle dims processes without disk I/O
+func (p *ProcessList) getRateStyle(rate float64) lipgloss.Style {
+	if rate < 1 {
+		return p.mutedStyle
+	}
+	return p.nameStyle
+}
+
 // getMemStyle returns style based on memory usage
 func (p *ProcessList) getMemStyle(mem float64) lipgloss.Style {
-	if mem >= 50 {
+	if mem >= p.memCritical {
 		return p.criticalStyle
 	}
-	if mem >= 20 {
+	if mem >= p.memWarning {
 		return p.warningStyle
 	}
 	return p.memStyle
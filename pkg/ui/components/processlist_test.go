@@ -0,0 +1,71 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// styleLevel names the threshold level a process list style stands for
+func styleLevel(p *ProcessList, s lipgloss.Style) string {
+	switch s.GetForeground() {
+	case p.criticalStyle.GetForeground():
+		return "critical"
+	case p.warningStyle.GetForeground():
+		return "warning"
+	}
+	return "normal"
+}
+
+func TestProcessStyleThresholds(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{19.9, "normal"},
+		{20, "warning"},
+		{20.1, "warning"},
+		{49.9, "warning"},
+		{50, "critical"},
+		{50.1, "critical"},
+	}
+	p := NewProcessList()
+	p.SetCPUThresholds(20, 50)
+	p.SetMemThresholds(20, 50)
+	for _, tt := range tests {
+		if got := styleLevel(p, p.getCPUStyle(tt.value)); got != tt.want {
+			t.Errorf("getCPUStyle(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+		if got := styleLevel(p, p.getMemStyle(tt.value)); got != tt.want {
+			t.Errorf("getMemStyle(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestProcessCPUStyleNormalized(t *testing.T) {
+	tests := []struct {
+		cpu       float64 // Raw, of one core
+		normalize bool
+		want      string
+	}{
+		{159.9, true, "normal"},
+		{160, true, "warning"},
+		{399.9, true, "warning"},
+		{400, true, "critical"},
+		{19.9, false, "normal"},
+		{20, false, "warning"},
+		{160, false, "critical"},
+	}
+	for _, tt := range tests {
+		p := NewProcessList()
+		p.SetCPUThresholds(20, 50)
+		p.SetNormalizeCPU(tt.normalize)
+		p.SetProcesses([]ProcessInfo{{PID: 1, Name: "proc", CPU: tt.cpu}})
+		p.Render(&data.SystemData{CPU: &data.CPUMetrics{CoreCount: 8}})
+
+		if got := styleLevel(p, p.table.rows[0][3].Style); got != tt.want {
+			t.Errorf("CPU %v (normalize %v) = %s, want %s", tt.cpu, tt.normalize, got, tt.want)
+		}
+	}
+}
@@ -8,24 +8,33 @@ import (
 
 // ProgressBar renders a progress bar
 type ProgressBar struct {
-	width      int
-	fillChar   string
-	emptyChar  string
-	fullStyle  lipgloss.Style
-	emptyStyle lipgloss.Style
+	width         int
+	fillChar      string
+	emptyChar     string
+	fullStyle     lipgloss.Style
+	emptyStyle    lipgloss.Style
+	normalStyle   lipgloss.Style
+	warningStyle  lipgloss.Style
+	criticalStyle lipgloss.Style
 }
 
 // NewProgressBar creates a new progress bar component
 func NewProgressBar() *ProgressBar {
-	var colorGreen = lipgloss.Color("#50fa7b")
-	var colorComment = lipgloss.Color("#44475a")
-
-	return &ProgressBar{
-		fillChar:   "█",
-		emptyChar:  "░",
-		fullStyle:  lipgloss.NewStyle().Foreground(colorGreen),
-		emptyStyle: lipgloss.NewStyle().Foreground(colorComment),
+	p := &ProgressBar{
+		fillChar:  "█",
+		emptyChar: "░",
 	}
+	p.SetTheme(DarkTheme())
+	return p
+}
+
+// SetTheme rebuilds the bar styles from the given theme
+func (p *ProgressBar) SetTheme(t *Theme) {
+	p.fullStyle = lipgloss.NewStyle().Foreground(t.Green)
+	p.emptyStyle = lipgloss.NewStyle().Foreground(t.Border)
+	p.normalStyle = lipgloss.NewStyle().Foreground(t.Green)
+	p.warningStyle = lipgloss.NewStyle().Foreground(t.Orange)
+	p.criticalStyle = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
 }
 
 // SetWidth sets the total width of the progress bar
@@ -88,12 +97,45 @@ func (p *ProgressBar) RenderWithLabel(percent float64, label string) string {
 func (p *ProgressBar) RenderDynamic(percent float64, warning, critical float64) string {
 	// Update color based on thresholds
 	if percent >= critical {
-		p.fullStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff5555")).Bold(true)
+		p.fullStyle = p.criticalStyle
 	} else if percent >= warning {
-		p.fullStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffb86c"))
+		p.fullStyle = p.warningStyle
 	} else {
-		p.fullStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#50fa7b"))
+		p.fullStyle = p.normalStyle
 	}
 
 	return p.Render(percent)
 }
+
+// BarSegment is one part of a stacked progress bar
+type BarSegment struct {
+	Percent float64
+	Style   lipgloss.Style
+}
+
+// RenderStacked returns a bar split into consecutive colored segments.
+// Segment boundaries are rounded from the running total so the filled
+// width always matches the sum of the segments.
+func (p *ProgressBar) RenderStacked(segments []BarSegment) string {
+	if p.width <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	cumulative := 0.0
+	filled := 0
+	for _, seg := range segments {
+		cumulative += seg.Percent
+		if cumulative > 100 {
+			cumulative = 100
+		}
+		end := int(float64(p.width)*cumulative/100.0 + 0.5)
+		if end > filled {
+			b.WriteString(seg.Style.Render(strings.Repeat(p.fillChar, end-filled)))
+			filled = end
+		}
+	}
+
+	b.WriteString(p.emptyStyle.Render(strings.Repeat(p.emptyChar, p.width-filled)))
+	return b.String()
+}
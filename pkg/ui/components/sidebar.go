@@ -1,15 +1,26 @@
 package components
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Tab represents a single tab in the sidebar
+// Tab represents a single tab in the sidebar. Tabs numbered above 9 have
+// no number key and are only reached with Tab/Shift+Tab.
 type Tab struct {
 	Name   string
 	Number int
 }
 
+// label returns the sidebar text of a tab, showing its number key if any
+func (t Tab) label() string {
+	if t.Number > 9 {
+		return "  " + t.Name
+	}
+	return fmt.Sprintf("%d %s", t.Number, t.Name)
+}
+
 // Sidebar displays the navigation tabs
 type Sidebar struct {
 	activeTabStyle lipgloss.Style
@@ -22,17 +33,7 @@ type Sidebar struct {
 
 // NewSidebar creates a new sidebar component
 func NewSidebar() *Sidebar {
-	var colorComment = lipgloss.Color("#6272a4")
-	var colorPink = lipgloss.Color("#ff79c6")
-
-	return &Sidebar{
-		activeTabStyle: lipgloss.NewStyle().
-			Foreground(colorPink).
-			Bold(true).
-			Padding(0, 1),
-		inactiveTabStyle: lipgloss.NewStyle().
-			Foreground(colorComment).
-			Padding(0, 1),
+	s := &Sidebar{
 		tabs: []Tab{
 			{Name: "CPU", Number: 1},
 			{Name: "MEM", Number: 2},
@@ -42,6 +43,32 @@ func NewSidebar() *Sidebar {
 		},
 		activeTab: 0,
 	}
+	s.SetTheme(DarkTheme())
+	return s
+}
+
+// SetTheme rebuilds the sidebar styles from the given theme
+func (s *Sidebar) SetTheme(t *Theme) {
+	s.activeTabStyle = lipgloss.NewStyle().
+		Foreground(t.Pink).
+		Bold(true).
+		Padding(0, 1)
+	s.inactiveTabStyle = lipgloss.NewStyle().
+		Foreground(t.Comment).
+		Padding(0, 1)
+}
+
+// SetTabs replaces the tabs shown in the sidebar
+func (s *Sidebar) SetTabs(tabs []Tab) {
+	s.tabs = tabs
+	if s.activeTab >= len(tabs) {
+		s.activeTab = 0
+	}
+}
+
+// Tabs returns the tabs shown in the sidebar
+func (s *Sidebar) Tabs() []Tab {
+	return s.tabs
 }
 
 // SetWidth sets the sidebar width
@@ -66,16 +93,57 @@ func (s *Sidebar) GetActiveTab() int {
 	return s.activeTab
 }
 
+// RenderHorizontal returns the tabs as a single-line strip
+func (s *Sidebar) RenderHorizontal() string {
+	return lipgloss.JoinHorizontal(lipgloss.Top, s.renderTabs(false)...)
+}
+
 // Render returns the rendered sidebar
 func (s *Sidebar) Render() string {
+	return lipgloss.JoinVertical(lipgloss.Left, s.renderTabs(true)...)
+}
+
+// TabAt returns the index of the tab rendered at line row of the sidebar,
+// or -1 if there is none there
+func (s *Sidebar) TabAt(row int) int {
+	top := 0
+	for i, tab := range s.renderTabs(true) {
+		height := lipgloss.Height(tab)
+		if row >= top && row < top+height {
+			return i
+		}
+		top += height
+	}
+	return -1
+}
+
+// HorizontalTabAt returns the index of the tab rendered at column x of the
+// horizontal strip, or -1 if there is none there
+func (s *Sidebar) HorizontalTabAt(x int) int {
+	left := 0
+	for i, tab := range s.renderTabs(false) {
+		width := lipgloss.Width(tab)
+		if x >= left && x < left+width {
+			return i
+		}
+		left += width
+	}
+	return -1
+}
+
+// renderTabs renders each tab label, padded to the sidebar width when
+// stacked vertically
+func (s *Sidebar) renderTabs(vertical bool) []string {
 	var tabs []string
 	for i, tab := range s.tabs {
+		style := s.inactiveTabStyle
 		if i == s.activeTab {
-			tabs = append(tabs, s.activeTabStyle.Render(tab.Name))
-		} else {
-			tabs = append(tabs, s.inactiveTabStyle.Render(tab.Name))
+			style = s.activeTabStyle
+		}
+		if vertical && s.width > 0 {
+			style = style.Width(s.width)
 		}
+		tabs = append(tabs, style.Render(tab.label()))
 	}
-
-	return lipgloss.JoinVertical(lipgloss.Left, tabs...)
+	return tabs
 }
@@ -4,13 +4,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/ctcac00/metrics-tui/internal/data"
 )
 
+// SnapshotSchemaVersion is bumped whenever the JSON layout of snapshots or
+// export bundles changes incompatibly
+const SnapshotSchemaVersion = 1
+
 // Snapshot represents a system state snapshot
 type Snapshot struct {
+	Version     int                `json:"version"`
 	Timestamp   time.Time          `json:"timestamp"`
 	CPU         *data.CPUMetrics  `json:"cpu"`
 	Memory      *data.MemoryMetrics `json:"memory"`
@@ -24,6 +30,8 @@ type Snapshot struct {
 type SnapshotManager struct {
 	outputDir string
 	format    string // json, text
+	location  *time.Location
+	anonymize bool
 }
 
 // NewSnapshotManager creates a new snapshot manager
@@ -31,22 +39,45 @@ func NewSnapshotManager(outputDir string, format string) *SnapshotManager {
 	return &SnapshotManager{
 		outputDir: outputDir,
 		format:    format,
+		location:  time.Local,
 	}
 }
 
 // NewSnapshotManagerWithDefaults creates a snapshot manager with defaults
 func NewSnapshotManagerWithDefaults() *SnapshotManager {
-	homeDir, _ := os.UserHomeDir()
 	return &SnapshotManager{
-		outputDir: homeDir + "/snapshots",
+		outputDir: DefaultSnapshotDir(),
 		format:    "json",
+		location:  time.Local,
+	}
+}
+
+// DefaultSnapshotDir returns ~/snapshots, or a directory under the system
+// temp dir when HOME can't be resolved (containers, systemd units), rather
+// than a bare /snapshots at the filesystem root
+func DefaultSnapshotDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil || homeDir == "" {
+		return filepath.Join(os.TempDir(), "metrics-tui", "snapshots")
 	}
+	return filepath.Join(homeDir, "snapshots")
+}
+
+// SetLocation sets the timezone for snapshot timestamps and filenames
+func (s *SnapshotManager) SetLocation(loc *time.Location) {
+	s.location = loc
+}
+
+// SetAnonymize redacts hostnames, addresses and serials from snapshots
+func (s *SnapshotManager) SetAnonymize(anonymize bool) {
+	s.anonymize = anonymize
 }
 
 // TakeSnapshot captures the current system state
 func (s *SnapshotManager) TakeSnapshot(systemData *data.SystemData) (*Snapshot, error) {
 	snapshot := &Snapshot{
-		Timestamp: time.Now(),
+		Version:   SnapshotSchemaVersion,
+		Timestamp: time.Now().In(s.location),
 		CPU:       systemData.CPU,
 		Memory:    systemData.Memory,
 		Disk:      systemData.Disk,
@@ -55,6 +86,10 @@ func (s *SnapshotManager) TakeSnapshot(systemData *data.SystemData) (*Snapshot,
 		Host:      systemData.Host,
 	}
 
+	if s.anonymize {
+		anonymizeSnapshot(snapshot)
+	}
+
 	return snapshot, nil
 }
 
@@ -113,7 +148,7 @@ func (s *SnapshotManager) saveText(snapshot *Snapshot, filepath string) error {
 
 	content += fmt.Sprintf("Monitor TUI Snapshot\n")
 	content += fmt.Sprintf("==================\n\n")
-	content += fmt.Sprintf("Timestamp: %s\n\n", snapshot.Timestamp.Format("2006-01-02 15:04:05"))
+	content += fmt.Sprintf("Timestamp: %s\n\n", snapshot.Timestamp.Format("2006-01-02 15:04:05 MST"))
 
 	if snapshot.Host != nil {
 		content += fmt.Sprintf("System: %s\n", snapshot.Host.Info.OS)
@@ -125,9 +160,16 @@ func (s *SnapshotManager) saveText(snapshot *Snapshot, filepath string) error {
 		content += "CPU Metrics\n"
 		content += "------------\n"
 		content += fmt.Sprintf("Total Usage: %.1f%%\n", snapshot.CPU.Total)
-		content += fmt.Sprintf("Cores: %d\n\n", snapshot.CPU.CoreCount)
+		content += fmt.Sprintf("Cores: %d\n\n", snapshot.CPU.DisplayCoreCount())
 		for i, usage := range snapshot.CPU.Usage {
-			content += fmt.Sprintf("  Core %d: %.1f%%\n", i, usage)
+			coreID := i
+			if i < len(snapshot.CPU.CoreIDs) {
+				coreID = snapshot.CPU.CoreIDs[i]
+			}
+			content += fmt.Sprintf("  Core %d: %.1f%%\n", coreID, usage)
+		}
+		for _, id := range snapshot.CPU.Offline {
+			content += fmt.Sprintf("  Core %d: offline\n", id)
 		}
 	}
 
@@ -180,7 +222,7 @@ func (s *SnapshotManager) ExportCSV(history map[string][]float64, filepath strin
 	}
 
 	for i := 0; i < maxLen; i++ {
-		content += time.Now().Format(time.RFC3339)
+		content += time.Now().In(s.location).Format(time.RFC3339)
 		if cpuData, ok := history["cpu"]; ok && i < len(cpuData) {
 			for _, val := range cpuData {
 				content += fmt.Sprintf(",%.2f", val)
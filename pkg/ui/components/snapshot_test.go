@@ -0,0 +1,24 @@
+//go:build unix
+
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultSnapshotDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if got, want := DefaultSnapshotDir(), filepath.Join(home, "snapshots"); got != want {
+		t.Errorf("with HOME set: DefaultSnapshotDir() = %q, want %q", got, want)
+	}
+
+	t.Setenv("HOME", "")
+	got := DefaultSnapshotDir()
+	if got == "/snapshots" || !strings.HasPrefix(got, os.TempDir()+string(filepath.Separator)) {
+		t.Errorf("without HOME: DefaultSnapshotDir() = %q, want a path under %q", got, os.TempDir())
+	}
+}
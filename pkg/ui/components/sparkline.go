@@ -1,17 +1,29 @@
 package components
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
 // SparkLine renders a sparkline chart from historical data
 type SparkLine struct {
-	width  int
-	height int
-	data   []float64
-	style  lipgloss.Style
+	width         int
+	height        int
+	data          []float64
+	times         []time.Time // When each data point was sampled, for the time axis
+	style         lipgloss.Style
+	normalStyle   lipgloss.Style
+	warningStyle  lipgloss.Style
+	criticalStyle lipgloss.Style
+	labelStyle    lipgloss.Style
+
+	// Multi-row options: a fixed 0-100 scale instead of the data's own
+	// min and max, and min/max labels to the left of the chart
+	percentScale bool
+	axisLabels   bool
 }
 
 // SparklineChars defines the characters used for sparkline rendering
@@ -19,13 +31,21 @@ var SparklineChars = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '
 
 // NewSparkLine creates a new sparkline component
 func NewSparkLine() *SparkLine {
-	var colorCyan = lipgloss.Color("#8be9fd")
-
-	return &SparkLine{
+	s := &SparkLine{
 		width:  40,
 		height: 1,
-		style:  lipgloss.NewStyle().Foreground(colorCyan),
 	}
+	s.SetTheme(DarkTheme())
+	return s
+}
+
+// SetTheme rebuilds the sparkline styles from the given theme
+func (s *SparkLine) SetTheme(t *Theme) {
+	s.style = lipgloss.NewStyle().Foreground(t.Cyan)
+	s.normalStyle = lipgloss.NewStyle().Foreground(t.Green)
+	s.warningStyle = lipgloss.NewStyle().Foreground(t.Orange)
+	s.criticalStyle = lipgloss.NewStyle().Foreground(t.Red).Bold(true)
+	s.labelStyle = lipgloss.NewStyle().Foreground(t.Comment)
 }
 
 // SetWidth sets the width (number of data points to display)
@@ -38,6 +58,19 @@ func (s *SparkLine) SetHeight(h int) {
 	s.height = h
 }
 
+// SetPercentScale scales multi-row charts from 0 to 100 rather than between
+// the lowest and highest values shown, so percentages of different charts
+// compare at a glance
+func (s *SparkLine) SetPercentScale(enabled bool) {
+	s.percentScale = enabled
+}
+
+// SetAxisLabels shows the scale's maximum and minimum to the left of the top
+// and bottom rows of multi-row charts, widening them by the label width
+func (s *SparkLine) SetAxisLabels(enabled bool) {
+	s.axisLabels = enabled
+}
+
 // SetData sets the data points to display
 func (s *SparkLine) SetData(data []float64) {
 	s.data = data
@@ -46,6 +79,49 @@ func (s *SparkLine) SetData(data []float64) {
 	}
 }
 
+// SetTimes sets when each data point was sampled, oldest first, lining up
+// with the end of the data
+func (s *SparkLine) SetTimes(times []time.Time) {
+	s.times = times
+}
+
+// RenderTimeAxis returns a line to show under the sparkline, labelling the
+// oldest point shown with how long ago it was sampled and the newest with
+// "now", e.g. "-2m          now". It is blank until there are two samples.
+func (s *SparkLine) RenderTimeAxis() string {
+	points := min(len(s.data), len(s.times), s.width)
+	if points < 2 {
+		return strings.Repeat(" ", s.width)
+	}
+	span := s.times[len(s.times)-1].Sub(s.times[len(s.times)-points])
+	start := "-" + formatSpan(span)
+
+	// The left label sits under the oldest point, which is right-aligned
+	// like the data; drop it when it would run into "now"
+	offset := s.width - points
+	gap := points - len(start) - len("now")
+	if gap < 1 {
+		return s.labelStyle.Render(fmt.Sprintf("%*s", s.width, "now"))
+	}
+	return s.labelStyle.Render(strings.Repeat(" ", offset) + start + strings.Repeat(" ", gap) + "now")
+}
+
+// formatSpan formats a time span to its largest units, e.g. "45s", "2m" or
+// "1h30m"
+func formatSpan(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Round(time.Second).Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Round(time.Minute).Minutes()))
+	}
+	d = d.Round(time.Minute)
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
 // SetStyle sets the rendering style
 func (s *SparkLine) SetStyle(style lipgloss.Style) {
 	s.style = style
@@ -106,31 +182,38 @@ func (s *SparkLine) Render() string {
 	return s.style.Render(result.String())
 }
 
-// RenderMultiLine renders a multi-row sparkline
+// RenderMultiLine renders a multi-row bar chart. Each row covers an equal
+// slice of the scale and each column fills up from the bottom in eighths,
+// so a value partway through a row shows as a partial block.
 func (s *SparkLine) RenderMultiLine() string {
 	if s.height <= 1 {
 		return s.Render()
 	}
 
-	if len(s.data) == 0 {
-		return strings.Repeat("\n"+strings.Repeat(" ", s.width), s.height)
-	}
-
 	// Get the last width elements
 	data := s.data
 	if len(data) > s.width {
 		data = data[len(data)-s.width:]
 	}
 
-	// Find min and max for normalization
 	min, max := s.getMinMax(data)
+	if s.percentScale {
+		min, max = 0, 100
+	}
 	rangeVal := max - min
 	if rangeVal == 0 {
 		rangeVal = 1
 	}
 
+	// Labels are right-aligned to the wider of the two
+	top, bottom := s.axisLabel(max), s.axisLabel(min)
+	labelWidth := lipgloss.Width(top)
+	if w := lipgloss.Width(bottom); w > labelWidth {
+		labelWidth = w
+	}
+
 	// Build each line from top to bottom
-	var lines []string
+	lines := make([]string, 0, s.height)
 	padding := s.width - len(data)
 	for row := s.height - 1; row >= 0; row-- {
 		var line strings.Builder
@@ -141,24 +224,50 @@ func (s *SparkLine) RenderMultiLine() string {
 		}
 
 		for _, value := range data {
-			// Normalize to 0-1
+			// Eighths of this row the bar fills, 0-8
 			normalized := (value - min) / rangeVal
-
-			// Calculate which row this value should appear on
-			valueRow := int(normalized * float64(s.height-1))
-
-			if valueRow >= row {
-				line.WriteRune(SparklineChars[len(SparklineChars)-1])
-			} else {
+			eighths := int((normalized*float64(s.height) - float64(row)) * 8)
+			switch {
+			case eighths <= 0 && row == 0:
+				// A baseline, as in the single-row sparkline, so the lowest
+				// values don't look like missing data
+				line.WriteRune(SparklineChars[0])
+			case eighths <= 0:
 				line.WriteRune(' ')
+			case eighths >= len(SparklineChars):
+				line.WriteRune(SparklineChars[len(SparklineChars)-1])
+			default:
+				line.WriteRune(SparklineChars[eighths-1])
+			}
+		}
+
+		chart := s.style.Render(line.String())
+		if s.axisLabels {
+			label := ""
+			if row == s.height-1 {
+				label = top
+			} else if row == 0 {
+				label = bottom
 			}
+			chart = s.labelStyle.Render(fmt.Sprintf("%*s ", labelWidth, label)) + chart
 		}
-		lines = append(lines, s.style.Render(line.String()))
+		lines = append(lines, chart)
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// axisLabel formats a scale bound for the y-axis
+func (s *SparkLine) axisLabel(value float64) string {
+	if s.percentScale {
+		return fmt.Sprintf("%.0f%%", value)
+	}
+	if value != float64(int64(value)) && value < 10 && value > -10 {
+		return fmt.Sprintf("%.1f", value)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
 // RenderWithColor returns sparkline with color based on latest value
 func (s *SparkLine) RenderWithColor(warning, critical float64) string {
 	if len(s.data) == 0 {
@@ -168,11 +277,11 @@ func (s *SparkLine) RenderWithColor(warning, critical float64) string {
 	// Update color based on latest value
 	latest := s.data[len(s.data)-1]
 	if latest >= critical {
-		s.style = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff5555")).Bold(true)
+		s.style = s.criticalStyle
 	} else if latest >= warning {
-		s.style = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffb86c"))
+		s.style = s.warningStyle
 	} else {
-		s.style = lipgloss.NewStyle().Foreground(lipgloss.Color("#50fa7b"))
+		s.style = s.normalStyle
 	}
 
 	return s.Render()
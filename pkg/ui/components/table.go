@@ -0,0 +1,253 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Alignment controls how text is placed within a table column
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+)
+
+// Column describes a single table column. A column with zero width takes
+// whatever width the fixed columns leave over.
+type Column struct {
+	Title    string
+	Width    int
+	MinWidth int // Lower bound for a flexible column
+	Align    Alignment
+}
+
+// Cell is a single table cell; a zero Style renders the text unstyled
+type Cell struct {
+	Text  string
+	Style lipgloss.Style
+}
+
+// Row is a single table row
+type Row []Cell
+
+// Table renders width-aware columns with a header and a scrollable body
+type Table struct {
+	headerStyle   lipgloss.Style
+	mutedStyle    lipgloss.Style
+	selectedStyle lipgloss.Style
+	columns       []Column
+	rows          []Row
+	width         int
+	height        int // Visible body rows, 0 for all
+	offset        int
+	selected      int
+	selectable    bool
+}
+
+// NewTable creates a new table with the given columns
+func NewTable(columns []Column) *Table {
+	t := &Table{
+		columns: columns,
+	}
+	t.SetTheme(DarkTheme())
+	return t
+}
+
+// SetTheme rebuilds the table styles from the given theme
+func (t *Table) SetTheme(theme *Theme) {
+	t.headerStyle = lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
+	t.mutedStyle = lipgloss.NewStyle().Foreground(theme.Comment)
+	t.selectedStyle = lipgloss.NewStyle().Background(theme.Border)
+}
+
+// SetColumns replaces the table columns
+func (t *Table) SetColumns(columns []Column) {
+	t.columns = columns
+}
+
+// SetRows replaces the table rows, keeping the scroll position in range
+func (t *Table) SetRows(rows []Row) {
+	t.rows = rows
+	t.clamp()
+}
+
+// SetWidth sets the render width
+func (t *Table) SetWidth(w int) {
+	t.width = w
+}
+
+// SetHeight sets how many body rows are visible (0 shows all rows)
+func (t *Table) SetHeight(h int) {
+	t.height = h
+	t.clamp()
+}
+
+// SetSelectable enables a highlighted selection that moves when scrolling
+func (t *Table) SetSelectable(selectable bool) {
+	t.selectable = selectable
+}
+
+// Selected returns the index of the selected row, or -1 without selection
+func (t *Table) Selected() int {
+	if !t.selectable || len(t.rows) == 0 {
+		return -1
+	}
+	return t.selected
+}
+
+// Select moves the selection to row i, scrolling it into view
+func (t *Table) Select(i int) {
+	t.selected = i
+	t.clamp()
+}
+
+// ScrollUp moves the selection (or the view, without selection) up one row
+func (t *Table) ScrollUp() {
+	if t.selectable {
+		t.selected--
+	} else {
+		t.offset--
+	}
+	t.clamp()
+}
+
+// ScrollDown moves the selection (or the view, without selection) down one row
+func (t *Table) ScrollDown() {
+	if t.selectable {
+		t.selected++
+	} else {
+		t.offset++
+	}
+	t.clamp()
+}
+
+// VisibleRange returns the half-open range of rows currently shown
+func (t *Table) VisibleRange() (int, int) {
+	end := len(t.rows)
+	if t.height > 0 && t.offset+t.height < end {
+		end = t.offset + t.height
+	}
+	return t.offset, end
+}
+
+// clamp keeps the selection and scroll offset within the rows
+func (t *Table) clamp() {
+	if t.selected >= len(t.rows) {
+		t.selected = len(t.rows) - 1
+	}
+	if t.selected < 0 {
+		t.selected = 0
+	}
+
+	visible := t.height
+	if visible <= 0 || visible > len(t.rows) {
+		visible = len(t.rows)
+	}
+
+	// Keep the selection on screen
+	if t.selectable {
+		if t.selected < t.offset {
+			t.offset = t.selected
+		}
+		if t.selected >= t.offset+visible {
+			t.offset = t.selected - visible + 1
+		}
+	}
+
+	if t.offset > len(t.rows)-visible {
+		t.offset = len(t.rows) - visible
+	}
+	if t.offset < 0 {
+		t.offset = 0
+	}
+}
+
+// columnWidths resolves flexible columns against the table width
+func (t *Table) columnWidths() []int {
+	widths := make([]int, len(t.columns))
+	fixed := len(t.columns) - 1 // One space between columns
+	flexible := 0
+	for i, col := range t.columns {
+		widths[i] = col.Width
+		fixed += col.Width
+		if col.Width == 0 {
+			flexible++
+		}
+	}
+
+	if flexible > 0 {
+		share := (t.width - fixed) / flexible
+		for i, col := range t.columns {
+			if col.Width == 0 {
+				widths[i] = share
+				if widths[i] < col.MinWidth {
+					widths[i] = col.MinWidth
+				}
+			}
+		}
+	}
+
+	return widths
+}
+
+// Render returns the rendered table
+func (t *Table) Render() string {
+	widths := t.columnWidths()
+
+	var b strings.Builder
+
+	// Header
+	headers := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		headers[i] = t.headerStyle.Render(fitCell(col.Title, widths[i], col.Align))
+	}
+	b.WriteString(strings.Join(headers, " "))
+	b.WriteString("\n")
+
+	separator := t.width
+	if separator <= 0 {
+		separator = lipgloss.Width(strings.Join(headers, " "))
+	}
+	b.WriteString(t.mutedStyle.Render(strings.Repeat("─", separator)))
+
+	// Body
+	start, end := t.VisibleRange()
+	for i := start; i < end; i++ {
+		gap := " "
+		if t.selectable && i == t.selected {
+			gap = t.selectedStyle.Render(" ")
+		}
+
+		cells := make([]string, len(t.columns))
+		for j, col := range t.columns {
+			var cell Cell
+			if j < len(t.rows[i]) {
+				cell = t.rows[i][j]
+			}
+			style := cell.Style
+			if t.selectable && i == t.selected {
+				style = style.Inherit(t.selectedStyle)
+			}
+			cells[j] = style.Render(fitCell(cell.Text, widths[j], col.Align))
+		}
+		b.WriteString("\n")
+		b.WriteString(strings.Join(cells, gap))
+	}
+
+	return b.String()
+}
+
+// fitCell truncates or pads text to exactly width cells
+func fitCell(text string, width int, align Alignment) string {
+	text = truncateString(text, width)
+	pad := width - lipgloss.Width(text)
+	if pad <= 0 {
+		return text
+	}
+	if align == AlignRight {
+		return strings.Repeat(" ", pad) + text
+	}
+	return text + strings.Repeat(" ", pad)
+}
@@ -0,0 +1,118 @@
+package components
+
+import "github.com/charmbracelet/lipgloss"
+
+// Theme holds the color palette shared by all components
+type Theme struct {
+	Name       string
+	Foreground lipgloss.Color
+	Background lipgloss.Color
+	Border     lipgloss.Color
+	Comment    lipgloss.Color
+	Cyan       lipgloss.Color
+	Green      lipgloss.Color
+	Orange     lipgloss.Color
+	Red        lipgloss.Color
+	Purple     lipgloss.Color
+	Pink       lipgloss.Color
+}
+
+// DarkTheme returns the default Dracula-based dark theme
+func DarkTheme() *Theme {
+	return &Theme{
+		Name:       "dark",
+		Foreground: lipgloss.Color("#f8f8f2"),
+		Background: lipgloss.Color("#282a36"),
+		Border:     lipgloss.Color("#44475a"),
+		Comment:    lipgloss.Color("#6272a4"),
+		Cyan:       lipgloss.Color("#8be9fd"),
+		Green:      lipgloss.Color("#50fa7b"),
+		Orange:     lipgloss.Color("#ffb86c"),
+		Red:        lipgloss.Color("#ff5555"),
+		Purple:     lipgloss.Color("#bd93f9"),
+		Pink:       lipgloss.Color("#ff79c6"),
+	}
+}
+
+// LightTheme returns a light theme for bright terminal backgrounds
+func LightTheme() *Theme {
+	return &Theme{
+		Name:       "light",
+		Foreground: lipgloss.Color("#1f1f1f"),
+		Background: lipgloss.Color("#fffbeb"),
+		Border:     lipgloss.Color("#cfcfde"),
+		Comment:    lipgloss.Color("#6c664b"),
+		Cyan:       lipgloss.Color("#036a96"),
+		Green:      lipgloss.Color("#14710a"),
+		Orange:     lipgloss.Color("#a34d14"),
+		Red:        lipgloss.Color("#cb3a2a"),
+		Purple:     lipgloss.Color("#644ac9"),
+		Pink:       lipgloss.Color("#a3144d"),
+	}
+}
+
+// ThemeColors overrides individual theme colors; empty fields keep the
+// theme's own color
+type ThemeColors struct {
+	Normal   string // Healthy values (green)
+	Warning  string // Warning band (orange)
+	Critical string // Critical band (red)
+	Label    string // Labels and headings (cyan)
+	Muted    string // Secondary text (comment)
+	Title    string // Panel titles (purple)
+	Accent   string // Highlights such as the active tab (pink)
+	Text     string // Regular text (foreground)
+	Border   string // Borders and empty bar segments
+}
+
+// WithColors returns a copy of the theme with the given colors applied
+func (t *Theme) WithColors(c ThemeColors) *Theme {
+	out := *t
+	overrides := []struct {
+		value  string
+		target *lipgloss.Color
+	}{
+		{c.Normal, &out.Green},
+		{c.Warning, &out.Orange},
+		{c.Critical, &out.Red},
+		{c.Label, &out.Cyan},
+		{c.Muted, &out.Comment},
+		{c.Title, &out.Purple},
+		{c.Accent, &out.Pink},
+		{c.Text, &out.Foreground},
+		{c.Border, &out.Border},
+	}
+	for _, o := range overrides {
+		if o.value != "" {
+			*o.target = lipgloss.Color(o.value)
+		}
+	}
+	return &out
+}
+
+// ThemeNames returns the names of all available themes in cycle order
+func ThemeNames() []string {
+	return []string{"dark", "light"}
+}
+
+// ThemeByName returns the theme with the given name, falling back to dark
+// for "auto" and unknown names
+func ThemeByName(name string) *Theme {
+	switch name {
+	case "light":
+		return LightTheme()
+	default:
+		return DarkTheme()
+	}
+}
+
+// NextTheme returns the theme following the given one in cycle order
+func NextTheme(current *Theme) *Theme {
+	names := ThemeNames()
+	for i, name := range names {
+		if current != nil && name == current.Name {
+			return ThemeByName(names[(i+1)%len(names)])
+		}
+	}
+	return ThemeByName(names[0])
+}
@@ -0,0 +1,67 @@
+package components
+
+import "github.com/charmbracelet/lipgloss"
+
+// Trend is the direction a metric moved since the previous sample
+type Trend int
+
+const (
+	TrendSteady Trend = iota
+	TrendRising
+	TrendFalling
+)
+
+// TrendOf compares the last two history points. Changes no larger than
+// threshold count as steady, so small jitter doesn't flip the arrow.
+func TrendOf(history []float64, threshold float64) Trend {
+	if len(history) < 2 {
+		return TrendSteady
+	}
+	delta := history[len(history)-1] - history[len(history)-2]
+	switch {
+	case delta > threshold:
+		return TrendRising
+	case delta < -threshold:
+		return TrendFalling
+	}
+	return TrendSteady
+}
+
+// TrendIndicator renders trend arrows; rising is drawn in the warning color
+// and falling in the normal color, since rising usage is the one to watch
+type TrendIndicator struct {
+	rising  lipgloss.Style
+	falling lipgloss.Style
+}
+
+// NewTrendIndicator creates a trend indicator with default styles
+func NewTrendIndicator() *TrendIndicator {
+	t := &TrendIndicator{}
+	t.SetTheme(DarkTheme())
+	return t
+}
+
+// SetTheme rebuilds the arrow styles from the given theme
+func (t *TrendIndicator) SetTheme(theme *Theme) {
+	t.rising = lipgloss.NewStyle().Foreground(theme.Orange)
+	t.falling = lipgloss.NewStyle().Foreground(theme.Green)
+}
+
+// Render returns " ▲" or " ▼" for a moving metric, and "" when steady
+func (t *TrendIndicator) Render(trend Trend) string {
+	switch trend {
+	case TrendRising:
+		return " " + t.rising.Render("▲")
+	case TrendFalling:
+		return " " + t.falling.Render("▼")
+	}
+	return ""
+}
+
+// Trends holds the current direction of each metric that shows an arrow
+type Trends struct {
+	CPU       Trend
+	Memory    Trend
+	NetworkRx Trend
+	NetworkTx Trend
+}
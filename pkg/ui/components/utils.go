@@ -1,6 +1,10 @@
 package components
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // formatBytes formats a byte count as human-readable
 func formatBytes(b uint64) string {
@@ -16,6 +20,12 @@ func formatBytes(b uint64) string {
 	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
+// FormatBytes formats a byte count as human-readable, for views outside
+// this package
+func FormatBytes(b uint64) string {
+	return formatBytes(b)
+}
+
 // formatUptime formats seconds into human-readable uptime
 func formatUptime(seconds uint64) string {
 	days := seconds / 86400
@@ -30,3 +40,19 @@ func formatUptime(seconds uint64) string {
 	}
 	return fmt.Sprintf("%dm", minutes)
 }
+
+// truncateString shortens s to at most width cells, ending with an ellipsis
+func truncateString(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
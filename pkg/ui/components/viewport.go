@@ -0,0 +1,114 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Viewport shows a window onto rendered content taller than the space it
+// has, with a line above and below saying how much is scrolled out of view.
+// Content that fits is shown as is.
+type Viewport struct {
+	mutedStyle lipgloss.Style
+	lines      []string
+	height     int // Visible lines, 0 for all
+	offset     int // First content line shown
+}
+
+// NewViewport creates a new viewport
+func NewViewport() *Viewport {
+	v := &Viewport{}
+	v.SetTheme(DarkTheme())
+	return v
+}
+
+// SetTheme rebuilds the viewport styles from the given theme
+func (v *Viewport) SetTheme(t *Theme) {
+	v.mutedStyle = lipgloss.NewStyle().Foreground(t.Comment)
+}
+
+// SetHeight sets how many lines are visible (0 shows all content)
+func (v *Viewport) SetHeight(h int) {
+	v.height = h
+	v.clamp()
+}
+
+// SetContent replaces the content, keeping the scroll position in range
+func (v *Viewport) SetContent(content string) {
+	v.lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+	v.clamp()
+}
+
+// GotoTop scrolls back to the start of the content
+func (v *Viewport) GotoTop() {
+	v.offset = 0
+}
+
+// ScrollUp scrolls the content up one line
+func (v *Viewport) ScrollUp() {
+	v.offset--
+	v.clamp()
+}
+
+// ScrollDown scrolls the content down one line
+func (v *Viewport) ScrollDown() {
+	v.offset++
+	v.clamp()
+}
+
+// scrolls reports whether the content is taller than the viewport. Below
+// three lines there is no room for the indicators, so content is cut off.
+func (v *Viewport) scrolls() bool {
+	return v.height >= 3 && len(v.lines) > v.height
+}
+
+// clamp keeps the offset within the content. At the end the top indicator
+// takes a line, so the last offset leaves height-1 content lines.
+func (v *Viewport) clamp() {
+	maxOffset := 0
+	if v.scrolls() {
+		maxOffset = len(v.lines) - v.height + 1
+	}
+	if v.offset > maxOffset {
+		v.offset = maxOffset
+	}
+	if v.offset < 0 {
+		v.offset = 0
+	}
+}
+
+// Render returns the visible part of the content
+func (v *Viewport) Render() string {
+	if !v.scrolls() {
+		if v.height > 0 && len(v.lines) > v.height {
+			return strings.Join(v.lines[:v.height], "\n")
+		}
+		return strings.Join(v.lines, "\n")
+	}
+
+	body := v.height
+	above := v.offset > 0
+	if above {
+		body--
+	}
+	below := len(v.lines) - v.offset - body
+	if below > 0 {
+		// The indicator takes the place of one more content line
+		body--
+		below++
+	}
+
+	var b strings.Builder
+	if above {
+		b.WriteString(v.mutedStyle.Render(fmt.Sprintf("▲ %d more", v.offset)))
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Join(v.lines[v.offset:v.offset+body], "\n"))
+	if below > 0 {
+		b.WriteString("\n")
+		b.WriteString(v.mutedStyle.Render(fmt.Sprintf("▼ %d more", below)))
+	}
+	return b.String()
+}
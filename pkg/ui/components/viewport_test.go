@@ -0,0 +1,80 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// numberedLines returns content of n lines "l0", "l1", ...
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("l%d", i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestViewportRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+		lines  int
+		scroll int // ScrollDown calls; negative for ScrollUp
+		want   string
+	}{
+		{"fits", 5, 3, 0, "l0\nl1\nl2"},
+		{"height zero shows all", 0, 4, 0, "l0\nl1\nl2\nl3"},
+		{"height below three cuts off", 2, 5, 0, "l0\nl1"},
+		{"height below three doesn't scroll", 2, 5, 3, "l0\nl1"},
+		{"one line over at top", 5, 6, 0, "l0\nl1\nl2\nl3\n▼ 2 more"},
+		{"one line over in middle", 5, 6, 1, "▲ 1 more\nl1\nl2\nl3\n▼ 2 more"},
+		{"one line over at max offset", 5, 6, 2, "▲ 2 more\nl2\nl3\nl4\nl5"},
+		{"scrolling past the end clamps", 5, 6, 10, "▲ 2 more\nl2\nl3\nl4\nl5"},
+		{"scrolling above the top clamps", 5, 6, -3, "l0\nl1\nl2\nl3\n▼ 2 more"},
+		{"smallest scrolling height", 3, 4, 1, "▲ 1 more\nl1\n▼ 2 more"},
+		{"smallest scrolling height at max offset", 3, 4, 2, "▲ 2 more\nl2\nl3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewViewport()
+			v.SetHeight(tt.height)
+			v.SetContent(numberedLines(tt.lines))
+			for range tt.scroll {
+				v.ScrollDown()
+			}
+			for range -tt.scroll {
+				v.ScrollUp()
+			}
+			if got := ansi.Strip(v.Render()); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewportClampOnResize(t *testing.T) {
+	v := NewViewport()
+	v.SetHeight(5)
+	v.SetContent(numberedLines(20))
+	for range 30 {
+		v.ScrollDown()
+	}
+	if v.offset != 16 {
+		t.Fatalf("offset at end = %d, want 16", v.offset)
+	}
+
+	// Shorter content pulls the offset back within range
+	v.SetContent(numberedLines(6))
+	if v.offset != 2 {
+		t.Errorf("offset after shrinking content = %d, want 2", v.offset)
+	}
+
+	// Content that now fits resets it entirely
+	v.SetHeight(10)
+	if v.offset != 0 {
+		t.Errorf("offset once content fits = %d, want 0", v.offset)
+	}
+}
@@ -2,9 +2,12 @@ package ui
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/config"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
 	"github.com/ctcac00/metrics-tui/pkg/ui/components/metrics"
 )
 
@@ -19,30 +22,98 @@ type Dashboard struct {
 	memoryMetrics  *metrics.MemoryMetrics
 	networkMetrics *metrics.NetworkMetrics
 	tempMetrics    *metrics.TemperatureMetrics
+
+	// layout lists the panels of each column, left to right, widths their
+	// relative widths (nil for equal columns) and heights the relative
+	// heights of stacked panels (nil to size them to their content)
+	layout  [][]string
+	widths  []int
+	heights map[string]int
+
+	// fit cuts stacked panels down to their share of the height
+	fit *components.Viewport
 }
 
 // NewDashboard creates a new dashboard component
 func NewDashboard() *Dashboard {
-	var colorBorder = lipgloss.Color("#44475a")
-
-	return &Dashboard{
-		border:         lipgloss.NewStyle().Foreground(colorBorder),
+	d := &Dashboard{
 		cpuMetrics:     metrics.NewCPUMetrics(),
 		memoryMetrics:  metrics.NewMemoryMetrics(),
 		networkMetrics: metrics.NewNetworkMetrics(),
 		tempMetrics:    metrics.NewTemperatureMetrics(),
+		layout:         config.DefaultDashboardLayout(),
+		fit:            components.NewViewport(),
+	}
+	d.SetTheme(components.DarkTheme())
+	return d
+}
+
+// SetTheme applies the theme to the dashboard and all of its panels
+func (d *Dashboard) SetTheme(t *components.Theme) {
+	d.border = lipgloss.NewStyle().Foreground(t.Border)
+	d.cpuMetrics.SetTheme(t)
+	d.memoryMetrics.SetTheme(t)
+	d.networkMetrics.SetTheme(t)
+	d.tempMetrics.SetTheme(t)
+	d.fit.SetTheme(t)
+}
+
+// SetLayout sets which panels appear in each column, the columns' relative
+// widths (nil for equal columns) and the relative heights of panels stacked
+// in a column (nil to size them to their content); all are expected to be
+// validated by the config package
+func (d *Dashboard) SetLayout(layout [][]string, widths []int, heights map[string]int) {
+	if len(layout) == 0 {
+		layout = config.DefaultDashboardLayout()
+	}
+	if len(widths) != len(layout) {
+		widths = nil
+	}
+	d.layout = layout
+	d.widths = widths
+	d.heights = heights
+	if d.width > 0 {
+		d.SetWidth(d.width)
 	}
 }
 
 // SetWidth sets the dashboard width
 func (d *Dashboard) SetWidth(w int) {
 	d.width = w
-	// Distribute width among panels (3 columns with spacing)
-	panelWidth := (w - 8) / 3
-	d.cpuMetrics.SetWidth(panelWidth)
-	d.memoryMetrics.SetWidth(panelWidth)
-	d.networkMetrics.SetWidth(panelWidth)
-	d.tempMetrics.SetWidth(panelWidth)
+	// Distribute width among the columns (with spacing), sizing every panel
+	// in a column to it
+	columns := len(d.layout)
+	available := w - 4*(columns-1)
+	total := columns
+	if d.widths != nil {
+		total = 0
+		for _, weight := range d.widths {
+			total += weight
+		}
+	}
+	for i, column := range d.layout {
+		weight := 1
+		if d.widths != nil {
+			weight = d.widths[i]
+		}
+		for _, panel := range column {
+			d.setPanelWidth(panel, available*weight/total)
+		}
+	}
+}
+
+// setPanelWidth sets the named panel's width
+func (d *Dashboard) setPanelWidth(panel string, w int) {
+	switch panel {
+	case "cpu":
+		d.cpuMetrics.SetWidth(w)
+	case "memory":
+		d.memoryMetrics.SetWidth(w)
+	case "network":
+		d.networkMetrics.SetWidth(w)
+	case "temperature":
+		d.tempMetrics.SetWidth(w)
+	}
 }
 
 // SetHeight sets the dashboard height
@@ -50,10 +121,78 @@ func (d *Dashboard) SetHeight(h int) {
 	d.height = h
 }
 
-// SetHistory sets the historical data for sparklines
-func (d *Dashboard) SetHistory(cpuHistory, memHistory []float64) {
-	d.cpuMetrics.SetHistory(cpuHistory)
-	d.memoryMetrics.SetHistory(memHistory)
+// SetValueDisplay sets whether used/total values show as percent, size, or both
+func (d *Dashboard) SetValueDisplay(values metrics.ValueDisplay) {
+	d.memoryMetrics.SetValueDisplay(values)
+}
+
+// SetMemoryPressure sets the weights and levels of the memory pressure gauge
+func (d *Dashboard) SetMemoryPressure(weights data.PressureWeights, warning, critical float64) {
+	d.memoryMetrics.SetPressure(weights, warning, critical)
+}
+
+// SetTrends sets the rising/falling arrows shown next to metric values
+func (d *Dashboard) SetTrends(trends components.Trends) {
+	d.cpuMetrics.SetTrend(trends.CPU)
+	d.memoryMetrics.SetTrend(trends.Memory)
+	d.networkMetrics.SetTrends(trends.NetworkRx, trends.NetworkTx)
+}
+
+// SetNetworkAliases sets friendly display names for network interfaces
+func (d *Dashboard) SetNetworkAliases(aliases map[string]string) {
+	d.networkMetrics.SetAliases(aliases)
+}
+
+// SetNetworkMinLinkSpeed sets the link speed in Mb/s below which a link is flagged
+func (d *Dashboard) SetNetworkMinLinkSpeed(mbps int) {
+	d.networkMetrics.SetMinLinkSpeed(mbps)
+}
+
+// SetConntrackThresholds sets the conntrack table usage percentages that color the network panel
+func (d *Dashboard) SetConntrackThresholds(warning, critical float64) {
+	d.networkMetrics.SetConntrackThresholds(warning, critical)
+}
+
+// SetCertThresholds sets the days left before expiry that color TLS certificates in the network panel
+func (d *Dashboard) SetCertThresholds(warning, critical float64) {
+	d.networkMetrics.SetCertThresholds(warning, critical)
+}
+
+// SetCPUMode sets how total CPU usage is shown ("percent" or "cores")
+func (d *Dashboard) SetCPUMode(mode string) {
+	d.cpuMetrics.SetMode(mode)
+}
+
+// SetCPUCoreTemps shows core temperatures on the CPU core rows
+func (d *Dashboard) SetCPUCoreTemps(enabled bool) {
+	d.cpuMetrics.SetCoreTemps(enabled)
+}
+
+// SetCPUBreakdown enables the stacked CPU time bar
+func (d *Dashboard) SetCPUBreakdown(enabled bool) {
+	d.cpuMetrics.SetBreakdown(enabled)
+}
+
+// SetHistory sets the historical data for sparklines and when each value
+// was sampled
+func (d *Dashboard) SetHistory(cpuHistory, memHistory []float64, times []time.Time) {
+	d.cpuMetrics.SetHistory(cpuHistory, times)
+	d.memoryMetrics.SetHistory(memHistory, times)
+}
+
+// SetLatencyHistory sets the ping round-trip time and loss history
+func (d *Dashboard) SetLatencyHistory(latency, loss map[string][]float64) {
+	d.networkMetrics.SetLatencyHistory(latency, loss)
+}
+
+// SetInterfaceHistory sets the per-interface receive/transmit rate history
+func (d *Dashboard) SetInterfaceHistory(history map[string]data.RxTxHistory) {
+	d.networkMetrics.SetInterfaceHistory(history)
+}
+
+// SetTemperatureHistory sets the temperature history of each sensor
+func (d *Dashboard) SetTemperatureHistory(history map[string][]float64) {
+	d.tempMetrics.SetHistory(history)
 }
 
 // ScrollUpCPU scrolls the CPU core list up
@@ -82,39 +221,129 @@ func (d *Dashboard) Render(systemData *data.SystemData) string {
 		return "Loading system data..."
 	}
 
-	// First, render Memory and Network to determine their combined height
-	// These don't need padding, so we render them first
-	memContent := d.memoryMetrics.Render(systemData)
-	netContent := d.networkMetrics.Render(systemData)
+	// Stacked panels with relative heights are cut or padded to their share
+	heights := d.panelHeights()
+
+	// Render every panel except Temperature first. Temperature is padded to
+	// the height of the tallest column that holds neither CPU (which scrolls
+	// independently) nor Temperature itself, so it doesn't look cut short.
+	contents := make(map[string]string)
+	tempHeight := 0
+	for _, column := range d.layout {
+		height := 0
+		matchable := true
+		for i, panel := range column {
+			if panel == "cpu" || panel == "temperature" {
+				matchable = false
+				continue
+			}
+			contents[panel] = d.fitPanel(d.renderPanel(panel, systemData), heights[panel])
+			height += len(strings.Split(contents[panel], "\n"))
+			if i > 0 {
+				height += 2 // spacing between stacked panels
+			}
+		}
+		if matchable && height > tempHeight {
+			tempHeight = height
+		}
+	}
 
-	// Calculate the combined height of column 3 (Memory + Network)
-	memLines := len(strings.Split(memContent, "\n"))
-	netLines := len(strings.Split(netContent, "\n"))
-	col3ContentHeight := memLines + netLines + 2 // +2 for spacing between panels
+	d.tempMetrics.SetHeight(0)
+	for _, column := range d.layout {
+		if len(column) == 1 && column[0] == "temperature" {
+			d.tempMetrics.SetHeight(tempHeight)
+		}
+	}
+	contents["temperature"] = d.fitPanel(d.renderPanel("temperature", systemData), heights["temperature"])
 
-	// Set target height for Temperature to match column 3
-	d.tempMetrics.SetHeight(col3ContentHeight)
+	// CPU content - render last as it scrolls independently
+	contents["cpu"] = d.fitPanel(d.renderPanel("cpu", systemData), heights["cpu"])
+
+	// Wrap each panel in a bordered box and stack each column's panels
+	columns := make([]string, len(d.layout))
+	for i, column := range d.layout {
+		panels := make([]string, len(column))
+		for j, panel := range column {
+			panels[j] = d.wrapInBox(dashboardTitles[panel], contents[panel])
+		}
+		columns[i] = d.stackRows(panels...)
+	}
 
-	// Now render Temperature with padding to match
-	tempContent := d.tempMetrics.Render(systemData)
+	return d.joinColumns(columns)
+}
 
-	// CPU content - render last as it scrolls independently
-	cpuContent := d.cpuMetrics.Render(systemData)
+// panelHeights splits the dashboard height between the panels of each
+// stacked column by their relative heights, returning the content lines of
+// each. Panels not named in heights count as 1; panels alone in their
+// column, or every panel when no heights are set, are left out and sized
+// to their content.
+func (d *Dashboard) panelHeights() map[string]int {
+	heights := make(map[string]int)
+	if len(d.heights) == 0 || d.height <= 0 {
+		return heights
+	}
+	for _, column := range d.layout {
+		if len(column) < 2 {
+			continue
+		}
+		// Each panel's box border takes two lines, and a blank line
+		// separates stacked panels
+		available := d.height - 2*len(column) - (len(column) - 1)
+		total := 0
+		for _, panel := range column {
+			total += d.panelWeight(panel)
+		}
+		for _, panel := range column {
+			heights[panel] = max(available*d.panelWeight(panel)/total, 1)
+		}
+	}
+	return heights
+}
 
-	// Wrap each in a bordered panel
-	cpuPanel := d.wrapInBox("CPU", cpuContent)
-	memPanel := d.wrapInBox("Memory", memContent)
-	netPanel := d.wrapInBox("Network", netContent)
-	tempPanel := d.wrapInBox("Temperature", tempContent)
+// panelWeight returns a panel's relative height, 1 when it isn't set
+func (d *Dashboard) panelWeight(panel string) int {
+	if weight, ok := d.heights[panel]; ok {
+		return weight
+	}
+	return 1
+}
 
-	// Layout: 3 columns
-	// Column 1: CPU
-	// Column 2: Temperature
-	// Column 3: Memory on top of Network
+// fitPanel cuts content taller than height behind a "▼ N more" line and
+// pads shorter content with blank lines. A height of 0 leaves content as is.
+func (d *Dashboard) fitPanel(content string, height int) string {
+	if height <= 0 {
+		return content
+	}
+	d.fit.SetHeight(height)
+	d.fit.SetContent(content)
+	content = d.fit.Render()
+	if lines := strings.Count(content, "\n") + 1; lines < height {
+		content += strings.Repeat("\n", height-lines)
+	}
+	return content
+}
 
-	col3 := d.stackRows(memPanel, netPanel)
+// dashboardTitles maps layout panel names to their box titles
+var dashboardTitles = map[string]string{
+	"cpu":         "CPU",
+	"memory":      "Memory",
+	"network":     "Network",
+	"temperature": "Temperature",
+}
 
-	return d.joinThreeColumns(cpuPanel, tempPanel, col3)
+// renderPanel renders the named panel's content
+func (d *Dashboard) renderPanel(panel string, systemData *data.SystemData) string {
+	switch panel {
+	case "cpu":
+		return d.cpuMetrics.Render(systemData)
+	case "memory":
+		return d.memoryMetrics.Render(systemData)
+	case "network":
+		return d.networkMetrics.Render(systemData)
+	case "temperature":
+		return d.tempMetrics.Render(systemData)
+	}
+	return ""
 }
 
 // wrapInBox wraps content in a nice bordered box
@@ -127,60 +356,44 @@ func (d *Dashboard) wrapInBox(title string, content string) string {
 	return borderStyle.Render(content)
 }
 
-// stackRows stacks two panels vertically
-func (d *Dashboard) stackRows(top, bottom string) string {
-	return top + "\n\n" + bottom
+// stackRows stacks panels vertically
+func (d *Dashboard) stackRows(panels ...string) string {
+	return strings.Join(panels, "\n\n")
 }
 
-// joinThreeColumns joins three panels side by side
-func (d *Dashboard) joinThreeColumns(col1, col2, col3 string) string {
-	lines1 := strings.Split(col1, "\n")
-	lines2 := strings.Split(col2, "\n")
-	lines3 := strings.Split(col3, "\n")
-
-	maxLines := len(lines1)
-	if len(lines2) > maxLines {
-		maxLines = len(lines2)
-	}
-	if len(lines3) > maxLines {
-		maxLines = len(lines3)
-	}
-
-	// Get visible width of each column's first line (ignores ANSI codes)
-	col1Width := 0
-	if len(lines1) > 0 {
-		col1Width = lipgloss.Width(lines1[0])
-	}
-	col2Width := 0
-	if len(lines2) > 0 {
-		col2Width = lipgloss.Width(lines2[0])
-	}
-
-	var result strings.Builder
-	for i := 0; i < maxLines; i++ {
-		// Column 1
-		if i < len(lines1) {
-			result.WriteString(lines1[i])
-		} else {
-			result.WriteString(strings.Repeat(" ", col1Width))
+// joinColumns joins panels side by side
+func (d *Dashboard) joinColumns(columns []string) string {
+	lines := make([][]string, len(columns))
+	widths := make([]int, len(columns))
+	maxLines := 0
+	for i, column := range columns {
+		lines[i] = strings.Split(column, "\n")
+		if len(lines[i]) > maxLines {
+			maxLines = len(lines[i])
 		}
-
-		result.WriteString("  ") // Spacing between columns
-
-		// Column 2
-		if i < len(lines2) {
-			result.WriteString(lines2[i])
-		} else {
-			result.WriteString(strings.Repeat(" ", col2Width))
+		// Visible width of the column's widest line (ignores ANSI codes)
+		for _, line := range lines[i] {
+			widths[i] = max(widths[i], lipgloss.Width(line))
 		}
+	}
 
-		result.WriteString("  ") // Spacing between columns
-
-		// Column 3
-		if i < len(lines3) {
-			result.WriteString(lines3[i])
+	var result strings.Builder
+	for row := 0; row < maxLines; row++ {
+		for i := range columns {
+			if i > 0 {
+				result.WriteString("  ") // Spacing between columns
+			}
+			line := ""
+			if row < len(lines[i]) {
+				line = lines[i][row]
+			}
+			result.WriteString(line)
+			// Pad short lines and columns so later columns stay aligned
+			if i < len(columns)-1 {
+				result.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(line)))
+			}
 		}
-		if i < maxLines-1 {
+		if row < maxLines-1 {
 			result.WriteString("\n")
 		}
 	}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDashboardPanelHeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  [][]string
+		heights map[string]int
+		want    map[string]int
+	}{
+		{
+			name:   "no heights",
+			layout: [][]string{{"cpu"}, {"memory", "network"}},
+			want:   map[string]int{},
+		},
+		{
+			// 30 lines less two borders each and one blank line leave 25
+			name:    "weighted stack",
+			layout:  [][]string{{"cpu"}, {"memory", "network"}},
+			heights: map[string]int{"memory": 4},
+			want:    map[string]int{"memory": 20, "network": 5},
+		},
+		{
+			name:    "three stacked, unlisted count as one",
+			layout:  [][]string{{"cpu", "memory", "network"}, {"temperature"}},
+			heights: map[string]int{"cpu": 2},
+			want:    map[string]int{"cpu": 11, "memory": 5, "network": 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDashboard()
+			d.SetHeight(30)
+			d.SetLayout(tt.layout, nil, tt.heights)
+			if got := d.panelHeights(); !maps.Equal(got, tt.want) {
+				t.Errorf("panelHeights() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboardFitPanel(t *testing.T) {
+	d := NewDashboard()
+	tests := []struct {
+		content string
+		height  int
+		want    string
+	}{
+		{"a\nb", 0, "a\nb"},
+		{"a\nb", 4, "a\nb\n\n"},
+		{"a\nb\nc\nd\ne", 4, "a\nb\nc\n▼ 2 more"},
+		{"a\nb\nc\nd", 4, "a\nb\nc\nd"},
+	}
+	for _, tt := range tests {
+		got := ansi.Strip(d.fitPanel(tt.content, tt.height))
+		if got != tt.want {
+			t.Errorf("fitPanel(%q, %d) = %q, want %q", tt.content, tt.height, got, tt.want)
+		}
+		if tt.height > 0 && strings.Count(got, "\n")+1 != tt.height {
+			t.Errorf("fitPanel(%q, %d) has %d lines", tt.content, tt.height, strings.Count(got, "\n")+1)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/pkg/collectors"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// DebugOverlay lists collector health and timings for troubleshooting
+type DebugOverlay struct {
+	title  lipgloss.Style
+	muted  lipgloss.Style
+	value  lipgloss.Style
+	ok     lipgloss.Style
+	failed lipgloss.Style
+	width  int
+	height int
+	table  *components.Table
+}
+
+// NewDebugOverlay creates a new debug overlay
+func NewDebugOverlay() *DebugOverlay {
+	d := &DebugOverlay{
+		table: components.NewTable([]components.Column{
+			{Title: "COLLECTOR", Width: 18},
+			{Title: "INTERVAL", Width: 8, Align: components.AlignRight},
+			{Title: "LAST OK", Width: 8, Align: components.AlignRight},
+			{Title: "AVG TIME", Width: 9, Align: components.AlignRight},
+			{Title: "RUNS", Width: 6, Align: components.AlignRight},
+			{Title: "FAILS", Width: 6, Align: components.AlignRight},
+			{Title: "LAST ERROR", MinWidth: 10},
+		}),
+	}
+	d.SetTheme(components.DarkTheme())
+	return d
+}
+
+// SetTheme rebuilds the overlay styles from the given theme
+func (d *DebugOverlay) SetTheme(t *components.Theme) {
+	d.title = lipgloss.NewStyle().Foreground(t.Purple).Bold(true)
+	d.muted = lipgloss.NewStyle().Foreground(t.Comment)
+	d.value = lipgloss.NewStyle().Foreground(t.Foreground)
+	d.ok = lipgloss.NewStyle().Foreground(t.Green)
+	d.failed = lipgloss.NewStyle().Foreground(t.Red)
+	d.table.SetTheme(t)
+}
+
+// SetSize sets the dimensions
+func (d *DebugOverlay) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+	d.table.SetWidth(width - 4)
+}
+
+// Render returns the overlay for the given collector statuses
+func (d *DebugOverlay) Render(statuses map[string]collectors.CollectorStatus) string {
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	rows := make([]components.Row, 0, len(names))
+	for _, name := range names {
+		s := statuses[name]
+
+		lastOK := "never"
+		if !s.LastSuccess.IsZero() {
+			lastOK = formatAge(now.Sub(s.LastSuccess))
+		}
+
+		errText, errStyle := "", d.ok
+		if s.LastError != nil {
+			errText, errStyle = s.LastError.Error(), d.failed
+		}
+
+		rows = append(rows, components.Row{
+			{Text: name, Style: d.value},
+			{Text: s.Interval.String(), Style: d.muted},
+			{Text: lastOK, Style: d.value},
+			{Text: s.AverageDuration().Round(time.Millisecond).String(), Style: d.value},
+			{Text: fmt.Sprintf("%d", s.SuccessCount), Style: d.ok},
+			{Text: fmt.Sprintf("%d", s.FailureCount), Style: errStyle},
+			{Text: errText, Style: errStyle},
+		})
+	}
+	d.table.SetRows(rows)
+
+	var b strings.Builder
+	b.WriteString(d.title.Render("Collector Health"))
+	b.WriteString("\n\n")
+	b.WriteString(d.table.Render())
+	b.WriteString("\n\n")
+	b.WriteString(d.muted.Italic(true).Render("Press D or Esc to close"))
+
+	return lipgloss.Place(d.width, d.height, lipgloss.Center, lipgloss.Center,
+		lipgloss.NewStyle().Padding(0, 2).Render(b.String()))
+}
+
+// formatAge formats how long ago something happened, e.g. "3s" or "2m05s"
+func formatAge(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
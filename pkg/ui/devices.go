@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ctcac00/metrics-tui/internal/data"
+)
+
+// deviceNoticeDuration is how long a hotplug notice stays in the footer
+const deviceNoticeDuration = 5 * time.Second
+
+// deviceWatcher remembers the mounted disks and network interfaces seen in
+// the previous collection, so hotplugged devices can be announced
+type deviceWatcher struct {
+	disks      map[string]bool
+	interfaces map[string]bool
+}
+
+// changes returns a notice for every disk or interface that appeared or
+// disappeared since the last call, e.g. "disk added: /media/usb". The first
+// collection of each kind only records the starting set.
+func (w *deviceWatcher) changes(systemData *data.SystemData) []string {
+	var notices []string
+
+	if systemData.Disk != nil {
+		current := make(map[string]bool, len(systemData.Disk.Usage))
+		for mount := range systemData.Disk.Usage {
+			current[mount] = true
+		}
+		notices = append(notices, diffDevices("disk", w.disks, current)...)
+		w.disks = current
+	}
+
+	if systemData.Network != nil {
+		current := make(map[string]bool, len(systemData.Network.IO))
+		for name := range systemData.Network.IO {
+			current[name] = true
+		}
+		notices = append(notices, diffDevices("interface", w.interfaces, current)...)
+		w.interfaces = current
+	}
+
+	return notices
+}
+
+// diffDevices lists added and removed names in sorted order; a nil previous
+// set means there is nothing to compare against yet
+func diffDevices(kind string, previous, current map[string]bool) []string {
+	if previous == nil {
+		return nil
+	}
+
+	var added, removed []string
+	for name := range current {
+		if !previous[name] {
+			added = append(added, name)
+		}
+	}
+	for name := range previous {
+		if !current[name] {
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+
+	notices := make([]string, 0, len(added)+len(removed))
+	for _, name := range added {
+		notices = append(notices, kind+" added: "+name)
+	}
+	for _, name := range removed {
+		notices = append(notices, kind+" removed: "+name)
+	}
+	return notices
+}
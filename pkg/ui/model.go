@@ -1,29 +1,79 @@
 package ui
 
 import (
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ctcac00/metrics-tui/internal/data"
 	"github.com/ctcac00/metrics-tui/pkg/collectors"
+	"github.com/ctcac00/metrics-tui/pkg/config"
 	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components/metrics"
 )
 
+// tabRefreshThrottle is the shortest gap between collections triggered by
+// switching tabs with ui.refresh_on_tab_switch
+const tabRefreshThrottle = time.Second
+
+// quitConfirmWindow is how long a second q press has to confirm quitting
+const quitConfirmWindow = 2 * time.Second
+
+// signalConfirmWindow is how long the prompt to signal a process waits for
+// an answer
+const signalConfirmWindow = 10 * time.Second
+
+// pendingSignal is a process signal waiting for the user to confirm it
+type pendingSignal struct {
+	process components.ProcessInfo
+	force   bool // SIGKILL rather than SIGTERM
+	asked   time.Time
+}
+
 // Model is the main Bubble Tea model for the TUI
 type Model struct {
-	width      int
-	height     int
-	quitting   bool
-	showHelp   bool
+	width     int
+	height    int
+	quitting  bool
+	showHelp  bool
+	showDebug bool
+	quitPress time.Time // First q press when ui.confirm_quit is on
+	signal    *pendingSignal
+	paused    atomic.Bool // Screen updates frozen with p
+
+	// Top mode process filter, and whether keys are typing into it
+	processFilter string
+	filtering     bool
+
+	// Previous network totals, for the receive/transmit rate history
+	lastNetRx   uint64
+	lastNetTx   uint64
+	lastNetTime time.Time
+
+	// Disks and interfaces seen last tick, for hotplug notices
+	devices deviceWatcher
+
 	systemData *data.SystemData
 	history    *data.HistoryData
+	config     *config.Config
+	theme      *components.Theme
 
 	// Components
 	header       *components.Header
 	footer       *components.Footer
 	help         *components.Help
+	debug        *DebugOverlay
 	dashboard    *Dashboard
+	panelTabs    *PanelTabs
+	sidebar      *components.Sidebar
+	topView      *TopView
+	compactView  *CompactView
 	alertBar     *components.AlertBar
 	alertManager *components.AlertManager
 
@@ -32,29 +82,115 @@ type Model struct {
 }
 
 // NewModel creates a new TUI model
-func NewModel() *Model {
+func NewModel(cfg *config.Config) *Model {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
 	m := &Model{
 		showHelp:   false,
 		systemData: &data.SystemData{},
 		history:    data.NewHistoryData(50), // 50 data points for sparklines
+		config:     cfg,
 	}
 
 	// Initialize components
 	m.header = components.NewHeader()
 	m.footer = components.NewFooter()
 	m.help = components.NewHelp()
+	m.debug = NewDebugOverlay()
 	m.dashboard = NewDashboard()
+	m.panelTabs = NewPanelTabs()
+	m.sidebar = components.NewSidebar()
+	m.sidebar.SetTabs(tabsFor(len(cfg.Collectors.Scripts) > 0))
+	m.sidebar.SetWidth(sidebarWidth)
+	m.topView = NewTopView()
+	m.topView.SetThresholds(cfg.Threshold)
+	m.compactView = NewCompactView()
+	m.compactView.SetThresholds(cfg.Threshold)
 	m.alertManager = components.NewAlertManager()
+	m.alertManager.SetLocation(cfg.Display.Location())
+	m.header.SetLocation(cfg.Display.Location())
+	m.panelTabs.SetLocation(cfg.Display.Location())
 	m.alertBar = components.NewAlertBar(m.alertManager)
+	m.alertBar.SetMaxItems(cfg.UI.AlertBarMaxItems)
+	m.applyTheme(components.ThemeByName(cfg.Display.Theme))
+	m.dashboard.SetCPUMode(cfg.Display.CPUMode)
+	m.panelTabs.SetCPUMode(cfg.Display.CPUMode)
+	m.topView.SetCPUMode(cfg.Display.CPUMode)
+	m.dashboard.SetCPUBreakdown(cfg.Display.CPUBreakdown)
+	m.panelTabs.SetCPUBreakdown(cfg.Display.CPUBreakdown)
+	m.topView.SetCPUBreakdown(cfg.Display.CPUBreakdown)
+	m.dashboard.SetCPUCoreTemps(cfg.CPU.CoreTemps)
+	m.panelTabs.SetCPUCoreTemps(cfg.CPU.CoreTemps)
+	m.topView.SetProcessThresholds(cfg.Process.CPUWarning, cfg.Process.CPUCritical, cfg.Process.MemWarning, cfg.Process.MemCritical)
+	m.topView.SetNormalizeProcessCPU(cfg.Process.NormalizeCPU)
+	m.topView.SetProcessSort(cfg.Process.Sort)
+	m.dashboard.SetNetworkAliases(cfg.Network.Aliases)
+	m.dashboard.SetNetworkMinLinkSpeed(cfg.Network.MinLinkSpeed)
+	m.dashboard.SetLayout(cfg.Dashboard.Layout, cfg.Dashboard.Widths, cfg.Dashboard.Heights)
+	values := metrics.ValueDisplay{Percent: cfg.Display.ShowPercentages, Absolute: cfg.Display.ShowAbsolute}
+	m.dashboard.SetValueDisplay(values)
+	m.panelTabs.SetValueDisplay(values)
+	m.dashboard.SetMemoryPressure(m.pressureWeights(), cfg.Threshold.PressureWarning, cfg.Threshold.PressureCritical)
+	m.panelTabs.SetMemoryPressure(m.pressureWeights(), cfg.Threshold.PressureWarning, cfg.Threshold.PressureCritical)
+	m.panelTabs.SetDiskLabels(cfg.Disk.Labels)
+	m.panelTabs.SetDiskInodeThresholds(cfg.Threshold.InodeWarning, cfg.Threshold.InodeCritical)
+	m.panelTabs.SetClockThresholds(cfg.Threshold.ClockOffsetWarning, cfg.Threshold.ClockOffsetCritical)
+	m.panelTabs.SetNetworkAliases(cfg.Network.Aliases)
+	m.panelTabs.SetNetworkMinLinkSpeed(cfg.Network.MinLinkSpeed)
+	m.dashboard.SetConntrackThresholds(cfg.Threshold.ConntrackWarning, cfg.Threshold.ConntrackCritical)
+	m.panelTabs.SetConntrackThresholds(cfg.Threshold.ConntrackWarning, cfg.Threshold.ConntrackCritical)
+	m.dashboard.SetCertThresholds(cfg.Threshold.CertExpiryWarning, cfg.Threshold.CertExpiryCritical)
+	m.panelTabs.SetCertThresholds(cfg.Threshold.CertExpiryWarning, cfg.Threshold.CertExpiryCritical)
 
 	// Set up alert thresholds
 	m.alertManager.SetThreshold("cpu", 70, 90)
 	m.alertManager.SetThreshold("memory", 80, 95)
 	m.alertManager.SetThreshold("temperature", 70, 85)
+	m.alertManager.SetThreshold("memory pressure", cfg.Threshold.PressureWarning, cfg.Threshold.PressureCritical)
+	m.alertManager.SetThreshold("conntrack", cfg.Threshold.ConntrackWarning, cfg.Threshold.ConntrackCritical)
 
 	// Initialize aggregator
-	config := collectors.DefaultAggregatorConfig()
-	m.aggregator = collectors.NewAggregator(config)
+	aggConfig := collectors.DefaultAggregatorConfig()
+	aggConfig.DisabledCollectors = cfg.Collectors.Disabled
+	if cfg.UI.Mode != "top" {
+		// Only the top view shows processes; skip scanning them otherwise
+		aggConfig.DisabledCollectors = append(slices.Clip(cfg.Collectors.Disabled), "processes")
+	}
+	aggConfig.Jitter = cfg.Collectors.Jitter
+	aggConfig.NetworkExcludeLoopback = cfg.Network.ExcludeLoopback
+	aggConfig.PingTargets = cfg.Network.PingTargets
+	aggConfig.CertEndpoints = cfg.Network.TLSEndpoints
+	aggConfig.UPSAddress = cfg.UPS.Address
+	aggConfig.Updates = cfg.Updates.Enabled
+	aggConfig.CPULogical = cfg.CPU.Logical
+	aggConfig.CPUPower = cfg.CPU.Power
+	aggConfig.MemoryUsedBasis = cfg.Memory.UsedBasis
+	aggConfig.Cgroup = !cfg.HostView
+	aggConfig.DiskDeviceInfo = cfg.Disk.ShowDeviceInfo
+	aggConfig.DiskTemperature = cfg.Disk.ShowTemperature
+	aggConfig.DiskHealth = cfg.Disk.ShowHealth
+	aggConfig.ProcessLimit = cfg.Process.Limit
+	// Per-process GPU usage only shows in the top view's process table
+	aggConfig.GPUProcesses = cfg.UI.Mode == "top"
+	applyIntervals(aggConfig, cfg.GetIntervalMap())
+	for _, script := range cfg.Collectors.Scripts {
+		aggConfig.Scripts = append(aggConfig.Scripts, collectors.ScriptConfig{
+			Name:     script.Name,
+			Command:  script.Command,
+			Interval: uint(script.Interval.Seconds()),
+			Unit:     script.Unit,
+			Warning:  script.Warning,
+			Critical: script.Critical,
+		})
+
+		// Scripts with a critical level take part in alerting
+		if script.Critical > 0 {
+			m.alertManager.SetThreshold(script.Name, script.Warning, script.Critical)
+		}
+	}
+	m.aggregator = collectors.NewAggregator(aggConfig)
 	m.aggregator.SetOnDataUpdate(m.onDataUpdate)
 
 	return m
@@ -63,15 +199,48 @@ func NewModel() *Model {
 // Init implements tea.Model
 func (m *Model) Init() tea.Cmd {
 	m.aggregator.Start()
-	return m.tickCmd()
+	return tea.Batch(m.tickCmd(), m.startedCmd())
 }
 
 // Update implements tea.Model
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The help overlay scrolls with the arrow keys and any other key
+		// closes it
+		if m.showHelp && msg.String() != "ctrl+c" {
+			switch msg.String() {
+			case "up", "k":
+				m.help.ScrollUp()
+			case "down", "j":
+				m.help.ScrollDown()
+			default:
+				m.showHelp = false
+				m.help.Hide()
+			}
+			return m, nil
+		}
+
+		// A signal prompt takes the next key: y sends, anything else cancels
+		if m.signal != nil && time.Since(m.signal.asked) <= signalConfirmWindow && msg.String() != "ctrl+c" {
+			m.confirmSignal(msg.String() == "y")
+			return m, nil
+		}
+		m.signal = nil
+
+		if m.filtering && msg.String() != "ctrl+c" {
+			m.editFilter(msg)
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
+			// With confirm_quit, q must be pressed twice; Ctrl+C always quits
+			if msg.String() == "q" && m.config.UI.ConfirmQuit && time.Since(m.quitPress) > quitConfirmWindow {
+				m.quitPress = time.Now()
+				m.footer.ShowMessage("Press q again to quit", quitConfirmWindow)
+				return m, nil
+			}
 			m.quitting = true
 			m.aggregator.Stop()
 			return m, tea.Quit
@@ -85,34 +254,140 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "T":
+			// Cycle to the next color theme
+			m.applyTheme(components.NextTheme(m.theme))
+			m.footer.ShowMessage("Theme: "+m.theme.Name, 2*time.Second)
+			return m, nil
+
+		case "D":
+			// Collector health overlay, only available when enabled
+			if m.config.DebugOverlay {
+				m.showDebug = !m.showDebug
+			}
+			return m, nil
+
+		case "y":
+			// Copy a text summary for pasting into chat or tickets
+			if !m.config.UI.Clipboard {
+				m.footer.ShowMessage("Clipboard is off (set ui.clipboard: true)", 3*time.Second)
+				return m, nil
+			}
+			summary := components.Summary(m.systemData, m.alertManager, m.config.Display.Location())
+			method, err := components.CopyToClipboard(summary, os.Stdout)
+			if err != nil {
+				m.footer.ShowMessage("Copy failed: "+err.Error(), 3*time.Second)
+			} else {
+				m.footer.ShowMessage("Summary copied ("+method+")", 2*time.Second)
+			}
+			return m, nil
+
+		case "o":
+			// Cycle the process table ordering: CPU, memory, disk I/O, GPU
+			if m.config.UI.Mode == "top" {
+				m.footer.ShowMessage("Sort processes by "+m.topView.NextProcessSort(), 2*time.Second)
+			}
+			return m, nil
+
+		case "/":
+			// Narrow the top mode process table as a filter is typed
+			if m.config.UI.Mode == "top" {
+				m.filtering = true
+				m.topView.SetProcessFilter(m.processFilter, true)
+			}
+			return m, nil
+
+		case "x", "X":
+			// Terminate (x) or kill (X) the selected process, after confirming
+			if m.config.UI.Mode == "top" {
+				m.askSignal(msg.String() == "X")
+			}
+			return m, nil
+
+		case "p":
+			// Freeze the screen so values can be read or copied
+			m.setPaused(!m.paused.Load())
+			return m, nil
+
+		case "r":
+			// Collect everything now instead of waiting for the intervals
+			if m.paused.Load() {
+				m.footer.ShowMessage("Paused, press p to resume", 2*time.Second)
+				return m, nil
+			}
+			m.footer.ShowMessage("Refreshing...", 2*time.Second)
+			aggregator := m.aggregator
+			return m, func() tea.Msg {
+				aggregator.CollectAll(tabRefreshThrottle)
+				return dataMsg{data: aggregator.GetSystemData()}
+			}
+
+		case "R":
+			// Start every sparkline and trend over, e.g. before a benchmark
+			m.history.Reset()
+			m.lastNetTime = time.Time{}
+			m.footer.ShowMessage("History cleared", 2*time.Second)
+			return m, nil
+
 		case "esc", "escape":
-			// Close help on escape
+			// Close overlays on escape
 			if m.showHelp {
 				m.showHelp = false
 				m.help.Hide()
 			}
+			m.showDebug = false
+			m.processFilter = ""
+			m.topView.SetProcessFilter("", false)
 			return m, nil
 
 		case "s":
 			// Take snapshot
 			snapshotMgr := components.NewSnapshotManagerWithDefaults()
+			snapshotMgr.SetLocation(m.config.Display.Location())
+			snapshotMgr.SetAnonymize(m.config.Snapshot.Anonymize)
 			snapshot, err := snapshotMgr.TakeSnapshot(m.systemData)
 			if err == nil {
 				snapshotMgr.SaveToFile(snapshot, "")
 			}
 			return m, nil
 
+		case "e":
+			// Export all history, alert history and the current state
+			exporter := components.NewExporterWithDefaults()
+			exporter.SetLocation(m.config.Display.Location())
+			exporter.SetAnonymize(m.config.Snapshot.Anonymize)
+			path, err := exporter.Export(m.systemData, m.history, m.alertManager)
+			if err != nil {
+				m.footer.ShowMessage("Export failed: "+err.Error(), 3*time.Second)
+			} else {
+				m.footer.ShowMessage("Exported to "+path, 3*time.Second)
+			}
+			return m, nil
+
+		case "tab":
+			m.cycleTab(1)
+			return m, m.refreshTabCmd()
+
+		case "shift+tab":
+			m.cycleTab(-1)
+			return m, m.refreshTabCmd()
+
+		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			m.selectTab(int(msg.String()[0] - '0'))
+			return m, m.refreshTabCmd()
+
 		case "up", "k":
-			// Scroll CPU cores up
-			m.dashboard.ScrollUpCPU()
+			m.scrollUp()
 			return m, nil
 
 		case "down", "j":
-			// Scroll CPU cores down
-			m.dashboard.ScrollDownCPU()
+			m.scrollDown()
 			return m, nil
 		}
 
+	case tea.MouseMsg:
+		return m, m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -120,17 +395,37 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.header.SetWidth(msg.Width)
 		m.footer.SetWidth(msg.Width)
 		m.help.SetSize(msg.Width, msg.Height)
-		m.dashboard.SetWidth(msg.Width - 4)   // Leave padding
-		m.dashboard.SetHeight(msg.Height - 4) // Leave room for header and footer
+		m.debug.SetSize(msg.Width, msg.Height)
+		m.dashboard.SetWidth(m.contentWidth()) // Leave room for navigation and padding
+		m.dashboard.SetHeight(msg.Height - 4)  // Leave room for header and footer
+		m.panelTabs.SetWidth(m.contentWidth())
+		m.panelTabs.SetHeight(msg.Height - 4)
+		m.sidebar.SetHeight(msg.Height - 4)
+		m.topView.SetWidth(msg.Width - 2)
+		m.topView.SetHeight(msg.Height - 3)
+		m.compactView.SetWidth(msg.Width)
 		m.alertBar.SetWidth(msg.Width)
 
 	case tickMsg:
+		if m.paused.Load() {
+			return m, m.tickCmd()
+		}
 		// Update history with latest data
 		m.updateHistory()
+		if notices := m.devices.changes(m.systemData); len(notices) > 0 {
+			m.footer.ShowMessage(strings.Join(notices, ", "), deviceNoticeDuration)
+		}
 		return m, m.tickCmd()
 
+	case startedMsg:
+		// Show the first round of data without waiting for the next tick
+		m.systemData = m.aggregator.GetSystemData()
+		m.updateHistory()
+
 	case dataMsg:
-		m.systemData = msg.data
+		if !m.paused.Load() {
+			m.systemData = msg.data
+		}
 	}
 
 	return m, nil
@@ -152,9 +447,32 @@ func (m *Model) View() string {
 		return m.help.Render()
 	}
 
+	// Debug overlay with collector health
+	if m.showDebug {
+		return m.debug.Render(m.aggregator.CollectorStatus())
+	}
+
+	// Compact mode is one line per metric with no header, tabs, or footer,
+	// for embedding in a tmux pane
+	if m.config.UI.Mode == "compact" {
+		if m.history != nil && m.config.Display.ShowGraphs {
+			m.compactView.SetHistory(m.history)
+		}
+		return m.compactView.Render(m.systemData)
+	}
+
 	// Update history data for dashboard
-	if m.history != nil {
-		m.dashboard.SetHistory(m.history.CPU, m.history.Memory)
+	if m.history != nil && m.config.Display.ShowGraphs {
+		m.dashboard.SetHistory(m.history.CPU, m.history.Memory, m.history.Times)
+		m.dashboard.SetLatencyHistory(m.history.Latency, m.history.Loss)
+		m.dashboard.SetInterfaceHistory(m.history.Interfaces)
+		m.dashboard.SetTemperatureHistory(m.history.Temps)
+		m.panelTabs.SetHistory(m.history)
+		m.header.SetBusyHistory(m.history.Busy)
+	}
+	if m.history != nil && m.config.Display.ShowTrends {
+		m.dashboard.SetTrends(m.trends())
+		m.panelTabs.SetTrends(m.trends())
 	}
 
 	// Render header with alert bar
@@ -164,34 +482,353 @@ func (m *Model) View() string {
 		header = lipgloss.JoinVertical(lipgloss.Left, header, alertBar)
 	}
 
-	// Render dashboard
-	dashboard := m.dashboard.Render(m.systemData)
-
 	// Render footer
 	footer := m.footer.Render()
 
-	// Add padding around dashboard
-	dashboardStyle := lipgloss.NewStyle().Padding(1, 2)
-	dashboardPadded := dashboardStyle.Render(dashboard)
+	// Top mode replaces the dashboard with summary bars and a process table
+	if m.config.UI.Mode == "top" {
+		topStyle := lipgloss.NewStyle().Padding(0, 1)
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			header,
+			topStyle.Render(m.topView.Render(m.systemData)),
+			footer,
+		)
+	}
+
+	// Render the dashboard or the selected metric panel
+	var content string
+	if tab := m.activeTab(); tab == TabDashboard {
+		content = m.dashboard.Render(m.systemData)
+	} else {
+		content = m.panelTabs.Render(tab, m.systemData)
+	}
+
+	// Add padding around content and place the tabs to its left, or above
+	// it as a strip when using the top bar
+	contentStyle := lipgloss.NewStyle().Padding(1, 2)
+	var body string
+	if m.config.UI.NavStyle == "topbar" {
+		body = lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.sidebar.RenderHorizontal(),
+			contentStyle.Render(content),
+		)
+	} else {
+		sidebarStyle := lipgloss.NewStyle().PaddingTop(1)
+		body = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			sidebarStyle.Render(m.sidebar.Render()),
+			contentStyle.Render(content),
+		)
+	}
 
 	// Join all parts vertically
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		header,
-		dashboardPadded,
+		body,
 		footer,
 	)
 }
 
+// editFilter applies a key typed into the process filter: Enter keeps the
+// filter and returns keys to their shortcuts, Esc clears it, and the arrow
+// keys still move the selection
+func (m *Model) editFilter(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc:
+		m.filtering = false
+		m.processFilter = ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.processFilter); len(runes) > 0 {
+			m.processFilter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyUp:
+		m.scrollUp()
+	case tea.KeyDown:
+		m.scrollDown()
+	case tea.KeySpace, tea.KeyRunes:
+		m.processFilter += string(msg.Runes)
+	}
+	m.topView.SetProcessFilter(m.processFilter, m.filtering)
+}
+
+// askSignal prompts to send SIGTERM, or SIGKILL when force is set, to the
+// process selected in the top mode table
+func (m *Model) askSignal(force bool) {
+	proc, ok := m.topView.SelectedProcess()
+	if !ok {
+		return
+	}
+	m.signal = &pendingSignal{process: proc, force: force, asked: time.Now()}
+	m.footer.ShowMessage(fmt.Sprintf("Send %s to %s (%d)? y/n", signalName(force), proc.Name, proc.PID), signalConfirmWindow)
+}
+
+// confirmSignal sends the pending signal when confirmed and drops it either way
+func (m *Model) confirmSignal(confirmed bool) {
+	sig := m.signal
+	m.signal = nil
+	if !confirmed {
+		m.footer.ShowMessage("Cancelled", 2*time.Second)
+		return
+	}
+	name := signalName(sig.force)
+	if err := collectors.SignalProcess(int32(sig.process.PID), sig.process.Name, sig.force); err != nil {
+		m.footer.ShowMessage(name+" failed: "+err.Error(), 3*time.Second)
+		return
+	}
+	m.footer.ShowMessage(fmt.Sprintf("Sent %s to %s (%d)", name, sig.process.Name, sig.process.PID), 2*time.Second)
+}
+
+// signalName names the signal askSignal sends
+func signalName(force bool) string {
+	if force {
+		return "SIGKILL"
+	}
+	return "SIGTERM"
+}
+
+// scrollUp scrolls CPU cores, lists and tall panels up
+func (m *Model) scrollUp() {
+	m.dashboard.ScrollUpCPU()
+	m.panelTabs.ScrollUpCPU()
+	m.panelTabs.ScrollUpConnections()
+	m.panelTabs.ScrollUpServices()
+	m.panelTabs.ScrollUpKubernetes()
+	m.panelTabs.ScrollUpVMs()
+	m.panelTabs.ScrollUpPanel()
+	m.topView.ScrollUp()
+}
+
+// scrollDown scrolls CPU cores, lists and tall panels down
+func (m *Model) scrollDown() {
+	m.dashboard.ScrollDownCPU()
+	m.panelTabs.ScrollDownCPU()
+	m.panelTabs.ScrollDownConnections()
+	m.panelTabs.ScrollDownServices()
+	m.panelTabs.ScrollDownKubernetes()
+	m.panelTabs.ScrollDownVMs()
+	m.panelTabs.ScrollDownPanel()
+	m.topView.ScrollDown()
+}
+
+// handleMouse scrolls like the arrow keys on the wheel, switches tabs when
+// one is clicked and acknowledges an alert clicked in the alert bar. Click
+// positions are matched against the layout View renders.
+func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if m.showHelp {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.help.ScrollUp()
+		case tea.MouseButtonWheelDown:
+			m.help.ScrollDown()
+		}
+		return nil
+	}
+	if m.showDebug || m.config.UI.Mode == "compact" {
+		return nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scrollUp()
+		return nil
+	case tea.MouseButtonWheelDown:
+		m.scrollDown()
+		return nil
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	// The alert bar, when shown, is the line right under the header
+	bodyTop := lipgloss.Height(m.header.Render(m.systemData))
+	if m.alertBar.Render() != "" {
+		if msg.Y == bodyTop {
+			if metric := m.alertBar.AlertAt(msg.X); metric != "" {
+				m.alertManager.Acknowledge(metric)
+				m.footer.ShowMessage("Alert acknowledged", 2*time.Second)
+			}
+			return nil
+		}
+		bodyTop++
+	}
+	if m.config.UI.Mode == "top" {
+		return nil
+	}
+
+	index := -1
+	if m.config.UI.NavStyle == "topbar" {
+		if msg.Y == bodyTop {
+			index = m.sidebar.HorizontalTabAt(msg.X)
+		}
+	} else if msg.X < sidebarWidth {
+		// The sidebar starts one line into the body
+		index = m.sidebar.TabAt(msg.Y - bodyTop - 1)
+	}
+	if index < 0 || index == m.sidebar.GetActiveTab() {
+		return nil
+	}
+	m.sidebar.SetActiveTab(index)
+	return m.refreshTabCmd()
+}
+
+// contentWidth returns the width available to the dashboard and panels
+func (m *Model) contentWidth() int {
+	if m.config.UI.NavStyle == "topbar" {
+		return m.width - 4
+	}
+	return m.width - sidebarWidth - 4
+}
+
+// activeTab returns the number of the selected tab
+func (m *Model) activeTab() int {
+	tabs := m.sidebar.Tabs()
+	if len(tabs) == 0 {
+		return TabDashboard
+	}
+	return tabs[m.sidebar.GetActiveTab()].Number
+}
+
+// selectTab switches to the tab with the given number, if it is shown
+func (m *Model) selectTab(number int) {
+	for i, tab := range m.sidebar.Tabs() {
+		if tab.Number == number {
+			m.sidebar.SetActiveTab(i)
+			return
+		}
+	}
+}
+
+// cycleTab moves the tab selection by delta, wrapping around
+func (m *Model) cycleTab(delta int) {
+	count := len(m.sidebar.Tabs())
+	if count == 0 {
+		return
+	}
+	m.sidebar.SetActiveTab(((m.sidebar.GetActiveTab()+delta)%count + count) % count)
+}
+
+// refreshTabCmd collects the active tab's metrics right away, so switching
+// to a slow collector's tab doesn't show stale data. It is a no-op unless
+// ui.refresh_on_tab_switch is set, and while updates are paused;
+// collectors refreshed less than tabRefreshThrottle ago are skipped.
+func (m *Model) refreshTabCmd() tea.Cmd {
+	if !m.config.UI.RefreshOnTabSwitch || m.paused.Load() {
+		return nil
+	}
+	name, ok := tabCollectors[m.activeTab()]
+	if !ok {
+		return nil
+	}
+
+	aggregator := m.aggregator
+	return func() tea.Msg {
+		if !aggregator.CollectNow(name, tabRefreshThrottle) {
+			return nil
+		}
+		return dataMsg{data: aggregator.GetSystemData()}
+	}
+}
+
+// applyTheme rebuilds the styles of every component from the given theme,
+// with any color overrides from the config applied on top
+func (m *Model) applyTheme(theme *components.Theme) {
+	colors := m.config.Display.Colors
+	theme = theme.WithColors(components.ThemeColors{
+		Normal:   colors.Normal,
+		Warning:  colors.Warning,
+		Critical: colors.Critical,
+		Label:    colors.Label,
+		Muted:    colors.Muted,
+		Title:    colors.Title,
+		Accent:   colors.Accent,
+		Text:     colors.Text,
+		Border:   colors.Border,
+	})
+	m.theme = theme
+	m.header.SetTheme(theme)
+	m.footer.SetTheme(theme)
+	m.help.SetTheme(theme)
+	m.debug.SetTheme(theme)
+	m.dashboard.SetTheme(theme)
+	m.panelTabs.SetTheme(theme)
+	m.sidebar.SetTheme(theme)
+	m.topView.SetTheme(theme)
+	m.compactView.SetTheme(theme)
+	m.alertBar.SetTheme(theme)
+}
+
+// applyIntervals copies configured collector intervals into the aggregator
+// config; sub-second values keep the aggregator default, since collectors
+// tick in whole seconds
+func applyIntervals(aggConfig *collectors.AggregatorConfig, intervals map[string]uint) {
+	for name, target := range map[string]*uint{
+		"cpu":         &aggConfig.CPUInterval,
+		"memory":      &aggConfig.MemoryInterval,
+		"disk":        &aggConfig.DiskInterval,
+		"network":     &aggConfig.NetworkInterval,
+		"sensors":     &aggConfig.SensorsInterval,
+		"host":        &aggConfig.HostInterval,
+		"processes":   &aggConfig.ProcessInterval,
+		"gpu":         &aggConfig.GPUInterval,
+		"containers":  &aggConfig.ContainerInterval,
+		"bandwidth":   &aggConfig.BandwidthInterval,
+		"connections": &aggConfig.ConnectionInterval,
+		"services":    &aggConfig.ServiceInterval,
+		"kubernetes":  &aggConfig.KubernetesInterval,
+		"vms":         &aggConfig.VMInterval,
+		"rpi":         &aggConfig.PiInterval,
+		"ping":        &aggConfig.PingInterval,
+		"clock":       &aggConfig.ClockInterval,
+		"conntrack":   &aggConfig.ConntrackInterval,
+		"ups":         &aggConfig.UPSInterval,
+		"updates":     &aggConfig.UpdatesInterval,
+		"certs":       &aggConfig.CertInterval,
+	} {
+		if interval := intervals[name]; interval > 0 {
+			*target = interval
+		}
+	}
+}
+
 // onDataUpdate is called when new data is available from the aggregator
 func (m *Model) onDataUpdate(d *data.SystemData) {
+	if m.paused.Load() {
+		return
+	}
 	m.systemData = d
 }
 
+// setPaused freezes or resumes screen updates, and with
+// ui.pause_collection collection too
+func (m *Model) setPaused(paused bool) {
+	m.paused.Store(paused)
+	m.header.SetPaused(paused)
+	if m.config.UI.PauseCollection {
+		m.aggregator.SetPaused(paused)
+	}
+	if paused {
+		m.footer.ShowMessage("Paused, press p to resume", 2*time.Second)
+	} else {
+		m.footer.ShowMessage("Resumed", 2*time.Second)
+	}
+}
+
 // updateHistory updates the history data with current values
 func (m *Model) updateHistory() {
+	m.history.AddTime(time.Now())
 	if m.systemData.CPU != nil {
 		m.history.AddCPU(m.systemData.CPU.Total)
+		if watts, ok := m.systemData.CPU.PackagePower(); ok {
+			m.history.AddPower(watts)
+		}
 		// Check CPU alerts
 		m.alertManager.CheckValue("cpu", m.systemData.CPU.Total)
 	}
@@ -199,7 +836,162 @@ func (m *Model) updateHistory() {
 		m.history.AddMemory(m.systemData.Memory.UsedPercent)
 		// Check memory alerts
 		m.alertManager.CheckValue("memory", m.systemData.Memory.UsedPercent)
+		if pressure, ok := data.MemoryPressure(m.systemData.Memory, m.pressureWeights()); ok {
+			m.alertManager.CheckValue("memory pressure", pressure)
+		}
+	}
+	if m.systemData.Network != nil {
+		m.addNetworkRates(m.systemData.Network)
+		for name, rate := range m.systemData.Network.Rates {
+			m.history.AddInterface(name, rate.BytesRecvPerSec, rate.BytesSentPerSec)
+		}
+	}
+	if m.systemData.Disk != nil {
+		for device, rate := range m.systemData.Disk.Rates {
+			m.history.AddDisk(device, rate.ReadBytesPerSec, rate.WriteBytesPerSec)
+		}
+	}
+	// A full conntrack table drops new connections, so warn before it fills
+	if conntrack := m.systemData.Conntrack; conntrack != nil && conntrack.Max > 0 {
+		m.alertManager.CheckValue("conntrack", conntrack.UsedPercent())
+	}
+	if m.systemData.Host != nil && m.systemData.Host.Activity != nil {
+		m.history.AddActivity(*m.systemData.Host.Activity)
+	}
+	if m.systemData.Ping != nil {
+		for _, target := range m.systemData.Ping.Targets {
+			m.history.AddPing(target)
+		}
+	}
+	if m.systemData.Sensors != nil {
+		for _, temp := range m.systemData.Sensors.Temperatures {
+			m.history.AddTemperature(temp.SensorKey, temp.Temperature)
+		}
 	}
+
+	// Composite system pressure for the header sparkline
+	weights := m.config.Display.BusyWeights
+	if busy, ok := data.SystemBusy(m.systemData, data.BusyWeights{
+		CPU:    weights.CPU,
+		IOWait: weights.IOWait,
+		Load:   weights.Load,
+	}); ok {
+		m.history.AddBusy(busy)
+	}
+
+	// Degraded software RAID arrays raise a critical alert until rebuilt
+	if m.systemData.Disk != nil {
+		for _, array := range m.systemData.Disk.RAID {
+			message := ""
+			if array.Degraded() {
+				message = fmt.Sprintf("RAID %s degraded: %d of %d devices active", array.Name, array.Active, array.Devices)
+				if array.Sync == "recovery" {
+					message += fmt.Sprintf(", rebuilding %.1f%%", array.SyncPercent)
+				}
+			}
+			m.alertManager.SetStateAlert("raid "+array.Name, components.Critical, message)
+		}
+	}
+
+	// Raspberry Pi firmware flags: under-voltage is critical, any throttling
+	// or frequency capping a warning, both only while currently set
+	if pi := m.systemData.Pi; pi != nil && pi.HasThrottled {
+		underVoltage := ""
+		if pi.Active(data.PiUnderVoltage) {
+			underVoltage = "Raspberry Pi under-voltage detected, check the power supply"
+		}
+		m.alertManager.SetStateAlert("under-voltage", components.Critical, underVoltage)
+
+		throttling := ""
+		if pi.Active(data.PiFreqCapped | data.PiThrottled | data.PiSoftTempLimit) {
+			var reasons []string
+			for _, reason := range pi.ActiveReasons() {
+				if reason != "under-voltage" {
+					reasons = append(reasons, reason)
+				}
+			}
+			throttling = fmt.Sprintf("Raspberry Pi %s at %.1f°C", strings.Join(reasons, ", "), pi.SoCTemp)
+		}
+		m.alertManager.SetStateAlert("throttling", components.Warning, throttling)
+	}
+
+	// Clock drift: a time service that lost sync is a warning; the offset
+	// is checked against the thresholds in milliseconds either direction
+	if clock := m.systemData.Clock; clock != nil && clock.Source != "" {
+		unsynced := ""
+		if !clock.Synced {
+			unsynced = fmt.Sprintf("System clock is not synchronized (%s)", clock.Source)
+		}
+		m.alertManager.SetStateAlert("clock sync", components.Warning, unsynced)
+
+		severity, drift := components.Info, ""
+		if clock.HasOffset {
+			offset := math.Abs(clock.Offset)
+			threshold := m.config.Threshold
+			if offset >= threshold.ClockOffsetCritical {
+				severity = components.Critical
+			} else if offset >= threshold.ClockOffsetWarning {
+				severity = components.Warning
+			}
+			if severity != components.Info {
+				drift = fmt.Sprintf("System clock is off by %+.1f ms (%s)", clock.Offset, clock.Source)
+			}
+		}
+		m.alertManager.SetStateAlert("clock offset", severity, drift)
+	}
+
+	// UPS on battery: critical for as long as mains power is out
+	if ups := m.systemData.UPS; ups != nil {
+		for _, stat := range ups.UPSes {
+			message := ""
+			if stat.OnBattery {
+				message = fmt.Sprintf("UPS %s on battery", stat.Name)
+				if stat.Charge >= 0 {
+					message += fmt.Sprintf(": %.0f%% charge", stat.Charge)
+				}
+				if stat.Runtime > 0 {
+					message += fmt.Sprintf(", %d min runtime left", int(stat.Runtime.Minutes()))
+				}
+				if stat.LowBattery {
+					message += ", battery low"
+				}
+			}
+			m.alertManager.SetStateAlert("ups "+stat.Name, components.Critical, message)
+		}
+	}
+
+	// TLS certificates close to expiry, checked against the thresholds in
+	// days left
+	if certs := m.systemData.Certs; certs != nil {
+		threshold := m.config.Threshold
+		for _, cert := range certs.Certs {
+			days := cert.DaysLeft(time.Now())
+			severity, message := components.Info, ""
+			if days < threshold.CertExpiryCritical {
+				severity = components.Critical
+			} else if days < threshold.CertExpiryWarning {
+				severity = components.Warning
+			}
+			if days < 0 {
+				message = fmt.Sprintf("TLS certificate of %s expired %s", cert.Endpoint, cert.NotAfter.Format("Jan 02 2006"))
+			} else if severity != components.Info {
+				message = fmt.Sprintf("TLS certificate of %s expires in %.0f days", cert.Endpoint, math.Floor(days))
+			}
+			m.alertManager.SetStateAlert("cert "+cert.Endpoint, severity, message)
+		}
+	}
+
+	// Failed or unplugged power supplies reported by the BMC
+	if m.systemData.Sensors != nil {
+		for _, psu := range m.systemData.Sensors.PowerSupplies {
+			message := ""
+			if psu.Failed {
+				message = fmt.Sprintf("%s: %s", psu.Name, psu.Status)
+			}
+			m.alertManager.SetStateAlert("psu "+psu.Name, components.Critical, message)
+		}
+	}
+
 	// Check temperature alerts
 	if m.systemData.Sensors != nil && len(m.systemData.Sensors.Temperatures) > 0 {
 		// Get the highest temperature
@@ -212,6 +1004,12 @@ func (m *Model) updateHistory() {
 		m.alertManager.CheckValue("temperature", maxTemp)
 	}
 
+	// Check custom metric alerts (only those with thresholds are registered)
+	for _, metric := range m.systemData.Custom {
+		m.history.AddCustom(metric.Name, metric.Value)
+		m.alertManager.CheckValue(metric.Name, metric.Value)
+	}
+
 	// Update alert bar visibility
 	hasAlerts := len(m.alertManager.GetActiveAlerts()) > 0
 	if hasAlerts {
@@ -221,16 +1019,82 @@ func (m *Model) updateHistory() {
 	}
 }
 
+// pressureWeights converts the configured memory pressure weights
+func (m *Model) pressureWeights() data.PressureWeights {
+	pressure := m.config.Memory.Pressure
+	return data.PressureWeights{
+		Available:    pressure.Available,
+		Swap:         pressure.Swap,
+		SwapFullRate: pressure.SwapFullMiB * 1024 * 1024,
+	}
+}
+
+// addNetworkRates records receive/transmit rates summed over all shown
+// interfaces, derived from the byte counters of consecutive samples
+func (m *Model) addNetworkRates(network *data.NetworkMetrics) {
+	var rx, tx uint64
+	for _, io := range network.IO {
+		rx += io.BytesRecv
+		tx += io.BytesSent
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(m.lastNetTime).Seconds()
+	// Skip the first sample and counters that went back (interface gone)
+	if !m.lastNetTime.IsZero() && elapsed > 0 && rx >= m.lastNetRx && tx >= m.lastNetTx {
+		m.history.AddNetworkRx(float64(rx-m.lastNetRx) / elapsed)
+		m.history.AddNetworkTx(float64(tx-m.lastNetTx) / elapsed)
+	}
+	m.lastNetRx, m.lastNetTx, m.lastNetTime = rx, tx, now
+}
+
+// trends returns the direction of each metric since the previous sample.
+// Percentages must move more than a point to count; network rates more
+// than 10% (and at least 1 KiB/s), since traffic is naturally bursty.
+func (m *Model) trends() components.Trends {
+	return components.Trends{
+		CPU:       components.TrendOf(m.history.CPU, 1),
+		Memory:    components.TrendOf(m.history.Memory, 1),
+		NetworkRx: components.TrendOf(m.history.Network.Rx, rateThreshold(m.history.Network.Rx)),
+		NetworkTx: components.TrendOf(m.history.Network.Tx, rateThreshold(m.history.Network.Tx)),
+	}
+}
+
+// rateThreshold returns the change a rate series must exceed to count as a
+// trend: 10% of the previous value, but no less than 1 KiB/s
+func rateThreshold(history []float64) float64 {
+	if len(history) < 2 {
+		return 0
+	}
+	threshold := history[len(history)-2] * 0.1
+	if threshold < 1024 {
+		threshold = 1024
+	}
+	return threshold
+}
+
 // tickMsg is sent on each tick
 type tickMsg time.Time
 
 // tickCmd returns a command that sends tick messages
 func (m *Model) tickCmd() tea.Cmd {
-	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(m.config.Refresh.Interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
+// startedMsg is sent once every collector has finished its first collection
+type startedMsg struct{}
+
+// startedCmd waits for the aggregator's first round of data
+func (m *Model) startedCmd() tea.Cmd {
+	started := m.aggregator.Started()
+	return func() tea.Msg {
+		<-started
+		return startedMsg{}
+	}
+}
+
 // dataMsg wraps new system data
 type dataMsg struct {
 	data *data.SystemData
@@ -0,0 +1,400 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components/metrics"
+)
+
+// Tab numbers, selected with the matching number key
+const (
+	TabDashboard = iota
+	TabCPU
+	TabMemory
+	TabDisk
+	TabNetwork
+	TabTemperature
+	TabLoad
+	TabCustom
+	TabGPU
+	TabContainers
+	TabConnections
+	TabPorts
+	TabServices
+	TabKubernetes
+	TabVMs
+)
+
+// tabCollectors maps each single-panel tab to the collector feeding it
+var tabCollectors = map[int]string{
+	TabCPU:         "cpu",
+	TabMemory:      "memory",
+	TabDisk:        "disk",
+	TabNetwork:     "network",
+	TabTemperature: "sensors",
+	TabLoad:        "host",
+	TabGPU:         "gpu",
+	TabContainers:  "containers",
+	TabConnections: "connections",
+	TabPorts:       "connections",
+	TabServices:    "services",
+	TabKubernetes:  "kubernetes",
+	TabVMs:         "vms",
+}
+
+// scrolledTabs are the tabs whose whole content scrolls when it is taller
+// than the terminal. The others scroll their own core list or table.
+var scrolledTabs = []int{TabMemory, TabDisk, TabNetwork, TabTemperature, TabLoad, TabCustom, TabGPU}
+
+// sidebarWidth is the width of the tab sidebar including padding
+const sidebarWidth = 10
+
+// tabsFor returns the sidebar tabs; the CUSTOM tab is only shown when
+// custom metrics are configured. GPU, containers, connections, ports,
+// services, Kubernetes and VMs come last so the custom tab keeps its number.
+func tabsFor(hasCustom bool) []components.Tab {
+	tabs := []components.Tab{
+		{Name: "DASH", Number: TabDashboard},
+		{Name: "CPU", Number: TabCPU},
+		{Name: "MEM", Number: TabMemory},
+		{Name: "DISK", Number: TabDisk},
+		{Name: "NET", Number: TabNetwork},
+		{Name: "TEMP", Number: TabTemperature},
+		{Name: "LOAD", Number: TabLoad},
+	}
+	if hasCustom {
+		tabs = append(tabs, components.Tab{Name: "CUSTOM", Number: TabCustom})
+	}
+	return append(tabs,
+		components.Tab{Name: "GPU", Number: TabGPU},
+		components.Tab{Name: "CTR", Number: TabContainers},
+		components.Tab{Name: "CONN", Number: TabConnections},
+		components.Tab{Name: "PORTS", Number: TabPorts},
+		components.Tab{Name: "SVC", Number: TabServices},
+		components.Tab{Name: "K8S", Number: TabKubernetes},
+		components.Tab{Name: "VM", Number: TabVMs},
+	)
+}
+
+// PanelTabs renders a single metric panel at full width for the
+// non-dashboard tabs
+type PanelTabs struct {
+	border lipgloss.Style
+	width  int
+	height int
+	active int // Tab rendered last, which the scroll keys move
+
+	// Scroll position of each tab in scrolledTabs, kept per tab
+	viewports map[int]*components.Viewport
+
+	cpuMetrics       *metrics.CPUMetrics
+	memoryMetrics    *metrics.MemoryMetrics
+	diskMetrics      *metrics.DiskMetrics
+	networkMetrics   *metrics.NetworkMetrics
+	tempMetrics      *metrics.TemperatureMetrics
+	loadMetrics      *metrics.LoadMetrics
+	customMetrics    *metrics.CustomMetrics
+	gpuMetrics       *metrics.GPUMetrics
+	containerMetrics *metrics.ContainerMetrics
+	bandwidthMetrics *metrics.BandwidthMetrics
+	connMetrics      *metrics.ConnectionMetrics
+	portMetrics      *metrics.PortMetrics
+	serviceMetrics   *metrics.ServiceMetrics
+	podMetrics       *metrics.PodMetrics
+	vmMetrics        *metrics.VMMetrics
+}
+
+// NewPanelTabs creates a new set of full-width metric panels
+func NewPanelTabs() *PanelTabs {
+	p := &PanelTabs{
+		cpuMetrics:       metrics.NewCPUMetrics(),
+		memoryMetrics:    metrics.NewMemoryMetrics(),
+		diskMetrics:      metrics.NewDiskMetrics(),
+		networkMetrics:   metrics.NewNetworkMetrics(),
+		tempMetrics:      metrics.NewTemperatureMetrics(),
+		loadMetrics:      metrics.NewLoadMetrics(),
+		customMetrics:    metrics.NewCustomMetrics(),
+		gpuMetrics:       metrics.NewGPUMetrics(),
+		containerMetrics: metrics.NewContainerMetrics(),
+		bandwidthMetrics: metrics.NewBandwidthMetrics(),
+		connMetrics:      metrics.NewConnectionMetrics(),
+		portMetrics:      metrics.NewPortMetrics(),
+		serviceMetrics:   metrics.NewServiceMetrics(),
+		podMetrics:       metrics.NewPodMetrics(),
+		vmMetrics:        metrics.NewVMMetrics(),
+		viewports:        make(map[int]*components.Viewport),
+	}
+	for _, tab := range scrolledTabs {
+		p.viewports[tab] = components.NewViewport()
+	}
+	p.SetTheme(components.DarkTheme())
+	return p
+}
+
+// SetTheme applies the theme to all panels
+func (p *PanelTabs) SetTheme(t *components.Theme) {
+	p.border = lipgloss.NewStyle().Foreground(t.Border)
+	p.cpuMetrics.SetTheme(t)
+	p.memoryMetrics.SetTheme(t)
+	p.diskMetrics.SetTheme(t)
+	p.networkMetrics.SetTheme(t)
+	p.tempMetrics.SetTheme(t)
+	p.loadMetrics.SetTheme(t)
+	p.customMetrics.SetTheme(t)
+	p.gpuMetrics.SetTheme(t)
+	p.containerMetrics.SetTheme(t)
+	p.bandwidthMetrics.SetTheme(t)
+	p.connMetrics.SetTheme(t)
+	p.portMetrics.SetTheme(t)
+	p.serviceMetrics.SetTheme(t)
+	p.podMetrics.SetTheme(t)
+	p.vmMetrics.SetTheme(t)
+	for _, viewport := range p.viewports {
+		viewport.SetTheme(t)
+	}
+}
+
+// SetWidth sets the available width
+func (p *PanelTabs) SetWidth(w int) {
+	p.width = w
+	// Border (2) + padding (2)
+	panelWidth := w - 4
+	p.cpuMetrics.SetWidth(panelWidth)
+	p.memoryMetrics.SetWidth(panelWidth)
+	p.diskMetrics.SetWidth(panelWidth)
+	p.networkMetrics.SetWidth(panelWidth)
+	p.tempMetrics.SetWidth(panelWidth)
+	p.loadMetrics.SetWidth(panelWidth)
+	p.customMetrics.SetWidth(panelWidth)
+	p.gpuMetrics.SetWidth(panelWidth)
+	p.containerMetrics.SetWidth(panelWidth)
+	p.bandwidthMetrics.SetWidth(panelWidth)
+	p.connMetrics.SetWidth(panelWidth)
+	p.portMetrics.SetWidth(panelWidth)
+	p.serviceMetrics.SetWidth(panelWidth)
+	p.podMetrics.SetWidth(panelWidth)
+	p.vmMetrics.SetWidth(panelWidth)
+}
+
+// SetHeight sets the available height
+func (p *PanelTabs) SetHeight(h int) {
+	p.height = h
+	// Border (2)
+	p.containerMetrics.SetHeight(h - 2)
+	p.connMetrics.SetHeight(h - 2)
+	p.portMetrics.SetHeight(h - 2)
+	p.serviceMetrics.SetHeight(h - 2)
+	p.podMetrics.SetHeight(h - 2)
+	p.vmMetrics.SetHeight(h - 2)
+	for _, viewport := range p.viewports {
+		viewport.SetHeight(h - 2)
+	}
+}
+
+// SetDiskLabels sets friendly display labels for disk mountpoints
+func (p *PanelTabs) SetDiskLabels(labels map[string]string) {
+	p.diskMetrics.SetLabels(labels)
+}
+
+// SetDiskInodeThresholds sets the inode usage percentages that color the disk panel
+func (p *PanelTabs) SetDiskInodeThresholds(warning, critical float64) {
+	p.diskMetrics.SetInodeThresholds(warning, critical)
+}
+
+// SetClockThresholds sets the clock offsets in milliseconds that color the load panel
+func (p *PanelTabs) SetClockThresholds(warning, critical float64) {
+	p.loadMetrics.SetClockThresholds(warning, critical)
+}
+
+// SetValueDisplay sets whether used/total values show as percent, size, or both
+func (p *PanelTabs) SetValueDisplay(values metrics.ValueDisplay) {
+	p.memoryMetrics.SetValueDisplay(values)
+	p.diskMetrics.SetValueDisplay(values)
+}
+
+// SetMemoryPressure sets the weights and levels of the memory pressure gauge
+func (p *PanelTabs) SetMemoryPressure(weights data.PressureWeights, warning, critical float64) {
+	p.memoryMetrics.SetPressure(weights, warning, critical)
+}
+
+// SetTrends sets the rising/falling arrows shown next to metric values
+func (p *PanelTabs) SetTrends(trends components.Trends) {
+	p.cpuMetrics.SetTrend(trends.CPU)
+	p.memoryMetrics.SetTrend(trends.Memory)
+	p.networkMetrics.SetTrends(trends.NetworkRx, trends.NetworkTx)
+}
+
+// SetLocation sets the timezone for timestamps shown in panels
+func (p *PanelTabs) SetLocation(loc *time.Location) {
+	p.loadMetrics.SetLocation(loc)
+}
+
+// SetNetworkAliases sets friendly display names for network interfaces
+func (p *PanelTabs) SetNetworkAliases(aliases map[string]string) {
+	p.networkMetrics.SetAliases(aliases)
+}
+
+// SetNetworkMinLinkSpeed sets the link speed in Mb/s below which a link is flagged
+func (p *PanelTabs) SetNetworkMinLinkSpeed(mbps int) {
+	p.networkMetrics.SetMinLinkSpeed(mbps)
+}
+
+// SetConntrackThresholds sets the conntrack table usage percentages that color the network panel
+func (p *PanelTabs) SetConntrackThresholds(warning, critical float64) {
+	p.networkMetrics.SetConntrackThresholds(warning, critical)
+}
+
+// SetCertThresholds sets the days left before expiry that color TLS certificates in the network panel
+func (p *PanelTabs) SetCertThresholds(warning, critical float64) {
+	p.networkMetrics.SetCertThresholds(warning, critical)
+}
+
+// SetCPUMode sets how total CPU usage is shown ("percent" or "cores")
+func (p *PanelTabs) SetCPUMode(mode string) {
+	p.cpuMetrics.SetMode(mode)
+}
+
+// SetCPUCoreTemps shows core temperatures on the CPU core rows
+func (p *PanelTabs) SetCPUCoreTemps(enabled bool) {
+	p.cpuMetrics.SetCoreTemps(enabled)
+}
+
+// SetCPUBreakdown enables the stacked CPU time bar
+func (p *PanelTabs) SetCPUBreakdown(enabled bool) {
+	p.cpuMetrics.SetBreakdown(enabled)
+}
+
+// SetHistory sets the historical data for sparklines
+func (p *PanelTabs) SetHistory(history *data.HistoryData) {
+	p.cpuMetrics.SetHistory(history.CPU, history.Times)
+	p.cpuMetrics.SetPowerHistory(history.Power)
+	p.loadMetrics.SetActivityHistory(history.Activity)
+	p.memoryMetrics.SetHistory(history.Memory, history.Times)
+	p.customMetrics.SetHistory(history.Custom)
+	p.networkMetrics.SetLatencyHistory(history.Latency, history.Loss)
+	p.networkMetrics.SetInterfaceHistory(history.Interfaces)
+	p.diskMetrics.SetIOHistory(history.Disks)
+	p.tempMetrics.SetHistory(history.Temps)
+}
+
+// ScrollUpCPU scrolls the CPU core list up
+func (p *PanelTabs) ScrollUpCPU() {
+	p.cpuMetrics.ScrollUp()
+}
+
+// ScrollDownCPU scrolls the CPU core list down
+func (p *PanelTabs) ScrollDownCPU() {
+	p.cpuMetrics.ScrollDown()
+}
+
+// ScrollUpConnections scrolls the connection and listening port tables up
+func (p *PanelTabs) ScrollUpConnections() {
+	p.connMetrics.ScrollUp()
+	p.portMetrics.ScrollUp()
+}
+
+// ScrollDownConnections scrolls the connection and listening port tables down
+func (p *PanelTabs) ScrollDownConnections() {
+	p.connMetrics.ScrollDown()
+	p.portMetrics.ScrollDown()
+}
+
+// ScrollUpServices scrolls the systemd service table up
+func (p *PanelTabs) ScrollUpServices() {
+	p.serviceMetrics.ScrollUp()
+}
+
+// ScrollDownServices scrolls the systemd service table down
+func (p *PanelTabs) ScrollDownServices() {
+	p.serviceMetrics.ScrollDown()
+}
+
+// ScrollUpKubernetes scrolls the pod table up
+func (p *PanelTabs) ScrollUpKubernetes() {
+	p.podMetrics.ScrollUp()
+}
+
+// ScrollDownKubernetes scrolls the pod table down
+func (p *PanelTabs) ScrollDownKubernetes() {
+	p.podMetrics.ScrollDown()
+}
+
+// ScrollUpVMs scrolls the virtual machine table up
+func (p *PanelTabs) ScrollUpVMs() {
+	p.vmMetrics.ScrollUp()
+}
+
+// ScrollDownVMs scrolls the virtual machine table down
+func (p *PanelTabs) ScrollDownVMs() {
+	p.vmMetrics.ScrollDown()
+}
+
+// ScrollUpPanel scrolls the content of the current tab up, if it is taller
+// than the panel
+func (p *PanelTabs) ScrollUpPanel() {
+	if viewport, ok := p.viewports[p.active]; ok {
+		viewport.ScrollUp()
+	}
+}
+
+// ScrollDownPanel scrolls the content of the current tab down, if it is
+// taller than the panel
+func (p *PanelTabs) ScrollDownPanel() {
+	if viewport, ok := p.viewports[p.active]; ok {
+		viewport.ScrollDown()
+	}
+}
+
+// Render returns the panel for the given tab
+func (p *PanelTabs) Render(tab int, systemData *data.SystemData) string {
+	p.active = tab
+	var content string
+	switch tab {
+	case TabCPU:
+		content = p.cpuMetrics.Render(systemData)
+	case TabMemory:
+		content = p.memoryMetrics.Render(systemData)
+	case TabDisk:
+		content = p.diskMetrics.Render(systemData)
+	case TabNetwork:
+		// Per-process bandwidth fills the space below the interfaces
+		content = p.networkMetrics.Render(systemData)
+		p.bandwidthMetrics.SetHeight(p.height - 2 - lipgloss.Height(content) - 1)
+		content = strings.TrimRight(content, "\n") + "\n\n" + p.bandwidthMetrics.Render(systemData)
+	case TabTemperature:
+		content = p.tempMetrics.Render(systemData)
+	case TabLoad:
+		content = p.loadMetrics.Render(systemData)
+	case TabCustom:
+		content = p.customMetrics.Render(systemData)
+	case TabGPU:
+		content = p.gpuMetrics.Render(systemData)
+	case TabContainers:
+		content = p.containerMetrics.Render(systemData)
+	case TabConnections:
+		content = p.connMetrics.Render(systemData)
+	case TabPorts:
+		content = p.portMetrics.Render(systemData)
+	case TabServices:
+		content = p.serviceMetrics.Render(systemData)
+	case TabKubernetes:
+		content = p.podMetrics.Render(systemData)
+	case TabVMs:
+		content = p.vmMetrics.Render(systemData)
+	}
+	if viewport, ok := p.viewports[tab]; ok {
+		viewport.SetContent(content)
+		content = viewport.Render()
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(p.border.GetForeground()).
+		Padding(0, 1).
+		Width(p.width - 2).
+		Render(content)
+}
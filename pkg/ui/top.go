@@ -0,0 +1,308 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ctcac00/metrics-tui/internal/data"
+	"github.com/ctcac00/metrics-tui/pkg/config"
+	"github.com/ctcac00/metrics-tui/pkg/ui/components"
+)
+
+// TopView renders a top-style single screen: summary bars followed by
+// a process table filling the remaining height
+type TopView struct {
+	label     lipgloss.Style
+	value     lipgloss.Style
+	muted     lipgloss.Style
+	width     int
+	height    int
+	cpuMode   string
+	breakdown bool
+
+	// Bar coloring levels, from the configured thresholds
+	cpuWarning, cpuCritical float64
+	memWarning, memCritical float64
+
+	// When the process list was last refreshed from collected process and
+	// GPU data
+	processUpdate time.Time
+	gpuUpdate     time.Time
+
+	progressBar  *components.ProgressBar
+	breakdownBar *components.CPUBreakdownBar
+	processList  *components.ProcessList
+}
+
+// NewTopView creates a new top-style view
+func NewTopView() *TopView {
+	defaults := config.DefaultConfig().Threshold
+	t := &TopView{
+		cpuWarning:   defaults.CPUWarning,
+		cpuCritical:  defaults.CPUCritical,
+		memWarning:   defaults.MemWarning,
+		memCritical:  defaults.MemCritical,
+		progressBar:  components.NewProgressBar(),
+		breakdownBar: components.NewCPUBreakdownBar(),
+		processList:  components.NewProcessList(),
+	}
+	t.SetTheme(components.DarkTheme())
+	return t
+}
+
+// SetTheme rebuilds the view styles from the given theme
+func (t *TopView) SetTheme(theme *components.Theme) {
+	t.label = lipgloss.NewStyle().Foreground(theme.Cyan).Bold(true)
+	t.value = lipgloss.NewStyle().Foreground(theme.Foreground)
+	t.muted = lipgloss.NewStyle().Foreground(theme.Comment)
+	t.progressBar.SetTheme(theme)
+	t.breakdownBar.SetTheme(theme)
+	t.processList.SetTheme(theme)
+}
+
+// SetWidth sets the view width
+func (t *TopView) SetWidth(w int) {
+	t.width = w
+	t.processList.SetWidth(w)
+
+	// Label (5) + spacing (2) + value column (24)
+	barWidth := w - 31
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	t.progressBar.SetWidth(barWidth)
+	t.breakdownBar.SetWidth(barWidth)
+}
+
+// SetHeight sets the view height
+func (t *TopView) SetHeight(h int) {
+	t.height = h
+}
+
+// SetThresholds sets the warning/critical levels used to color the CPU and
+// memory bars
+func (t *TopView) SetThresholds(thresholds config.ThresholdConfig) {
+	t.cpuWarning = thresholds.CPUWarning
+	t.cpuCritical = thresholds.CPUCritical
+	t.memWarning = thresholds.MemWarning
+	t.memCritical = thresholds.MemCritical
+}
+
+// SetCPUMode sets how total CPU usage is shown ("percent" or "cores")
+func (t *TopView) SetCPUMode(mode string) {
+	t.cpuMode = mode
+}
+
+// SetCPUBreakdown enables the stacked CPU time bar
+func (t *TopView) SetCPUBreakdown(enabled bool) {
+	t.breakdown = enabled
+}
+
+// SetProcessThresholds sets the CPU% and MEM% coloring levels of the
+// process table
+func (t *TopView) SetProcessThresholds(cpuWarning, cpuCritical, memWarning, memCritical float64) {
+	t.processList.SetCPUThresholds(cpuWarning, cpuCritical)
+	t.processList.SetMemThresholds(memWarning, memCritical)
+}
+
+// SetNormalizeProcessCPU shows process CPU% relative to all cores
+func (t *TopView) SetNormalizeProcessCPU(normalize bool) {
+	t.processList.SetNormalizeCPU(normalize)
+}
+
+// SetProcessSort sets the column the process table is ordered by ("cpu",
+// "memory" or "io")
+func (t *TopView) SetProcessSort(sortBy string) {
+	t.processList.SetSort(sortBy)
+}
+
+// NextProcessSort switches the process table to the next ordering and
+// returns it
+func (t *TopView) NextProcessSort() string {
+	return t.processList.NextSort()
+}
+
+// SetProcesses sets the processes shown in the table
+func (t *TopView) SetProcesses(procs []components.ProcessInfo) {
+	t.processList.SetProcesses(procs)
+}
+
+// SetProcessFilter narrows the process table to processes whose name, user
+// or PID contain filter; editing shows a cursor while it's typed
+func (t *TopView) SetProcessFilter(filter string, editing bool) {
+	t.processList.SetFilter(filter, editing)
+}
+
+// SelectedProcess returns the highlighted process in the table, if any
+func (t *TopView) SelectedProcess() (components.ProcessInfo, bool) {
+	return t.processList.SelectedProcess()
+}
+
+// ScrollUp moves the process table selection up
+func (t *TopView) ScrollUp() {
+	t.processList.ScrollUp()
+}
+
+// ScrollDown moves the process table selection down
+func (t *TopView) ScrollDown() {
+	t.processList.ScrollDown()
+}
+
+// Render returns the rendered top view
+func (t *TopView) Render(systemData *data.SystemData) string {
+	if systemData == nil {
+		return "Loading system data..."
+	}
+
+	if procs := systemData.Processes; procs != nil && t.processesChanged(systemData) {
+		infos := make([]components.ProcessInfo, 0, len(procs.Processes))
+		for _, p := range procs.Processes {
+			infos = append(infos, components.ProcessInfo{
+				PID:         int(p.PID),
+				Name:        p.Name,
+				User:        p.User,
+				CPU:         p.CPU,
+				Memory:      p.Memory,
+				Command:     p.Command,
+				ReadPerSec:  p.ReadPerSec,
+				WritePerSec: p.WritePerSec,
+			})
+		}
+		t.processList.SetShowGPU(hasNVIDIA(systemData.GPU))
+		t.processList.SetProcesses(joinGPUProcesses(infos, systemData.GPU))
+	}
+
+	summary := t.renderSummary(systemData)
+
+	// Give the process table whatever height the summary leaves over
+	summaryLines := len(strings.Split(summary, "\n"))
+	t.processList.SetHeight(t.height - summaryLines - 1)
+
+	return summary + "\n\n" + t.processList.Render(systemData)
+}
+
+// processesChanged reports whether process or GPU data was collected since
+// the process list was last built, and notes their collection times
+func (t *TopView) processesChanged(systemData *data.SystemData) bool {
+	var gpuUpdate time.Time
+	if systemData.GPU != nil {
+		gpuUpdate = systemData.GPU.LastUpdate
+	}
+	if systemData.Processes.LastUpdate.Equal(t.processUpdate) && gpuUpdate.Equal(t.gpuUpdate) {
+		return false
+	}
+	t.processUpdate = systemData.Processes.LastUpdate
+	t.gpuUpdate = gpuUpdate
+	return true
+}
+
+// hasNVIDIA reports whether an NVIDIA GPU, the only kind with per-process
+// usage, was found
+func hasNVIDIA(gpu *data.GPUMetrics) bool {
+	if gpu == nil {
+		return false
+	}
+	for _, stat := range gpu.GPUs {
+		if stat.Vendor == "nvidia" {
+			return true
+		}
+	}
+	return false
+}
+
+// joinGPUProcesses fills in the GPU usage of listed processes by PID and
+// appends GPU processes the process collector didn't rank, so GPU hogs
+// show up even when their CPU and memory use is modest
+func joinGPUProcesses(infos []components.ProcessInfo, gpu *data.GPUMetrics) []components.ProcessInfo {
+	if gpu == nil || len(gpu.Processes) == 0 {
+		return infos
+	}
+	index := make(map[int]int, len(infos))
+	for i, info := range infos {
+		index[info.PID] = i
+	}
+	for _, p := range gpu.Processes {
+		i, ok := index[int(p.PID)]
+		if !ok {
+			infos = append(infos, components.ProcessInfo{PID: int(p.PID), Name: p.Name})
+			i = len(infos) - 1
+		}
+		infos[i].GPU = max(p.Utilization, 0)
+		infos[i].GPUMemory = p.MemoryUsed
+	}
+	return infos
+}
+
+// renderSummary renders the CPU, memory, swap, and load summary lines
+func (t *TopView) renderSummary(systemData *data.SystemData) string {
+	var lines []string
+
+	if cpu := systemData.CPU; cpu != nil {
+		detail := fmt.Sprintf("%5.1f%% of %.4g cores", cpu.Total, cpu.CapacityCores())
+		if t.cpuMode == "cores" {
+			busy := cpu.Total / 100 * cpu.CapacityCores()
+			detail = fmt.Sprintf("%.1f of %.4g cores busy", busy, cpu.CapacityCores())
+		}
+		if cpu.CgroupCores > 0 {
+			detail += " (cgroup)"
+		}
+		if t.breakdown && cpu.Breakdown != nil {
+			lines = append(lines, fmt.Sprintf("%s  %s %s",
+				t.label.Width(5).Render("CPU"),
+				t.breakdownBar.Render(cpu.Breakdown),
+				t.value.Render(detail),
+			))
+			lines = append(lines, fmt.Sprintf("%s  %s", t.label.Width(5).Render(""), t.breakdownBar.RenderLegend(cpu.Breakdown)))
+		} else {
+			lines = append(lines, t.renderBarLine("CPU", cpu.Total, t.cpuWarning, t.cpuCritical, detail))
+		}
+	} else {
+		lines = append(lines, t.renderPendingLine("CPU"))
+	}
+
+	if systemData.Memory != nil {
+		mem := systemData.Memory
+		detail := fmt.Sprintf("%s / %s", components.FormatBytes(mem.Used), components.FormatBytes(mem.Total))
+		if mem.Cgroup {
+			detail += " (cgroup)"
+		}
+		lines = append(lines, t.renderBarLine("Mem", mem.UsedPercent, t.memWarning, t.memCritical, detail))
+
+		// Swap has no threshold setting; use the Memory panel's levels
+		if mem.Swap.Total > 0 {
+			lines = append(lines, t.renderBarLine("Swap", mem.Swap.UsedPercent, 50, 80,
+				fmt.Sprintf("%s / %s", components.FormatBytes(mem.Swap.Used), components.FormatBytes(mem.Swap.Total))))
+		}
+	} else {
+		lines = append(lines, t.renderPendingLine("Mem"))
+	}
+
+	if systemData.Host != nil && systemData.Host.LoadAvg != nil {
+		load := systemData.Host.LoadAvg
+		lines = append(lines, fmt.Sprintf("%s  %s",
+			t.label.Width(5).Render("Load"),
+			t.value.Render(fmt.Sprintf("%.2f %.2f %.2f", load.Load1, load.Load5, load.Load15)),
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderBarLine renders a single labeled progress bar line
+func (t *TopView) renderBarLine(label string, percent, warning, critical float64, detail string) string {
+	return fmt.Sprintf("%s  %s %s",
+		t.label.Width(5).Render(label),
+		t.progressBar.RenderDynamic(percent, warning, critical),
+		t.value.Render(detail),
+	)
+}
+
+// renderPendingLine renders a placeholder line for metrics not yet collected
+func (t *TopView) renderPendingLine(label string) string {
+	return fmt.Sprintf("%s  %s",
+		t.label.Width(5).Render(label),
+		t.muted.Render("Loading..."),
+	)
+}